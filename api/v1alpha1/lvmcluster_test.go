@@ -13,6 +13,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 )
 
@@ -77,7 +78,7 @@ var _ = Describe("webhook acceptance tests", func() {
 		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 	})
 
-	It("duplicate LVMClusters get rejected", func(ctx SpecContext) {
+	It("LVMClusters with the same deviceClass get rejected", func(ctx SpecContext) {
 		generatedName := generateUniqueNameForTestCase(ctx)
 		GinkgoT().Setenv(cluster.OperatorNamespaceEnvVar, generatedName)
 		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: generatedName}}
@@ -102,7 +103,7 @@ var _ = Describe("webhook acceptance tests", func() {
 
 		statusError := &k8serrors.StatusError{}
 		Expect(errors.As(err, &statusError)).To(BeTrue())
-		Expect(statusError.Status().Message).To(ContainSubstring(ErrDuplicateLVMCluster.Error()))
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrDeviceClassClaimedByOtherLVMCluster.Error()))
 
 		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 	},
@@ -112,6 +113,23 @@ var _ = Describe("webhook acceptance tests", func() {
 		FlakeAttempts(3),
 	)
 
+	It("LVMClusters with distinct deviceClasses and devices can coexist", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{Paths: []DevicePath{"/dev/sda"}}
+		Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+		other := defaultClusterTemplate.DeepCopy()
+		other.SetName(fmt.Sprintf("%s-other", resource.GetName()))
+		other.SetNamespace(resource.GetNamespace())
+		other.Spec.Storage.DeviceClasses[0].Name = "other-device-class"
+		other.Spec.Storage.DeviceClasses[0].Default = false
+		other.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{Paths: []DevicePath{"/dev/sdb"}}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+	})
+
 	It("namespace cannot be looked up via ENV", func(ctx SpecContext) {
 		generatedName := generateUniqueNameForTestCase(ctx)
 		inacceptableNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: generatedName}}
@@ -371,6 +389,36 @@ var _ = Describe("webhook acceptance tests", func() {
 		Expect(k8sClient.Delete(ctx, updated)).To(Succeed())
 	})
 
+	It("the implicit default deviceClass of another LVMCluster cannot be taken over", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].Default = false
+		resource.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{Paths: []DevicePath{"/dev/sda"}}
+		Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+
+		other := defaultClusterTemplate.DeepCopy()
+		other.SetName(fmt.Sprintf("%s-other", resource.GetName()))
+		other.SetNamespace(resource.GetNamespace())
+		other.Spec.Storage.DeviceClasses[0].Name = "other-device-class"
+		other.Spec.Storage.DeviceClasses[0].Default = true
+		other.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{Paths: []DevicePath{"/dev/sdb"}}
+
+		err := k8sClient.Create(ctx, other)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrOnlyOneDefaultDeviceClassAllowed.Error()))
+
+		other.Spec.Storage.DeviceClasses[0].Default = false
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, other)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+	},
+		// the first LVMCluster might not yet be visible to the webhook
+		FlakeAttempts(3),
+	)
+
 	It("two default device classes are not allowed", func(ctx SpecContext) {
 		resource := defaultLVMClusterInUniqueNamespace(ctx)
 		resource.Spec.Storage.DeviceClasses = append(resource.Spec.Storage.DeviceClasses, DeviceClass{
@@ -501,7 +549,7 @@ var _ = Describe("webhook acceptance tests", func() {
 		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 	})
 
-	It("updating NodeSelector is not allowed", func(ctx SpecContext) {
+	It("updating NodeSelector is allowed", func(ctx SpecContext) {
 		resource := defaultLVMClusterInUniqueNamespace(ctx)
 		Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 
@@ -519,12 +567,7 @@ var _ = Describe("webhook acceptance tests", func() {
 			},
 		}}
 
-		err := k8sClient.Update(ctx, updated)
-		Expect(err).To(HaveOccurred())
-		Expect(err).To(Satisfy(k8serrors.IsForbidden))
-		statusError := &k8serrors.StatusError{}
-		Expect(errors.As(err, &statusError)).To(BeTrue())
-		Expect(statusError.Status().Message).To(ContainSubstring(ErrNodeSelectorCannotBeChanged.Error()))
+		Expect(k8sClient.Update(ctx, updated)).To(Succeed())
 
 		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 	})
@@ -707,6 +750,126 @@ var _ = Describe("webhook acceptance tests", func() {
 		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
 	})
 
+	It("partition free space combined with force wipe is forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{
+			Paths:                             []DevicePath{"/dev/newpath"},
+			ForceWipeDevicesAndDestroyAllData: ptr.To(true),
+			PartitionFreeSpace:                ptr.To(true),
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrPartitionFreeSpaceWithForceWipe.Error()))
+	})
+
+	It("partition free space without explicit device paths is forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].DeviceSelector = &DeviceSelector{
+			PartitionFreeSpace: ptr.To(true),
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrPartitionFreeSpaceWithoutPaths.Error()))
+	})
+
+	It("alert thresholds with a critical percentage below the near full percentage are forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].Alerts = &DeviceClassAlerts{
+			ThinPoolDataUsage: &UsageAlertConfig{NearFullPercent: ptr.To(90)},
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrAlertThresholdsInvalid.Error()))
+	})
+
+	It("custom alert thresholds are accepted on create", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.Storage.DeviceClasses[0].Alerts = &DeviceClassAlerts{
+			ThinPoolDataUsage: &UsageAlertConfig{NearFullPercent: ptr.To(90), CriticalPercent: ptr.To(95)},
+			VolumeGroupStatus: &AlertConfig{Enabled: ptr.To(false)},
+		}
+		Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+	})
+
+	It("vg-manager memory limit below its request is forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.VGManager = &VGManagerConfig{
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("32Mi")},
+			},
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrVGManagerConfigInvalid.Error()))
+	})
+
+	It("vg-manager environment variables identifying the pod are forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.VGManager = &VGManagerConfig{
+			Env: []corev1.EnvVar{{Name: "NODE_NAME", Value: "node"}},
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrVGManagerConfigInvalid.Error()))
+	})
+
+	It("vg-manager rollout without a positive progress deadline is forbidden", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.VGManager = &VGManagerConfig{
+			UpdateStrategy: &VGManagerUpdateStrategy{ProgressDeadline: &metav1.Duration{}},
+		}
+
+		err := k8sClient.Create(ctx, resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Satisfy(k8serrors.IsForbidden))
+
+		statusError := &k8serrors.StatusError{}
+		Expect(errors.As(err, &statusError)).To(BeTrue())
+		Expect(statusError.Status().Message).To(ContainSubstring(ErrVGManagerConfigInvalid.Error()))
+	})
+
+	It("vg-manager configuration is accepted on create", func(ctx SpecContext) {
+		resource := defaultLVMClusterInUniqueNamespace(ctx)
+		resource.Spec.VGManager = &VGManagerConfig{
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+			},
+			PriorityClassName: "system-node-critical",
+			UpdateStrategy:    &VGManagerUpdateStrategy{MaxUnavailable: ptr.To(intstr.FromString("25%"))},
+			LogLevel:          ptr.To[int32](2),
+			Env:               []corev1.EnvVar{{Name: "GOMAXPROCS", Value: "4"}},
+		}
+		Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+	})
+
 	It("chunk size change before create", func(ctx SpecContext) {
 		resource := defaultLVMClusterInUniqueNamespace(ctx)
 		resource.Spec.Storage.DeviceClasses[0].ThinPoolConfig.ChunkSize = ptr.To(k8sresource.MustParse("256Ki"))
@@ -17,10 +17,13 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // LVMClusterSpec defines the desired state of LVMCluster
@@ -33,8 +36,110 @@ type LVMClusterSpec struct {
 	// Storage contains the device class configuration for local storage devices.
 	// +Optional
 	Storage Storage `json:"storage,omitempty"`
+	// VGManager configures the vg-manager DaemonSet that manages the volume groups on the nodes.
+	// As the DaemonSet is shared by all LVMClusters, only the configuration of the oldest LVMCluster is used.
+	// +optional
+	VGManager *VGManagerConfig `json:"vgManager,omitempty"`
+	// DriftPolicies configure how changes made by others to the resources managed by LVMS are handled.
+	// Such drift is reported through the ResourcesInSync condition, and overwritten unless the policy of the resource is Report.
+	// As the CSIDriver, the SecurityContextConstraints and the vg-manager DaemonSet are shared by all LVMClusters,
+	// only the policies of the oldest LVMCluster are used for them.
+	// +optional
+	DriftPolicies *DriftPolicies `json:"driftPolicies,omitempty"`
+}
+
+// DriftPolicy configures how changes made by others to a resource managed by LVMS are handled.
+// +kubebuilder:validation:Enum=Enforce;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce overwrites the changes with the desired state of the resource.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+	// DriftPolicyReport leaves the changes in place and only reports them.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// DriftPolicies configure the drift policy of every kind of resource managed by LVMS. All of them default to Enforce.
+type DriftPolicies struct {
+	// StorageClass is the drift policy of the StorageClasses of the device classes.
+	// +optional
+	StorageClass DriftPolicy `json:"storageClass,omitempty"`
+
+	// CSIDriver is the drift policy of the TopoLVM CSIDriver.
+	// +optional
+	CSIDriver DriftPolicy `json:"csiDriver,omitempty"`
+
+	// SecurityContextConstraints is the drift policy of the SecurityContextConstraints of vg-manager on OpenShift.
+	// +optional
+	SecurityContextConstraints DriftPolicy `json:"securityContextConstraints,omitempty"`
+
+	// VolumeSnapshotClass is the drift policy of the VolumeSnapshotClasses of the thin provisioned device classes.
+	// +optional
+	VolumeSnapshotClass DriftPolicy `json:"volumeSnapshotClass,omitempty"`
+
+	// DaemonSet is the drift policy of the vg-manager DaemonSet.
+	// +optional
+	DaemonSet DriftPolicy `json:"daemonSet,omitempty"`
+}
+
+// VGManagerConfig configures the resources, the scheduling and the logging of the vg-manager DaemonSet.
+type VGManagerConfig struct {
+	// Resources are the compute resources of the vg-manager container.
+	// Requests that are not set default to 5m CPU and 45Mi memory, and there are no limits by default.
+	// The memory limit of the Go runtime (GOMEMLIMIT) is set to the memory limit, or to the memory request if there is no limit.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the priority class of the vg-manager pods. Defaults to openshift-user-critical.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// UpdateStrategy configures how changes of the vg-manager DaemonSet are rolled out to the nodes.
+	// +optional
+	UpdateStrategy *VGManagerUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// NodeSelector restricts vg-manager to the nodes with the given labels, in addition to the node selectors of the device classes.
+	// The volume groups on nodes that do not match are no longer managed.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// LogLevel is the verbosity of the logs of vg-manager. 0 only logs informational messages,
+	// higher levels log debug messages of increasing verbosity. It overrides the log level passed down by the operator.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	LogLevel *int32 `json:"logLevel,omitempty"`
+
+	// Env are additional environment variables of the vg-manager container.
+	// They override the variables of the same name set by LVMS, like GOMEMLIMIT or GOMAXPROCS,
+	// except for NODE_NAME, NAMESPACE and NAME, which cannot be set.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// VGManagerUpdateStrategy configures the rollout of the vg-manager DaemonSet.
+// Changes are rolled out in batches of nodes. The next batch is only updated once vg-manager on every node
+// of the current batch is ready, reports its volume groups as ready and registered the CSI driver with the kubelet.
+type VGManagerUpdateStrategy struct {
+	// MaxUnavailable is the maximum number or percentage of nodes on which vg-manager is updated at the same time.
+	// Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// ProgressDeadline is the time vg-manager has to become healthy again on an updated node.
+	// If it does not, the rollout is halted and the LVMCluster is degraded until the node recovers
+	// or another change is rolled out. Defaults to 15m.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
 }
 
+var VGManagerProgressDeadlineDefault = metav1.Duration{Duration: 15 * time.Minute}
+
 type ThinPoolConfig struct {
 	// Name specifies a name for the thin pool.
 	// +kubebuilder:validation:Required
@@ -167,11 +272,87 @@ type DeviceClass struct {
 	// +optional
 	DeviceDiscoveryPolicy *DeviceDiscoveryPolicySpec `json:"deviceDiscoveryPolicy,omitempty"`
 
+	// DeviceHealthPolicy excludes unhealthy devices from being added to the volume group of this device class.
+	// The health of the devices is always collected and reported, it is only used to exclude devices if this is set.
+	// +optional
+	DeviceHealthPolicy *DeviceHealthPolicy `json:"deviceHealthPolicy,omitempty"`
+
+	// OrphanedLogicalVolumePolicy configures how logical volumes in the volume group of this device class
+	// that are not backed by a TopoLVM LogicalVolume are handled. They are only reported if this is not set.
+	// +optional
+	OrphanedLogicalVolumePolicy *OrphanedLogicalVolumePolicy `json:"orphanedLogicalVolumePolicy,omitempty"`
+
 	// StorageClassOptions allows customization of the StorageClass created for this device class.
 	// +optional
 	StorageClassOptions *StorageClassOptions `json:"storageClassOptions,omitempty"`
+
+	// Alerts configures the alerts that LVMS creates for the device class when Prometheus is available in the cluster.
+	// All alerts are enabled with their default thresholds and durations unless configured otherwise.
+	// +optional
+	Alerts *DeviceClassAlerts `json:"alerts,omitempty"`
 }
 
+// DeviceClassAlerts configures the alerts on the capacity and the health of a device class.
+type DeviceClassAlerts struct {
+	// VolumeGroupUsage configures the alerts on the used capacity of the volume group.
+	// +optional
+	VolumeGroupUsage *UsageAlertConfig `json:"volumeGroupUsage,omitempty"`
+
+	// ThinPoolDataUsage configures the alerts on the data usage of the thin pool.
+	// It is only used if the device class has a ThinPoolConfig.
+	// +optional
+	ThinPoolDataUsage *UsageAlertConfig `json:"thinPoolDataUsage,omitempty"`
+
+	// ThinPoolMetadataUsage configures the alerts on the metadata usage of the thin pool.
+	// It is only used if the device class has a ThinPoolConfig.
+	// +optional
+	ThinPoolMetadataUsage *UsageAlertConfig `json:"thinPoolMetadataUsage,omitempty"`
+
+	// VolumeGroupStatus configures the alerts on volume groups that are Degraded or Failed on a node.
+	// +optional
+	VolumeGroupStatus *AlertConfig `json:"volumeGroupStatus,omitempty"`
+
+	// MissingPhysicalVolumes configures the alert on physical volumes of the volume group that are missing on a node.
+	// +optional
+	MissingPhysicalVolumes *AlertConfig `json:"missingPhysicalVolumes,omitempty"`
+}
+
+// AlertConfig configures whether an alert is created and how long its condition has to hold before it fires.
+type AlertConfig struct {
+	// Enabled is a flag to create the alert. Alerts are enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// For is the duration the condition of the alert has to hold before the alert fires. Defaults to 5m.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+}
+
+// UsageAlertConfig configures a pair of warning and critical alerts on a usage percentage.
+type UsageAlertConfig struct {
+	AlertConfig `json:",inline"`
+
+	// NearFullPercent is the usage percentage above which the warning alert fires. Defaults to 75.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	NearFullPercent *int `json:"nearFullPercent,omitempty"`
+
+	// CriticalPercent is the usage percentage above which the critical alert fires. Defaults to 85.
+	// It has to be greater than NearFullPercent.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CriticalPercent *int `json:"criticalPercent,omitempty"`
+}
+
+const (
+	AlertNearFullPercentDefault = 75
+	AlertCriticalPercentDefault = 85
+)
+
+var AlertForDefault = metav1.Duration{Duration: 5 * time.Minute}
+
 // StorageClassOptions defines optional overrides for the StorageClass generated by LVMS for a device class.
 type StorageClassOptions struct {
 	// ReclaimPolicy sets the reclaim policy for PVs provisioned by this device class.
@@ -224,6 +405,63 @@ type DeviceSelector struct {
 	// Force wipe the devices only when you know that they do not contain any important data.
 	// +optional
 	ForceWipeDevicesAndDestroyAllData *bool `json:"forceWipeDevicesAndDestroyAllData,omitempty"`
+
+	// PartitionFreeSpace is a flag to use the unpartitioned free space of selected devices that already carry a GPT partition table.
+	// When enabled, a new GPT partition spanning the largest free region of the device is created and used as the physical volume.
+	// Existing partitions are never modified, and devices holding the root filesystem are refused.
+	// This option cannot be combined with ForceWipeDevicesAndDestroyAllData and requires explicit device paths.
+	// +optional
+	PartitionFreeSpace *bool `json:"partitionFreeSpace,omitempty"`
+}
+
+// DeviceHealthPolicy configures the exclusion of devices based on their health as reported by SMART.
+// A device is unhealthy if it failed its SMART overall-health self-assessment or exceeds one of the thresholds.
+// Devices whose health could not be collected are not excluded, and devices that are already part of the volume group
+// are never removed from it.
+type DeviceHealthPolicy struct {
+	// MaxReallocatedSectors is the maximum number of reallocated sectors of an ATA or SCSI device.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReallocatedSectors *int64 `json:"maxReallocatedSectors,omitempty"`
+
+	// MaxMediaErrors is the maximum number of unrecovered media and data integrity errors of an NVMe device.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMediaErrors *int64 `json:"maxMediaErrors,omitempty"`
+
+	// MaxPercentageUsed is the maximum estimate of the used endurance of an NVMe device in percent.
+	// The estimate can exceed 100 once the rated endurance of the device is used up.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	MaxPercentageUsed *int64 `json:"maxPercentageUsed,omitempty"`
+}
+
+// OrphanedLogicalVolumeAction is the action taken for orphaned logical volumes.
+type OrphanedLogicalVolumeAction string
+
+const (
+	// OrphanedLogicalVolumeActionReport only reports orphaned logical volumes in the LVMVolumeGroupNodeStatus.
+	OrphanedLogicalVolumeActionReport OrphanedLogicalVolumeAction = "Report"
+	// OrphanedLogicalVolumeActionDelete reports orphaned logical volumes and deletes them after the grace period.
+	OrphanedLogicalVolumeActionDelete OrphanedLogicalVolumeAction = "Delete"
+)
+
+// OrphanedLogicalVolumePolicy configures the handling of orphaned logical volumes. A logical volume is orphaned
+// if it was provisioned by TopoLVM, but no TopoLVM LogicalVolume on the node refers to it anymore,
+// e.g. because the LogicalVolume was removed while the node was unavailable.
+type OrphanedLogicalVolumePolicy struct {
+	// Action is the action taken for orphaned logical volumes.
+	// Report only reports them, Delete deletes them once they were orphaned for longer than the grace period.
+	// +kubebuilder:validation:Enum=Report;Delete
+	// +kubebuilder:default=Report
+	// +optional
+	Action OrphanedLogicalVolumeAction `json:"action,omitempty"`
+
+	// GracePeriod is the time an orphaned logical volume is retained after it was first found to be orphaned
+	// before it is deleted. Defaults to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 type DevicePath string
@@ -274,6 +512,12 @@ const (
 
 	// VolumeGroupsReady indicates whether the volume groups maintained by the operator are in a ready state.
 	VolumeGroupsReady = "VolumeGroupsReady"
+
+	// VGManagerRollout indicates whether the vg-manager DaemonSet is rolled out to all nodes without issues.
+	VGManagerRollout = "VGManagerRollout"
+
+	// ResourcesInSync indicates whether the resources maintained by the operator match their desired state.
+	ResourcesInSync = "ResourcesInSync"
 )
 
 // DeviceClassStatus defines the observed status of the deviceclass across all nodes
@@ -282,6 +526,15 @@ type DeviceClassStatus struct {
 	Name string `json:"name,omitempty"`
 	// NodeStatus tells if the deviceclass was created on the node
 	NodeStatus []NodeStatus `json:"nodeStatus,omitempty"`
+	// Size is the total capacity of the volume groups of the deviceclass across all nodes
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the volume groups of the deviceclass across all nodes
+	// that is not allocated to any logical volume
+	Free *resource.Quantity `json:"free,omitempty"`
+	// ThinPoolSize is the total capacity of the thin pools of the deviceclass across all nodes
+	ThinPoolSize *resource.Quantity `json:"thinPoolSize,omitempty"`
+	// ThinPoolUsed is the data space in use in the thin pools of the deviceclass across all nodes
+	ThinPoolUsed *resource.Quantity `json:"thinPoolUsed,omitempty"`
 }
 
 type Storage struct {
@@ -301,6 +554,7 @@ type NodeStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // LVMCluster is the Schema for the lvmclusters API
 type LVMCluster struct {
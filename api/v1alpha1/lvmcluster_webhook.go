@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/openshift/lvm-operator/v4/internal/cluster"
@@ -28,7 +30,11 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/labels"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	corev1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -55,12 +61,16 @@ var (
 	ErrOnlyOneDefaultDeviceClassAllowed                      = errors.New("only one default deviceClass is allowed")
 	ErrPathsOrOptionalPathsMandatoryWithNonNilDeviceSelector = errors.New("either paths or optionalPaths must be specified when DeviceSelector is specified")
 	ErrEmptyPathsWithMultipleDeviceClasses                   = errors.New("path list should not be empty when there are multiple deviceClasses")
-	ErrDuplicateLVMCluster                                   = errors.New("duplicate LVMClusters are not allowed, remove the old LVMCluster or work with the existing instance")
+	ErrDeviceClassClaimedByOtherLVMCluster                   = errors.New("the deviceClass name is already used by another LVMCluster")
+	ErrDevicesClaimedByOtherLVMCluster                       = errors.New("the devices are already claimed by another LVMCluster on the same node")
 	ErrThinPoolConfigCannotBeChanged                         = errors.New("ThinPoolConfig can not be changed")
 	ErrThinPoolMetadataSizeCanOnlyBeIncreased                = errors.New("thin pool metadata size can only be increased")
-	ErrNodeSelectorCannotBeChanged                           = errors.New("NodeSelector can not be changed")
 	ErrDevicePathsCannotBeAddedInUpdate                      = errors.New("device paths can not be added after a device class has been initialized")
 	ErrForceWipeOptionCannotBeChanged                        = errors.New("ForceWipeDevicesAndDestroyAllData can not be changed")
+	ErrPartitionFreeSpaceWithForceWipe                       = errors.New("PartitionFreeSpace can not be combined with ForceWipeDevicesAndDestroyAllData")
+	ErrPartitionFreeSpaceWithoutPaths                        = errors.New("PartitionFreeSpace requires explicit device paths in paths or optionalPaths")
+	ErrAlertThresholdsInvalid                                = errors.New("the critical percentage of an alert must be greater than its near full percentage")
+	ErrVGManagerConfigInvalid                                = errors.New("the vg-manager configuration is invalid")
 )
 
 //+kubebuilder:webhook:path=/validate-lvm-topolvm-io-v1alpha1-lvmcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=lvm.topolvm.io,resources=lvmclusters,verbs=create;update,versions=v1alpha1,name=vlvmcluster.kb.io,admissionReviewVersions=v1
@@ -86,12 +96,10 @@ func (v *lvmClusterValidator) ValidateCreate(ctx context.Context, l *LVMCluster)
 		)
 	}
 
-	existing := &LVMClusterList{}
-	if err := v.List(ctx, existing, &client.ListOptions{Limit: 1, Namespace: l.GetNamespace()}); err != nil {
-		return warnings, fmt.Errorf("could not verify that LVMCluster was not already created %w", err)
-	} else if len(existing.Items) > 0 {
-		return warnings, fmt.Errorf("LVMCluster exists at %q: %w",
-			client.ObjectKeyFromObject(&existing.Items[0]), ErrDuplicateLVMCluster)
+	conflictWarnings, err := v.verifyNoConflictWithOtherLVMClusters(ctx, l)
+	warnings = append(warnings, conflictWarnings...)
+	if err != nil {
+		return warnings, err
 	}
 
 	deviceClassWarnings, err := v.verifyDeviceClass(l)
@@ -100,6 +108,11 @@ func (v *lvmClusterValidator) ValidateCreate(ctx context.Context, l *LVMCluster)
 		return warnings, err
 	}
 
+	err = v.verifyPartitionFreeSpace(l)
+	if err != nil {
+		return warnings, err
+	}
+
 	pathWarnings, err := v.verifyPathsAreNotEmpty(l)
 	warnings = append(warnings, pathWarnings...)
 	if err != nil {
@@ -121,6 +134,12 @@ func (v *lvmClusterValidator) ValidateCreate(ctx context.Context, l *LVMCluster)
 		return warnings, err
 	}
 
+	alertWarnings, err := v.verifyAlerts(l)
+	warnings = append(warnings, alertWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
 	err = v.verifyChunkSize(l)
 	if err != nil {
 		return warnings, err
@@ -141,11 +160,17 @@ func (v *lvmClusterValidator) ValidateCreate(ctx context.Context, l *LVMCluster)
 		return warnings, err
 	}
 
+	vgManagerWarnings, err := v.verifyVGManager(ctx, l)
+	warnings = append(warnings, vgManagerWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type
-func (v *lvmClusterValidator) ValidateUpdate(_ context.Context, oldLVMCluster, l *LVMCluster) (admission.Warnings, error) {
+func (v *lvmClusterValidator) ValidateUpdate(ctx context.Context, oldLVMCluster, l *LVMCluster) (admission.Warnings, error) {
 	lvmclusterlog.Info("validate update", "name", l.Name)
 	warnings := admission.Warnings{}
 
@@ -155,6 +180,11 @@ func (v *lvmClusterValidator) ValidateUpdate(_ context.Context, oldLVMCluster, l
 		return warnings, err
 	}
 
+	err = v.verifyPartitionFreeSpace(l)
+	if err != nil {
+		return warnings, err
+	}
+
 	pathWarnings, err := v.verifyPathsAreNotEmpty(l)
 	warnings = append(warnings, pathWarnings...)
 	if err != nil {
@@ -176,12 +206,30 @@ func (v *lvmClusterValidator) ValidateUpdate(_ context.Context, oldLVMCluster, l
 		return warnings, err
 	}
 
+	alertWarnings, err := v.verifyAlerts(l)
+	warnings = append(warnings, alertWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
 	scOptionWarnings, err := v.validateAdditionalParamsAndLabels(l)
 	warnings = append(warnings, scOptionWarnings...)
 	if err != nil {
 		return warnings, err
 	}
 
+	vgManagerWarnings, err := v.verifyVGManager(ctx, l)
+	warnings = append(warnings, vgManagerWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	conflictWarnings, err := v.verifyNoConflictWithOtherLVMClusters(ctx, l)
+	warnings = append(warnings, conflictWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
 	// Validate device class removal follows the business rules
 	err = validateDeviceClassRemoval(oldLVMCluster.Spec.Storage.DeviceClasses, l.Spec.Storage.DeviceClasses)
 	if err != nil {
@@ -228,12 +276,15 @@ func (v *lvmClusterValidator) ValidateUpdate(_ context.Context, oldLVMCluster, l
 			}
 		}
 
-		newNodeSelector := deviceClass.NodeSelector
+		// the node selector can be changed, but nodes that are no longer selected retain the volume group
+		// as long as it holds persistent volumes
 		oldNodeSelector, err := v.getNodeSelectorOfDeviceClass(oldLVMCluster, deviceClass.Name)
-		if (newNodeSelector != nil && oldNodeSelector == nil && !errors.Is(err, ErrDeviceClassNotFound)) ||
-			(newNodeSelector == nil && oldNodeSelector != nil) ||
-			(newNodeSelector != nil && oldNodeSelector != nil && !reflect.DeepEqual(newNodeSelector, oldNodeSelector)) {
-			return warnings, ErrNodeSelectorCannotBeChanged
+		if !errors.Is(err, ErrDeviceClassNotFound) && !reflect.DeepEqual(deviceClass.NodeSelector, oldNodeSelector) {
+			nodeSelectorWarnings, err := v.verifyNodeSelectorChange(ctx, oldLVMCluster, l, &deviceClass, oldNodeSelector)
+			warnings = append(warnings, nodeSelectorWarnings...)
+			if err != nil {
+				return warnings, err
+			}
 		}
 
 		if deviceClass.DeviceSelector != nil {
@@ -358,6 +409,225 @@ func (v *lvmClusterValidator) verifyPathsAreNotEmpty(l *LVMCluster) (admission.W
 	return nil, nil
 }
 
+// verifyNoConflictWithOtherLVMClusters makes sure that the LVMCluster can coexist with the other LVMClusters.
+// Device class names have to be unique as they name the volume groups and storage classes, only one device class
+// can be the default one, and two device classes that can be placed on the same node must not claim the same devices.
+func (v *lvmClusterValidator) verifyNoConflictWithOtherLVMClusters(ctx context.Context, l *LVMCluster) (admission.Warnings, error) {
+	others := &LVMClusterList{}
+	if err := v.List(ctx, others, client.InNamespace(l.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("could not verify that the LVMCluster does not conflict with other LVMClusters: %w", err)
+	}
+	nodes := &corev1.NodeList{}
+	if err := v.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("could not verify that the LVMCluster does not conflict with other LVMClusters: %w", err)
+	}
+
+	for _, other := range others.Items {
+		if other.GetName() == l.GetName() || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		for _, deviceClass := range l.Spec.Storage.DeviceClasses {
+			for _, otherDeviceClass := range other.Spec.Storage.DeviceClasses {
+				if deviceClass.Name == otherDeviceClass.Name {
+					return nil, fmt.Errorf("deviceClass %s is defined in LVMCluster %q: %w",
+						deviceClass.Name, client.ObjectKeyFromObject(&other), ErrDeviceClassClaimedByOtherLVMCluster)
+				}
+				if deviceClass.Default && otherDeviceClass.Default {
+					return nil, fmt.Errorf("deviceClass %s of LVMCluster %q is already the default deviceClass: %w",
+						otherDeviceClass.Name, client.ObjectKeyFromObject(&other), ErrOnlyOneDefaultDeviceClassAllowed)
+				}
+				node, err := sharedNode(nodes, l, &deviceClass, &other, &otherDeviceClass)
+				if err != nil {
+					return nil, err
+				}
+				if node == "" {
+					continue
+				}
+				if devices := overlappingDevices(&deviceClass, &otherDeviceClass); devices != "" {
+					return nil, fmt.Errorf("deviceClass %s and deviceClass %s of LVMCluster %q both claim %s on node %s: %w",
+						deviceClass.Name, otherDeviceClass.Name, client.ObjectKeyFromObject(&other), devices, node,
+						ErrDevicesClaimedByOtherLVMCluster)
+				}
+			}
+		}
+	}
+
+	// the implicit default of an LVMCluster with a single device class stays with the oldest of them
+	var warnings admission.Warnings
+	if holder := implicitDefaultHolder(l, others.Items); holder != nil {
+		holderDeviceClass := holder.Spec.Storage.DeviceClasses[0].Name
+		if slices.ContainsFunc(l.Spec.Storage.DeviceClasses, func(deviceClass DeviceClass) bool { return deviceClass.Default }) {
+			return nil, fmt.Errorf("deviceClass %s of LVMCluster %q is implicitly the default deviceClass as the only deviceClass of its LVMCluster: %w",
+				holderDeviceClass, client.ObjectKeyFromObject(holder), ErrOnlyOneDefaultDeviceClassAllowed)
+		}
+		if len(l.Spec.Storage.DeviceClasses) == 1 {
+			warnings = append(warnings, fmt.Sprintf("deviceClass %s is not the default deviceClass, as deviceClass %s of LVMCluster %q is implicitly the default one",
+				l.Spec.Storage.DeviceClasses[0].Name, holderDeviceClass, client.ObjectKeyFromObject(holder)))
+		}
+	}
+	return warnings, nil
+}
+
+// verifyNodeSelectorChange warns about the nodes that are no longer selected by the device class but still have
+// persistent volumes on it. The volume group is retained on these nodes until the persistent volumes are removed.
+func (v *lvmClusterValidator) verifyNodeSelectorChange(ctx context.Context, oldLVMCluster, l *LVMCluster, deviceClass *DeviceClass, oldNodeSelector *corev1.NodeSelector) (admission.Warnings, error) {
+	nodes := &corev1.NodeList{}
+	if err := v.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("could not verify the node selector change of deviceClass %s: %w", deviceClass.Name, err)
+	}
+	pvs := &corev1.PersistentVolumeList{}
+	if err := v.List(ctx, pvs); err != nil {
+		return nil, fmt.Errorf("could not verify the node selector change of deviceClass %s: %w", deviceClass.Name, err)
+	}
+
+	oldDeviceClass := deviceClass.DeepCopy()
+	oldDeviceClass.NodeSelector = oldNodeSelector
+
+	var retained []string
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if ok, err := deviceClassFitsNode(node, oldLVMCluster, oldDeviceClass); err != nil || !ok {
+			continue
+		}
+		if ok, err := deviceClassFitsNode(node, l, deviceClass); err != nil {
+			return nil, err
+		} else if ok {
+			continue
+		}
+		if slices.ContainsFunc(pvs.Items, func(pv corev1.PersistentVolume) bool {
+			return isPersistentVolumeOfDeviceClassOnNode(&pv, deviceClass.Name, node.Name)
+		}) {
+			retained = append(retained, node.Name)
+		}
+	}
+	if len(retained) == 0 {
+		return nil, nil
+	}
+	slices.Sort(retained)
+	return admission.Warnings{fmt.Sprintf("deviceClass %s no longer selects node(s) %s, which still have PersistentVolumes. "+
+		"The volume group is retained on these nodes and reported as %s until the PersistentVolumes are removed",
+		deviceClass.Name, strings.Join(retained, ", "), VGStatusOrphaned)}, nil
+}
+
+// isPersistentVolumeOfDeviceClassOnNode checks if the persistent volume was provisioned by TopoLVM
+// from the device class on the node.
+func isPersistentVolumeOfDeviceClassOnNode(pv *corev1.PersistentVolume, deviceClass, node string) bool {
+	if pv.Spec.StorageClassName != constants.StorageClassPrefix+deviceClass ||
+		pv.Spec.CSI == nil || pv.Spec.CSI.Driver != constants.TopolvmCSIDriverName ||
+		pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == constants.TopologyNodeKey && slices.Contains(expression.Values, node) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// implicitDefaultHolder returns the other LVMCluster whose only device class is implicitly the default one. If no device
+// class of the other LVMClusters is explicitly the default one, this is the oldest LVMCluster with a single device class.
+// Nil is returned if there is none or if the given LVMCluster itself is the oldest one.
+func implicitDefaultHolder(l *LVMCluster, others []LVMCluster) *LVMCluster {
+	var candidates []*LVMCluster
+	for i := range others {
+		other := &others[i]
+		if other.GetName() == l.GetName() || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if slices.ContainsFunc(other.Spec.Storage.DeviceClasses, func(deviceClass DeviceClass) bool { return deviceClass.Default }) {
+			return nil
+		}
+		if len(other.Spec.Storage.DeviceClasses) == 1 {
+			candidates = append(candidates, other)
+		}
+	}
+	if len(l.Spec.Storage.DeviceClasses) == 1 {
+		candidates = append(candidates, l)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	oldest := slices.MinFunc(candidates, func(a, b *LVMCluster) int {
+		// an LVMCluster that is being created has no creation timestamp yet and is the newest one
+		if a.CreationTimestamp.IsZero() != b.CreationTimestamp.IsZero() {
+			if a.CreationTimestamp.IsZero() {
+				return 1
+			}
+			return -1
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	if oldest == l {
+		return nil
+	}
+	return oldest
+}
+
+// sharedNode returns the name of a node both device classes can be placed on, or an empty string if there is none.
+func sharedNode(nodes *corev1.NodeList, l *LVMCluster, deviceClass *DeviceClass, other *LVMCluster, otherDeviceClass *DeviceClass) (string, error) {
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if ok, err := deviceClassFitsNode(node, l, deviceClass); err != nil {
+			return "", err
+		} else if !ok {
+			continue
+		}
+		if ok, err := deviceClassFitsNode(node, other, otherDeviceClass); err != nil {
+			return "", err
+		} else if ok {
+			return node.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// deviceClassFitsNode checks if the node tolerates the tolerations of the LVMCluster and matches the node selector
+// of the device class.
+func deviceClassFitsNode(node *corev1.Node, l *LVMCluster, deviceClass *DeviceClass) (bool, error) {
+	for _, taint := range node.Spec.Taints {
+		if !corev1helper.TolerationsTolerateTaint(klog.Background(), l.Spec.Tolerations, &taint, true) {
+			return false, nil
+		}
+	}
+	if deviceClass.NodeSelector == nil {
+		return true, nil
+	}
+	ok, err := corev1helper.MatchNodeSelectorTerms(node, deviceClass.NodeSelector)
+	if err != nil {
+		return false, fmt.Errorf("could not match the node selector of deviceClass %s: %w", deviceClass.Name, err)
+	}
+	return ok, nil
+}
+
+// overlappingDevices returns the devices claimed by both device classes, or an empty string if there are none.
+// A device class without paths claims all available devices.
+func overlappingDevices(deviceClass, otherDeviceClass *DeviceClass) string {
+	paths, otherPaths := deviceClassPaths(deviceClass), deviceClassPaths(otherDeviceClass)
+	if len(paths) == 0 || len(otherPaths) == 0 {
+		return "all available devices"
+	}
+	var overlapping []string
+	for _, path := range paths {
+		if slices.Contains(otherPaths, path) {
+			overlapping = append(overlapping, string(path))
+		}
+	}
+	return strings.Join(overlapping, ",")
+}
+
+func deviceClassPaths(deviceClass *DeviceClass) []DevicePath {
+	if deviceClass.DeviceSelector == nil {
+		return nil
+	}
+	return append(slices.Clone(deviceClass.DeviceSelector.Paths), deviceClass.DeviceSelector.OptionalPaths...)
+}
+
 func (v *lvmClusterValidator) verifyThinPoolConfig(config *ThinPoolConfig) (admission.Warnings, error) {
 	if config.SizePercent <= ThinPoolConfigMaxRecommendedSizePercent {
 		return nil, nil
@@ -509,6 +779,78 @@ func (v *lvmClusterValidator) verifyFstype(l *LVMCluster) error {
 	return nil
 }
 
+func (v *lvmClusterValidator) verifyPartitionFreeSpace(l *LVMCluster) error {
+	for _, deviceClass := range l.Spec.Storage.DeviceClasses {
+		selector := deviceClass.DeviceSelector
+		if selector == nil || selector.PartitionFreeSpace == nil || !*selector.PartitionFreeSpace {
+			continue
+		}
+		if len(selector.Paths) == 0 && len(selector.OptionalPaths) == 0 {
+			return fmt.Errorf("deviceClass %s is invalid: %w", deviceClass.Name, ErrPartitionFreeSpaceWithoutPaths)
+		}
+		if selector.ForceWipeDevicesAndDestroyAllData != nil && *selector.ForceWipeDevicesAndDestroyAllData {
+			return fmt.Errorf("deviceClass %s is invalid: %w", deviceClass.Name, ErrPartitionFreeSpaceWithForceWipe)
+		}
+	}
+
+	return nil
+}
+
+func (v *lvmClusterValidator) verifyAlerts(l *LVMCluster) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	for _, deviceClass := range l.Spec.Storage.DeviceClasses {
+		alerts := deviceClass.Alerts
+		if alerts == nil {
+			continue
+		}
+		if deviceClass.ThinPoolConfig == nil && (alerts.ThinPoolDataUsage != nil || alerts.ThinPoolMetadataUsage != nil) {
+			warnings = append(warnings, fmt.Sprintf(
+				"thin pool alerts are configured for device class %q without a thinPoolConfig and are ignored", deviceClass.Name))
+		}
+		for _, usage := range []struct {
+			field  string
+			config *UsageAlertConfig
+		}{
+			{"volumeGroupUsage", alerts.VolumeGroupUsage},
+			{"thinPoolDataUsage", alerts.ThinPoolDataUsage},
+			{"thinPoolMetadataUsage", alerts.ThinPoolMetadataUsage},
+		} {
+			if usage.config == nil {
+				continue
+			}
+			nearFull, critical := AlertNearFullPercentDefault, AlertCriticalPercentDefault
+			if usage.config.NearFullPercent != nil {
+				nearFull = *usage.config.NearFullPercent
+			}
+			if usage.config.CriticalPercent != nil {
+				critical = *usage.config.CriticalPercent
+			}
+			if critical <= nearFull {
+				return warnings, fmt.Errorf("alerts.%s of deviceClass %s is invalid (nearFullPercent %d, criticalPercent %d): %w",
+					usage.field, deviceClass.Name, nearFull, critical, ErrAlertThresholdsInvalid)
+			}
+			if err := verifyAlertFor(deviceClass.Name, usage.field, &usage.config.AlertConfig); err != nil {
+				return warnings, err
+			}
+		}
+		if err := verifyAlertFor(deviceClass.Name, "volumeGroupStatus", alerts.VolumeGroupStatus); err != nil {
+			return warnings, err
+		}
+		if err := verifyAlertFor(deviceClass.Name, "missingPhysicalVolumes", alerts.MissingPhysicalVolumes); err != nil {
+			return warnings, err
+		}
+	}
+
+	return warnings, nil
+}
+
+func verifyAlertFor(deviceClassName, field string, config *AlertConfig) error {
+	if config != nil && config.For != nil && config.For.Duration < 0 {
+		return fmt.Errorf("alerts.%s.for of deviceClass %s must not be negative", field, deviceClassName)
+	}
+	return nil
+}
+
 func (v *lvmClusterValidator) verifyChunkSize(l *LVMCluster) error {
 	for _, dc := range l.Spec.Storage.DeviceClasses {
 		if dc.ThinPoolConfig == nil {
@@ -618,3 +960,101 @@ func (v *lvmClusterValidator) validateAdditionalParamsAndLabels(l *LVMCluster) (
 	}
 	return warnings, nil
 }
+
+// verifyVGManager validates the parts of the vg-manager configuration that cannot be expressed in the schema.
+// As the vg-manager DaemonSet is shared, it warns if the configuration is ignored because another LVMCluster is older.
+func (v *lvmClusterValidator) verifyVGManager(ctx context.Context, l *LVMCluster) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	config := l.Spec.VGManager
+	if config == nil {
+		return warnings, nil
+	}
+
+	if config.Resources != nil {
+		defaults := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(constants.VgManagerCPURequest),
+			corev1.ResourceMemory: resource.MustParse(constants.VgManagerMemRequest),
+		}
+		for name, quantity := range config.Resources.Requests {
+			if quantity.Sign() < 0 {
+				return warnings, fmt.Errorf("vgManager.resources.requests.%s must not be negative: %w", name, ErrVGManagerConfigInvalid)
+			}
+		}
+		for name, limit := range config.Resources.Limits {
+			if limit.Sign() < 0 {
+				return warnings, fmt.Errorf("vgManager.resources.limits.%s must not be negative: %w", name, ErrVGManagerConfigInvalid)
+			}
+			request, ok := config.Resources.Requests[name]
+			if !ok {
+				request, ok = defaults[name]
+			}
+			if ok && request.Cmp(limit) > 0 {
+				return warnings, fmt.Errorf("vgManager.resources.limits.%s (%s) must not be less than its request (%s): %w",
+					name, limit.String(), request.String(), ErrVGManagerConfigInvalid)
+			}
+		}
+	}
+
+	if config.PriorityClassName != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(config.PriorityClassName); len(errs) > 0 {
+			return warnings, fmt.Errorf("vgManager.priorityClassName %q is invalid: %s: %w",
+				config.PriorityClassName, strings.Join(errs, "; "), ErrVGManagerConfigInvalid)
+		}
+	}
+
+	if config.UpdateStrategy != nil && config.UpdateStrategy.MaxUnavailable != nil {
+		maxUnavailable := config.UpdateStrategy.MaxUnavailable
+		valid := maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal >= 1
+		if maxUnavailable.Type == intstr.String {
+			percent, err := strconv.Atoi(strings.TrimSuffix(maxUnavailable.StrVal, "%"))
+			valid = err == nil && strings.HasSuffix(maxUnavailable.StrVal, "%") && percent >= 1 && percent <= 100
+		}
+		if !valid {
+			return warnings, fmt.Errorf("vgManager.updateStrategy.maxUnavailable %q must be a positive number or a percentage between 1%% and 100%%: %w",
+				maxUnavailable.String(), ErrVGManagerConfigInvalid)
+		}
+	}
+
+	if config.UpdateStrategy != nil && config.UpdateStrategy.ProgressDeadline != nil &&
+		config.UpdateStrategy.ProgressDeadline.Duration <= 0 {
+		return warnings, fmt.Errorf("vgManager.updateStrategy.progressDeadline must be positive: %w", ErrVGManagerConfigInvalid)
+	}
+
+	for key, val := range config.NodeSelector {
+		if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+			return warnings, fmt.Errorf("vgManager.nodeSelector key %q is invalid: %s: %w", key, strings.Join(errs, "; "), ErrVGManagerConfigInvalid)
+		}
+		if errs := k8svalidation.IsValidLabelValue(val); len(errs) > 0 {
+			return warnings, fmt.Errorf("vgManager.nodeSelector value %q for key %q is invalid: %s: %w",
+				val, key, strings.Join(errs, "; "), ErrVGManagerConfigInvalid)
+		}
+	}
+	if len(config.NodeSelector) > 0 {
+		warnings = append(warnings, "volume groups on nodes that do not match vgManager.nodeSelector are no longer managed by vg-manager")
+	}
+
+	for _, env := range config.Env {
+		if _, reserved := constants.ReservedVGManagerEnvVars[env.Name]; reserved {
+			return warnings, fmt.Errorf("vgManager.env variable %q is set by LVMS and cannot be overridden: %w", env.Name, ErrVGManagerConfigInvalid)
+		}
+	}
+
+	others := &LVMClusterList{}
+	if err := v.List(ctx, others, client.InNamespace(l.GetNamespace())); err != nil {
+		return warnings, fmt.Errorf("could not verify which LVMCluster configures vg-manager: %w", err)
+	}
+	for _, other := range others.Items {
+		if other.GetName() == l.GetName() || !other.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		// a new LVMCluster has no creation timestamp yet, but is always newer than the existing ones
+		if l.CreationTimestamp.IsZero() || other.CreationTimestamp.Before(&l.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&l.CreationTimestamp) && other.GetName() < l.GetName()) {
+			warnings = append(warnings, fmt.Sprintf(
+				"vgManager is ignored because vg-manager is configured by the older LVMCluster %q", other.GetName()))
+			break
+		}
+	}
+
+	return warnings, nil
+}
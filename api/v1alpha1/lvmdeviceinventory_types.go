@@ -0,0 +1,109 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeviceOwnerSystem is the owner of devices that are used by the host, e.g. for its root filesystem.
+const DeviceOwnerSystem = "system"
+
+// LVMDeviceInventoryStatus defines the observed state of LVMDeviceInventory
+type LVMDeviceInventoryStatus struct {
+	// Devices are the block devices on the node, including partitions and other devices stacked on a disk
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Devices []InventoryDevice `json:"devices,omitempty"`
+	// LastUpdateTime is the time vgmanager last refreshed the inventory
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type InventoryDevice struct {
+	// Name is the kernel name of the device, e.g. /dev/sda
+	Name string `json:"name"`
+	// Parent is the kernel name of the device this device is stacked on, e.g. the disk of a partition
+	// +optional
+	Parent string `json:"parent,omitempty"`
+	// Type is the type of the device as reported by lsblk, e.g. disk, part, loop or mpath
+	Type string `json:"type"`
+	// Size is the capacity of the device
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Model is the model of the device
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Serial is the serial number of the device
+	// +optional
+	Serial string `json:"serial,omitempty"`
+	// ByID are the persistent /dev/disk/by-id links of the device, which can be used in a DeviceSelector
+	// +optional
+	ByID []string `json:"byID,omitempty"`
+	// FSType is the filesystem or other signature found on the device, e.g. xfs or LVM2_member
+	// +optional
+	FSType string `json:"fsType,omitempty"`
+	// Owner is the volume group the device or one of its children is a physical volume of,
+	// or system if the device or one of its children is mounted on the host. It is empty if the device is unused.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// Available tells if the device passed all filters and could be used by a device class without a DeviceSelector
+	Available bool `json:"available"`
+	// Filters are the results of the filters vgmanager applies to the devices of a device class without
+	// a DeviceSelector or DeviceHealthPolicy
+	// +optional
+	Filters []DeviceFilterResult `json:"filters,omitempty"`
+}
+
+type DeviceFilterResult struct {
+	// Name is the name of the filter
+	Name string `json:"name"`
+	// Passed tells if the device passed the filter
+	Passed bool `json:"passed"`
+	// Reason is the human-readable reason why the device did not pass the filter
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Last Update",type=date,JSONPath=`.status.lastUpdateTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// LVMDeviceInventory is the Schema for the lvmdeviceinventories API.
+// vgmanager keeps one LVMDeviceInventory per node, named after the node, with the block devices of the node.
+type LVMDeviceInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status LVMDeviceInventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LVMDeviceInventoryList contains a list of LVMDeviceInventory
+type LVMDeviceInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LVMDeviceInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LVMDeviceInventory{}, &LVMDeviceInventoryList{})
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -48,10 +49,89 @@ type LVMVolumeGroupSpec struct {
 	// +kubebuilder:validation:Enum=Static;Dynamic
 	// +optional
 	DeviceDiscoveryPolicy *DeviceDiscoveryPolicySpec `json:"deviceDiscoveryPolicy,omitempty"`
+
+	// DeviceHealthPolicy excludes unhealthy devices from being added to the volume group.
+	// +optional
+	DeviceHealthPolicy *DeviceHealthPolicy `json:"deviceHealthPolicy,omitempty"`
+
+	// OrphanedLogicalVolumePolicy configures how orphaned logical volumes in the volume group are handled.
+	// +optional
+	OrphanedLogicalVolumePolicy *OrphanedLogicalVolumePolicy `json:"orphanedLogicalVolumePolicy,omitempty"`
 }
 
+const (
+	// VolumeGroupReady indicates whether the volume group is ready on all nodes it targets.
+	VolumeGroupReady = "Ready"
+)
+
 // LVMVolumeGroupStatus defines the observed state of LVMVolumeGroup
 type LVMVolumeGroupStatus struct {
+	// ObservedGeneration is the generation of the LVMVolumeGroup the status was aggregated for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Nodes are the names of the nodes targeted by the volume group
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// ReadyNodes is the number of targeted nodes on which the volume group is ready
+	// +optional
+	ReadyNodes int32 `json:"readyNodes,omitempty"`
+
+	// ProgressingNodes is the number of targeted nodes on which the volume group is still being set up
+	// or has not been reconciled for the current generation yet
+	// +optional
+	ProgressingNodes int32 `json:"progressingNodes,omitempty"`
+
+	// DegradedNodes is the number of targeted nodes on which the volume group is degraded
+	// +optional
+	DegradedNodes int32 `json:"degradedNodes,omitempty"`
+
+	// FailedNodes is the number of targeted nodes on which the volume group failed
+	// +optional
+	FailedNodes int32 `json:"failedNodes,omitempty"`
+
+	// OrphanedNodes are the names of the nodes that are no longer targeted by the volume group,
+	// but retain it because it still holds persistent or logical volumes
+	// +optional
+	OrphanedNodes []string `json:"orphanedNodes,omitempty"`
+
+	// NodeStatus contains a summary of the volume group on each targeted node
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	NodeStatus []VolumeGroupNodeSummary `json:"nodeStatus,omitempty"`
+
+	// Size is the total capacity of the volume group across all nodes
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// Free is the capacity of the volume group across all nodes that is not allocated to any logical volume
+	// +optional
+	Free *resource.Quantity `json:"free,omitempty"`
+
+	// Conditions describes the state of the volume group across all nodes
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// VolumeGroupNodeSummary is the summary of the volume group on a node
+type VolumeGroupNodeSummary struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+	// Status is the status of the volume group on the node
+	Status VGStatusType `json:"status"`
+	// Reason provides more detail on the status of the volume group on the node
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Size is the total capacity of the volume group on the node
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the volume group on the node that is not allocated to any logical volume
+	// +optional
+	Free *resource.Quantity `json:"free,omitempty"`
 }
 
 //+kubebuilder:object:root=true
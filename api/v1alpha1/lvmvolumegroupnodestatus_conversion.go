@@ -0,0 +1,28 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import ctrl "sigs.k8s.io/controller-runtime"
+
+// Hub marks v1alpha1 as the version of LVMVolumeGroupNodeStatus that all other versions are converted from and to.
+// It is the storage version and the version used by the operator and vg-manager.
+func (*LVMVolumeGroupNodeStatus) Hub() {}
+
+// SetupWebhookWithManager registers the conversion webhook of LVMVolumeGroupNodeStatus.
+func (s *LVMVolumeGroupNodeStatus) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, s).Complete()
+}
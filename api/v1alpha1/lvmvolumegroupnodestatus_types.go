@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -64,6 +65,12 @@ const (
 	VGStatusFailed VGStatusType = "Failed"
 	// VGStatusDegraded means that the VG has been created but is not using the specified config
 	VGStatusDegraded VGStatusType = "Degraded"
+	// VGStatusOrphaned means that the node no longer matches the node selector of the device class,
+	// but the VG is retained because it still holds persistent or logical volumes
+	VGStatusOrphaned VGStatusType = "Orphaned"
+	// VGStatusUnknown means that the status of the VG was not refreshed by vgmanager for too long,
+	// e.g. because vgmanager is not running on the node or the node is not reachable
+	VGStatusUnknown VGStatusType = "Unknown"
 )
 
 type VGStatus struct {
@@ -75,9 +82,20 @@ type VGStatus struct {
 	Reason string `json:"reason,omitempty"`
 	// Devices is the list of devices used by the volume group
 	Devices []string `json:"devices,omitempty"`
+	// Size is the total capacity of the volume group
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the volume group that is not allocated to any logical volume
+	Free *resource.Quantity `json:"free,omitempty"`
+	// PhysicalVolumes contains the capacity and state of the physical volumes of the volume group
+	PhysicalVolumes []PhysicalVolumeStatus `json:"physicalVolumes,omitempty"`
+	// ThinPool contains the usage of the thin pool of the volume group, if one is configured
+	ThinPool *ThinPoolStatus `json:"thinPool,omitempty"`
 	// Excluded contains the per node status of applied device exclusions that were picked up via selector,
 	// but were not used for other reasons.
 	Excluded []ExcludedDevice `json:"excluded,omitempty"`
+	// Multipath contains the dm-multipath devices that were picked up for the volume group
+	// together with the health of their underlying paths.
+	Multipath []MultipathDevice `json:"multipath,omitempty"`
 	// DeviceDiscoveryPolicy is a field to indicate the effective device discovery policy for this volume group.
 	// Preconfigured indicates explicit DeviceSelector paths are configured and the discovery policy is not applicable.
 	// RuntimeDynamic indicates devices are discovered and added dynamically at runtime (no explicit paths, Dynamic policy).
@@ -95,15 +113,157 @@ type ExcludedDevice struct {
 	Reasons []string `json:"reasons"`
 }
 
+type PhysicalVolumeStatus struct {
+	// Name is the path of the device backing the physical volume
+	Name string `json:"name"`
+	// Size is the total capacity of the physical volume
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the physical volume that is not allocated to any logical volume
+	Free *resource.Quantity `json:"free,omitempty"`
+	// DeviceSize is the size of the underlying device, which can exceed the size of the physical volume
+	// if the device was grown after the physical volume was created
+	DeviceSize *resource.Quantity `json:"deviceSize,omitempty"`
+	// Missing tells if the device of the physical volume can no longer be found on the node
+	Missing bool `json:"missing,omitempty"`
+	// Health is the health of the disk backing the physical volume as reported by SMART,
+	// if it could be collected
+	Health *DeviceHealth `json:"health,omitempty"`
+}
+
+type DeviceHealth struct {
+	// Device is the disk the health was collected for, e.g. the disk a partition is part of
+	Device string `json:"device"`
+	// Healthy tells if the disk passed its SMART overall-health self-assessment
+	Healthy bool `json:"healthy"`
+	// ReallocatedSectors is the number of reallocated sectors of an ATA or SCSI disk
+	ReallocatedSectors *int64 `json:"reallocatedSectors,omitempty"`
+	// MediaErrors is the number of unrecovered media and data integrity errors of an NVMe disk
+	MediaErrors *int64 `json:"mediaErrors,omitempty"`
+	// PercentageUsed is the estimate of the used endurance of an NVMe disk in percent
+	PercentageUsed *int64 `json:"percentageUsed,omitempty"`
+	// Temperature is the current temperature of the disk in degrees Celsius
+	Temperature *int64 `json:"temperature,omitempty"`
+}
+
+type ThinPoolStatus struct {
+	// Name is the name of the thin pool logical volume
+	Name string `json:"name"`
+	// Size is the capacity of the thin pool
+	Size *resource.Quantity `json:"size,omitempty"`
+	// DataPercent is the percentage of the thin pool data space in use, as reported by lvm2
+	DataPercent string `json:"dataPercent,omitempty"`
+	// MetadataPercent is the percentage of the thin pool metadata space in use, as reported by lvm2
+	MetadataPercent string `json:"metadataPercent,omitempty"`
+	// ChunkSize is the chunk size of the thin pool
+	ChunkSize *resource.Quantity `json:"chunkSize,omitempty"`
+	// LogicalVolumeCount is the number of thin logical volumes provisioned from the thin pool
+	LogicalVolumeCount int `json:"logicalVolumeCount"`
+}
+
+type MultipathDevice struct {
+	// Name is the path of the multipath device
+	Name string `json:"name"`
+	// Paths are the underlying paths of the multipath device
+	Paths []MultipathPath `json:"paths,omitempty"`
+}
+
+type MultipathPath struct {
+	// Name is the path of the underlying device
+	Name string `json:"name"`
+	// State is the state of the path as reported by the kernel, e.g. running or offline
+	State string `json:"state,omitempty"`
+	// Healthy tells if the path is usable for I/O
+	Healthy bool `json:"healthy"`
+}
+
+const (
+	// DevicesWiped indicates whether the devices selected for the volume group were wiped before use.
+	// It is only reported if forceWipeDevicesAndDestroyAllData is enabled for the volume group.
+	DevicesWiped = "DevicesWiped"
+
+	// DevicesDiscovered indicates whether the devices for the volume group were discovered on the node.
+	DevicesDiscovered = "DevicesDiscovered"
+
+	// VolumeGroupCreated indicates whether the volume group was created or extended with the discovered devices.
+	VolumeGroupCreated = "VolumeGroupCreated"
+
+	// ThinPoolReady indicates whether the thin pool of the volume group is created and valid.
+	// It is only reported if a thin pool is configured for the volume group.
+	ThinPoolReady = "ThinPoolReady"
+
+	// LVMDConfigApplied indicates whether the device class of the volume group is configured in lvmd.
+	LVMDConfigApplied = "LVMDConfigApplied"
+)
+
 // LVMVolumeGroupNodeStatusStatus defines the observed state of LVMVolumeGroupNodeStatus
 type LVMVolumeGroupNodeStatusStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// VolumeGroups contains the conditions of the volume groups on the node
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VolumeGroups []VolumeGroupConditions `json:"volumeGroups,omitempty"`
+}
+
+type VolumeGroupConditions struct {
+	// Name is the name of the volume group
+	Name string `json:"name"`
+	// Conditions describe the state of the volume group on the node. The observedGeneration of a condition
+	// refers to the generation of the LVMVolumeGroup it was set for.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastReconcileTime is the time vgmanager last reconciled the volume group on the node.
+	// It is refreshed at a coarse interval and serves as heartbeat of vgmanager.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// VGManagerVersion is the version of vgmanager that last reconciled the volume group on the node
+	// +optional
+	VGManagerVersion string `json:"vgManagerVersion,omitempty"`
+	// OrphanedLogicalVolumes are the logical volumes provisioned by TopoLVM in the volume group
+	// that no TopoLVM LogicalVolume refers to
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	OrphanedLogicalVolumes []OrphanedLogicalVolume `json:"orphanedLogicalVolumes,omitempty"`
+	// MissingLogicalVolumes are the TopoLVM LogicalVolumes of the volume group on the node
+	// whose logical volume does not exist in the volume group
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MissingLogicalVolumes []MissingLogicalVolume `json:"missingLogicalVolumes,omitempty"`
+}
+
+type OrphanedLogicalVolume struct {
+	// Name is the name of the logical volume
+	Name string `json:"name"`
+	// Size is the size of the logical volume
+	Size *resource.Quantity `json:"size,omitempty"`
+	// CreationTime is the time the logical volume was created as recorded by lvm2
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// OrphanedSince is the time the logical volume was first found to be orphaned,
+	// from which the grace period of the OrphanedLogicalVolumePolicy is measured
+	// +optional
+	OrphanedSince *metav1.Time `json:"orphanedSince,omitempty"`
+	// DeletionTime is the time the logical volume is deleted at according to the OrphanedLogicalVolumePolicy
+	// +optional
+	DeletionTime *metav1.Time `json:"deletionTime,omitempty"`
+}
+
+type MissingLogicalVolume struct {
+	// Name is the name of the TopoLVM LogicalVolume
+	Name string `json:"name"`
+	// VolumeID is the name of the logical volume that is missing in the volume group
+	VolumeID string `json:"volumeID"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 
 // LVMVolumeGroupNodeStatus is the Schema for the lvmvolumegroupnodestatuses API
 type LVMVolumeGroupNodeStatus struct {
@@ -25,8 +25,34 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertConfig) DeepCopyInto(out *AlertConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertConfig.
+func (in *AlertConfig) DeepCopy() *AlertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceClass) DeepCopyInto(out *DeviceClass) {
 	*out = *in
@@ -50,11 +76,26 @@ func (in *DeviceClass) DeepCopyInto(out *DeviceClass) {
 		*out = new(DeviceDiscoveryPolicySpec)
 		**out = **in
 	}
+	if in.DeviceHealthPolicy != nil {
+		in, out := &in.DeviceHealthPolicy, &out.DeviceHealthPolicy
+		*out = new(DeviceHealthPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedLogicalVolumePolicy != nil {
+		in, out := &in.OrphanedLogicalVolumePolicy, &out.OrphanedLogicalVolumePolicy
+		*out = new(OrphanedLogicalVolumePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageClassOptions != nil {
 		in, out := &in.StorageClassOptions, &out.StorageClassOptions
 		*out = new(StorageClassOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(DeviceClassAlerts)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClass.
@@ -67,6 +108,46 @@ func (in *DeviceClass) DeepCopy() *DeviceClass {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClassAlerts) DeepCopyInto(out *DeviceClassAlerts) {
+	*out = *in
+	if in.VolumeGroupUsage != nil {
+		in, out := &in.VolumeGroupUsage, &out.VolumeGroupUsage
+		*out = new(UsageAlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ThinPoolDataUsage != nil {
+		in, out := &in.ThinPoolDataUsage, &out.ThinPoolDataUsage
+		*out = new(UsageAlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ThinPoolMetadataUsage != nil {
+		in, out := &in.ThinPoolMetadataUsage, &out.ThinPoolMetadataUsage
+		*out = new(UsageAlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeGroupStatus != nil {
+		in, out := &in.VolumeGroupStatus, &out.VolumeGroupStatus
+		*out = new(AlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingPhysicalVolumes != nil {
+		in, out := &in.MissingPhysicalVolumes, &out.MissingPhysicalVolumes
+		*out = new(AlertConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClassAlerts.
+func (in *DeviceClassAlerts) DeepCopy() *DeviceClassAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceClassAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceClassStatus) DeepCopyInto(out *DeviceClassStatus) {
 	*out = *in
@@ -77,6 +158,26 @@ func (in *DeviceClassStatus) DeepCopyInto(out *DeviceClassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ThinPoolSize != nil {
+		in, out := &in.ThinPoolSize, &out.ThinPoolSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ThinPoolUsed != nil {
+		in, out := &in.ThinPoolUsed, &out.ThinPoolUsed
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClassStatus.
@@ -89,6 +190,86 @@ func (in *DeviceClassStatus) DeepCopy() *DeviceClassStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceFilterResult) DeepCopyInto(out *DeviceFilterResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceFilterResult.
+func (in *DeviceFilterResult) DeepCopy() *DeviceFilterResult {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceFilterResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceHealth) DeepCopyInto(out *DeviceHealth) {
+	*out = *in
+	if in.ReallocatedSectors != nil {
+		in, out := &in.ReallocatedSectors, &out.ReallocatedSectors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MediaErrors != nil {
+		in, out := &in.MediaErrors, &out.MediaErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PercentageUsed != nil {
+		in, out := &in.PercentageUsed, &out.PercentageUsed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceHealth.
+func (in *DeviceHealth) DeepCopy() *DeviceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceHealthPolicy) DeepCopyInto(out *DeviceHealthPolicy) {
+	*out = *in
+	if in.MaxReallocatedSectors != nil {
+		in, out := &in.MaxReallocatedSectors, &out.MaxReallocatedSectors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMediaErrors != nil {
+		in, out := &in.MaxMediaErrors, &out.MaxMediaErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPercentageUsed != nil {
+		in, out := &in.MaxPercentageUsed, &out.MaxPercentageUsed
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceHealthPolicy.
+func (in *DeviceHealthPolicy) DeepCopy() *DeviceHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeviceSelector) DeepCopyInto(out *DeviceSelector) {
 	*out = *in
@@ -107,6 +288,11 @@ func (in *DeviceSelector) DeepCopyInto(out *DeviceSelector) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PartitionFreeSpace != nil {
+		in, out := &in.PartitionFreeSpace, &out.PartitionFreeSpace
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceSelector.
@@ -119,6 +305,21 @@ func (in *DeviceSelector) DeepCopy() *DeviceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftPolicies) DeepCopyInto(out *DriftPolicies) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftPolicies.
+func (in *DriftPolicies) DeepCopy() *DriftPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExcludedDevice) DeepCopyInto(out *ExcludedDevice) {
 	*out = *in
@@ -139,6 +340,36 @@ func (in *ExcludedDevice) DeepCopy() *ExcludedDevice {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryDevice) DeepCopyInto(out *InventoryDevice) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ByID != nil {
+		in, out := &in.ByID, &out.ByID
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]DeviceFilterResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryDevice.
+func (in *InventoryDevice) DeepCopy() *InventoryDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LVMCluster) DeepCopyInto(out *LVMCluster) {
 	*out = *in
@@ -209,6 +440,16 @@ func (in *LVMClusterSpec) DeepCopyInto(out *LVMClusterSpec) {
 		}
 	}
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.VGManager != nil {
+		in, out := &in.VGManager, &out.VGManager
+		*out = new(VGManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftPolicies != nil {
+		in, out := &in.DriftPolicies, &out.DriftPolicies
+		*out = new(DriftPolicies)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMClusterSpec.
@@ -250,13 +491,97 @@ func (in *LVMClusterStatus) DeepCopy() *LVMClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LVMDeviceInventory) DeepCopyInto(out *LVMDeviceInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMDeviceInventory.
+func (in *LVMDeviceInventory) DeepCopy() *LVMDeviceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(LVMDeviceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LVMDeviceInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LVMDeviceInventoryList) DeepCopyInto(out *LVMDeviceInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LVMDeviceInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMDeviceInventoryList.
+func (in *LVMDeviceInventoryList) DeepCopy() *LVMDeviceInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(LVMDeviceInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LVMDeviceInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LVMDeviceInventoryStatus) DeepCopyInto(out *LVMDeviceInventoryStatus) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]InventoryDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMDeviceInventoryStatus.
+func (in *LVMDeviceInventoryStatus) DeepCopy() *LVMDeviceInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LVMDeviceInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LVMVolumeGroup) DeepCopyInto(out *LVMVolumeGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMVolumeGroup.
@@ -315,7 +640,7 @@ func (in *LVMVolumeGroupNodeStatus) DeepCopyInto(out *LVMVolumeGroupNodeStatus)
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMVolumeGroupNodeStatus.
@@ -393,6 +718,13 @@ func (in *LVMVolumeGroupNodeStatusSpec) DeepCopy() *LVMVolumeGroupNodeStatusSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LVMVolumeGroupNodeStatusStatus) DeepCopyInto(out *LVMVolumeGroupNodeStatusStatus) {
 	*out = *in
+	if in.VolumeGroups != nil {
+		in, out := &in.VolumeGroups, &out.VolumeGroups
+		*out = make([]VolumeGroupConditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMVolumeGroupNodeStatusStatus.
@@ -428,6 +760,16 @@ func (in *LVMVolumeGroupSpec) DeepCopyInto(out *LVMVolumeGroupSpec) {
 		*out = new(DeviceDiscoveryPolicySpec)
 		**out = **in
 	}
+	if in.DeviceHealthPolicy != nil {
+		in, out := &in.DeviceHealthPolicy, &out.DeviceHealthPolicy
+		*out = new(DeviceHealthPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanedLogicalVolumePolicy != nil {
+		in, out := &in.OrphanedLogicalVolumePolicy, &out.OrphanedLogicalVolumePolicy
+		*out = new(OrphanedLogicalVolumePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMVolumeGroupSpec.
@@ -443,6 +785,40 @@ func (in *LVMVolumeGroupSpec) DeepCopy() *LVMVolumeGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LVMVolumeGroupStatus) DeepCopyInto(out *LVMVolumeGroupStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanedNodes != nil {
+		in, out := &in.OrphanedNodes, &out.OrphanedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeStatus != nil {
+		in, out := &in.NodeStatus, &out.NodeStatus
+		*out = make([]VolumeGroupNodeSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LVMVolumeGroupStatus.
@@ -455,6 +831,56 @@ func (in *LVMVolumeGroupStatus) DeepCopy() *LVMVolumeGroupStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MissingLogicalVolume) DeepCopyInto(out *MissingLogicalVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MissingLogicalVolume.
+func (in *MissingLogicalVolume) DeepCopy() *MissingLogicalVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MissingLogicalVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipathDevice) DeepCopyInto(out *MultipathDevice) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]MultipathPath, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipathDevice.
+func (in *MultipathDevice) DeepCopy() *MultipathDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipathDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipathPath) DeepCopyInto(out *MultipathPath) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipathPath.
+func (in *MultipathPath) DeepCopy() *MultipathPath {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipathPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
@@ -471,6 +897,93 @@ func (in *NodeStatus) DeepCopy() *NodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedLogicalVolume) DeepCopyInto(out *OrphanedLogicalVolume) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.OrphanedSince != nil {
+		in, out := &in.OrphanedSince, &out.OrphanedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.DeletionTime != nil {
+		in, out := &in.DeletionTime, &out.DeletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedLogicalVolume.
+func (in *OrphanedLogicalVolume) DeepCopy() *OrphanedLogicalVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedLogicalVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedLogicalVolumePolicy) DeepCopyInto(out *OrphanedLogicalVolumePolicy) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedLogicalVolumePolicy.
+func (in *OrphanedLogicalVolumePolicy) DeepCopy() *OrphanedLogicalVolumePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedLogicalVolumePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhysicalVolumeStatus) DeepCopyInto(out *PhysicalVolumeStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DeviceSize != nil {
+		in, out := &in.DeviceSize, &out.DeviceSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(DeviceHealth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhysicalVolumeStatus.
+func (in *PhysicalVolumeStatus) DeepCopy() *PhysicalVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PhysicalVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Storage) DeepCopyInto(out *Storage) {
 	*out = *in
@@ -557,6 +1070,126 @@ func (in *ThinPoolConfig) DeepCopy() *ThinPoolConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThinPoolStatus) DeepCopyInto(out *ThinPoolStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ChunkSize != nil {
+		in, out := &in.ChunkSize, &out.ChunkSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThinPoolStatus.
+func (in *ThinPoolStatus) DeepCopy() *ThinPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ThinPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageAlertConfig) DeepCopyInto(out *UsageAlertConfig) {
+	*out = *in
+	in.AlertConfig.DeepCopyInto(&out.AlertConfig)
+	if in.NearFullPercent != nil {
+		in, out := &in.NearFullPercent, &out.NearFullPercent
+		*out = new(int)
+		**out = **in
+	}
+	if in.CriticalPercent != nil {
+		in, out := &in.CriticalPercent, &out.CriticalPercent
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageAlertConfig.
+func (in *UsageAlertConfig) DeepCopy() *UsageAlertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageAlertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGManagerConfig) DeepCopyInto(out *VGManagerConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(VGManagerUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGManagerConfig.
+func (in *VGManagerConfig) DeepCopy() *VGManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VGManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VGManagerUpdateStrategy) DeepCopyInto(out *VGManagerUpdateStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGManagerUpdateStrategy.
+func (in *VGManagerUpdateStrategy) DeepCopy() *VGManagerUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(VGManagerUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VGStatus) DeepCopyInto(out *VGStatus) {
 	*out = *in
@@ -565,6 +1198,28 @@ func (in *VGStatus) DeepCopyInto(out *VGStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PhysicalVolumes != nil {
+		in, out := &in.PhysicalVolumes, &out.PhysicalVolumes
+		*out = make([]PhysicalVolumeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ThinPool != nil {
+		in, out := &in.ThinPool, &out.ThinPool
+		*out = new(ThinPoolStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Excluded != nil {
 		in, out := &in.Excluded, &out.Excluded
 		*out = make([]ExcludedDevice, len(*in))
@@ -572,6 +1227,13 @@ func (in *VGStatus) DeepCopyInto(out *VGStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Multipath != nil {
+		in, out := &in.Multipath, &out.Multipath
+		*out = make([]MultipathDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VGStatus.
@@ -583,3 +1245,66 @@ func (in *VGStatus) DeepCopy() *VGStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupConditions) DeepCopyInto(out *VolumeGroupConditions) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.OrphanedLogicalVolumes != nil {
+		in, out := &in.OrphanedLogicalVolumes, &out.OrphanedLogicalVolumes
+		*out = make([]OrphanedLogicalVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MissingLogicalVolumes != nil {
+		in, out := &in.MissingLogicalVolumes, &out.MissingLogicalVolumes
+		*out = make([]MissingLogicalVolume, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupConditions.
+func (in *VolumeGroupConditions) DeepCopy() *VolumeGroupConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeGroupNodeSummary) DeepCopyInto(out *VolumeGroupNodeSummary) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeGroupNodeSummary.
+func (in *VolumeGroupNodeSummary) DeepCopy() *VolumeGroupNodeSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeGroupNodeSummary)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,43 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// convertSlice converts every element of in, and keeps nil slices nil.
+func convertSlice[S, D any](in []S, convert func(S) D) []D {
+	if in == nil {
+		return nil
+	}
+	out := make([]D, len(in))
+	for i := range in {
+		out[i] = convert(in[i])
+	}
+	return out
+}
+
+// convertPointer converts the value in points to, and keeps nil pointers nil.
+func convertPointer[S, D any](in *S, convert func(S) D) *D {
+	if in == nil {
+		return nil
+	}
+	out := convert(*in)
+	return &out
+}
+
+// convertString converts between string types of the same meaning in different versions.
+func convertString[S, D ~string](in S) D {
+	return D(in)
+}
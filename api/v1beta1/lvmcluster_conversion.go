@@ -0,0 +1,270 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this LVMCluster to the Hub version (v1alpha1).
+func (src *LVMCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*lvmv1alpha1.LVMCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Tolerations = src.Spec.Tolerations
+	dst.Spec.VGManager = convertPointer(src.Spec.VGManager, convertVGManagerConfigToHub)
+	dst.Spec.DriftPolicies = convertPointer(src.Spec.DriftPolicies, convertDriftPoliciesToHub)
+	dst.Spec.Storage.DeviceClasses = convertSlice(src.Spec.DeviceClasses, convertDeviceClassToHub)
+
+	// the readiness of v1alpha1 is derived from the state, as it is set by the operator
+	dst.Status.State = lvmv1alpha1.LVMStateType(src.Status.State)
+	dst.Status.Ready = dst.Status.State == lvmv1alpha1.LVMStatusReady
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.DeviceClassStatuses = convertSlice(src.Status.DeviceClasses, func(in DeviceClassStatus) lvmv1alpha1.DeviceClassStatus {
+		return lvmv1alpha1.DeviceClassStatus{
+			Name: in.Name,
+			NodeStatus: convertSlice(in.Nodes, func(in NodeStatus) lvmv1alpha1.NodeStatus {
+				return lvmv1alpha1.NodeStatus{Node: in.Node, VGStatus: convertVolumeGroupStatusToHub(in.VolumeGroupStatus)}
+			}),
+			Size:         in.Size,
+			Free:         in.Free,
+			ThinPoolSize: in.ThinPoolSize,
+			ThinPoolUsed: in.ThinPoolUsed,
+		}
+	})
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *LVMCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*lvmv1alpha1.LVMCluster)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Tolerations = src.Spec.Tolerations
+	dst.Spec.VGManager = convertPointer(src.Spec.VGManager, convertVGManagerConfigFromHub)
+	dst.Spec.DriftPolicies = convertPointer(src.Spec.DriftPolicies, convertDriftPoliciesFromHub)
+	dst.Spec.DeviceClasses = convertSlice(src.Spec.Storage.DeviceClasses, convertDeviceClassFromHub)
+
+	dst.Status.State = LVMState(src.Status.State)
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.DeviceClasses = convertSlice(src.Status.DeviceClassStatuses, func(in lvmv1alpha1.DeviceClassStatus) DeviceClassStatus {
+		return DeviceClassStatus{
+			Name: in.Name,
+			Nodes: convertSlice(in.NodeStatus, func(in lvmv1alpha1.NodeStatus) NodeStatus {
+				return NodeStatus{Node: in.Node, VolumeGroupStatus: convertVolumeGroupStatusFromHub(in.VGStatus)}
+			}),
+			Size:         in.Size,
+			Free:         in.Free,
+			ThinPoolSize: in.ThinPoolSize,
+			ThinPoolUsed: in.ThinPoolUsed,
+		}
+	})
+
+	return nil
+}
+
+func convertVGManagerConfigToHub(in VGManagerConfig) lvmv1alpha1.VGManagerConfig {
+	return lvmv1alpha1.VGManagerConfig{
+		Resources:         in.Resources,
+		PriorityClassName: in.PriorityClassName,
+		UpdateStrategy: convertPointer(in.UpdateStrategy, func(in VGManagerUpdateStrategy) lvmv1alpha1.VGManagerUpdateStrategy {
+			return lvmv1alpha1.VGManagerUpdateStrategy{MaxUnavailable: in.MaxUnavailable, ProgressDeadline: in.ProgressDeadline}
+		}),
+		NodeSelector: in.NodeSelector,
+		LogLevel:     in.LogLevel,
+		Env:          in.Env,
+	}
+}
+
+func convertVGManagerConfigFromHub(in lvmv1alpha1.VGManagerConfig) VGManagerConfig {
+	return VGManagerConfig{
+		Resources:         in.Resources,
+		PriorityClassName: in.PriorityClassName,
+		UpdateStrategy: convertPointer(in.UpdateStrategy, func(in lvmv1alpha1.VGManagerUpdateStrategy) VGManagerUpdateStrategy {
+			return VGManagerUpdateStrategy{MaxUnavailable: in.MaxUnavailable, ProgressDeadline: in.ProgressDeadline}
+		}),
+		NodeSelector: in.NodeSelector,
+		LogLevel:     in.LogLevel,
+		Env:          in.Env,
+	}
+}
+
+func convertDriftPoliciesToHub(in DriftPolicies) lvmv1alpha1.DriftPolicies {
+	return lvmv1alpha1.DriftPolicies{
+		StorageClass:               lvmv1alpha1.DriftPolicy(in.StorageClass),
+		CSIDriver:                  lvmv1alpha1.DriftPolicy(in.CSIDriver),
+		SecurityContextConstraints: lvmv1alpha1.DriftPolicy(in.SecurityContextConstraints),
+		VolumeSnapshotClass:        lvmv1alpha1.DriftPolicy(in.VolumeSnapshotClass),
+		DaemonSet:                  lvmv1alpha1.DriftPolicy(in.DaemonSet),
+	}
+}
+
+func convertDriftPoliciesFromHub(in lvmv1alpha1.DriftPolicies) DriftPolicies {
+	return DriftPolicies{
+		StorageClass:               DriftPolicy(in.StorageClass),
+		CSIDriver:                  DriftPolicy(in.CSIDriver),
+		SecurityContextConstraints: DriftPolicy(in.SecurityContextConstraints),
+		VolumeSnapshotClass:        DriftPolicy(in.VolumeSnapshotClass),
+		DaemonSet:                  DriftPolicy(in.DaemonSet),
+	}
+}
+
+func convertDeviceClassToHub(in DeviceClass) lvmv1alpha1.DeviceClass {
+	return lvmv1alpha1.DeviceClass{
+		Name: in.Name,
+		DeviceSelector: convertPointer(in.DeviceSelector, func(in DeviceSelector) lvmv1alpha1.DeviceSelector {
+			return lvmv1alpha1.DeviceSelector{
+				Paths:                             convertSlice(in.Paths, convertString[DevicePath, lvmv1alpha1.DevicePath]),
+				OptionalPaths:                     convertSlice(in.OptionalPaths, convertString[DevicePath, lvmv1alpha1.DevicePath]),
+				ForceWipeDevicesAndDestroyAllData: in.ForceWipeDevicesAndDestroyAllData,
+				PartitionFreeSpace:                in.PartitionFreeSpace,
+			}
+		}),
+		NodeSelector: in.NodeSelector,
+		ThinPoolConfig: convertPointer(in.ThinPool, func(in ThinPool) lvmv1alpha1.ThinPoolConfig {
+			return lvmv1alpha1.ThinPoolConfig{
+				Name:                          in.Name,
+				SizePercent:                   in.SizePercent,
+				OverprovisionRatio:            in.OverprovisionRatio,
+				ChunkSizeCalculationPolicy:    lvmv1alpha1.ChunkSizeCalculationPolicy(in.ChunkSizeCalculationPolicy),
+				ChunkSize:                     in.ChunkSize,
+				MetadataSize:                  in.MetadataSize,
+				MetadataSizeCalculationPolicy: lvmv1alpha1.MetadataSizePolicy(in.MetadataSizeCalculationPolicy),
+			}
+		}),
+		Default:               in.Default,
+		FilesystemType:        lvmv1alpha1.DeviceFilesystemType(in.FilesystemType),
+		DeviceDiscoveryPolicy: convertPointer(in.DeviceDiscoveryPolicy, convertString[DeviceDiscoveryPolicy, lvmv1alpha1.DeviceDiscoveryPolicySpec]),
+		DeviceHealthPolicy: convertPointer(in.DeviceHealthPolicy, func(in DeviceHealthPolicy) lvmv1alpha1.DeviceHealthPolicy {
+			return lvmv1alpha1.DeviceHealthPolicy{
+				MaxReallocatedSectors: in.MaxReallocatedSectors,
+				MaxMediaErrors:        in.MaxMediaErrors,
+				MaxPercentageUsed:     in.MaxPercentageUsed,
+			}
+		}),
+		OrphanedLogicalVolumePolicy: convertPointer(in.OrphanedLogicalVolumePolicy, func(in OrphanedLogicalVolumePolicy) lvmv1alpha1.OrphanedLogicalVolumePolicy {
+			return lvmv1alpha1.OrphanedLogicalVolumePolicy{
+				Action:      lvmv1alpha1.OrphanedLogicalVolumeAction(in.Action),
+				GracePeriod: in.GracePeriod,
+			}
+		}),
+		StorageClassOptions: convertPointer(in.StorageClass, func(in StorageClass) lvmv1alpha1.StorageClassOptions {
+			return lvmv1alpha1.StorageClassOptions{
+				ReclaimPolicy:        in.ReclaimPolicy,
+				VolumeBindingMode:    in.VolumeBindingMode,
+				AdditionalParameters: in.AdditionalParameters,
+				AdditionalLabels:     in.AdditionalLabels,
+			}
+		}),
+		Alerts: convertPointer(in.Alerts, func(in DeviceClassAlerts) lvmv1alpha1.DeviceClassAlerts {
+			return lvmv1alpha1.DeviceClassAlerts{
+				VolumeGroupUsage:       convertPointer(in.VolumeGroupUsage, convertUsageAlertToHub),
+				ThinPoolDataUsage:      convertPointer(in.ThinPoolDataUsage, convertUsageAlertToHub),
+				ThinPoolMetadataUsage:  convertPointer(in.ThinPoolMetadataUsage, convertUsageAlertToHub),
+				VolumeGroupStatus:      convertPointer(in.VolumeGroupStatus, convertAlertToHub),
+				MissingPhysicalVolumes: convertPointer(in.MissingPhysicalVolumes, convertAlertToHub),
+			}
+		}),
+	}
+}
+
+func convertDeviceClassFromHub(in lvmv1alpha1.DeviceClass) DeviceClass {
+	return DeviceClass{
+		Name:    in.Name,
+		Default: in.Default,
+		DeviceSelector: convertPointer(in.DeviceSelector, func(in lvmv1alpha1.DeviceSelector) DeviceSelector {
+			return DeviceSelector{
+				Paths:                             convertSlice(in.Paths, convertString[lvmv1alpha1.DevicePath, DevicePath]),
+				OptionalPaths:                     convertSlice(in.OptionalPaths, convertString[lvmv1alpha1.DevicePath, DevicePath]),
+				ForceWipeDevicesAndDestroyAllData: in.ForceWipeDevicesAndDestroyAllData,
+				PartitionFreeSpace:                in.PartitionFreeSpace,
+			}
+		}),
+		NodeSelector: in.NodeSelector,
+		ThinPool: convertPointer(in.ThinPoolConfig, func(in lvmv1alpha1.ThinPoolConfig) ThinPool {
+			return ThinPool{
+				Name:                          in.Name,
+				SizePercent:                   in.SizePercent,
+				OverprovisionRatio:            in.OverprovisionRatio,
+				ChunkSizeCalculationPolicy:    ChunkSizeCalculationPolicy(in.ChunkSizeCalculationPolicy),
+				ChunkSize:                     in.ChunkSize,
+				MetadataSize:                  in.MetadataSize,
+				MetadataSizeCalculationPolicy: MetadataSizePolicy(in.MetadataSizeCalculationPolicy),
+			}
+		}),
+		FilesystemType:        FilesystemType(in.FilesystemType),
+		DeviceDiscoveryPolicy: convertPointer(in.DeviceDiscoveryPolicy, convertString[lvmv1alpha1.DeviceDiscoveryPolicySpec, DeviceDiscoveryPolicy]),
+		DeviceHealthPolicy: convertPointer(in.DeviceHealthPolicy, func(in lvmv1alpha1.DeviceHealthPolicy) DeviceHealthPolicy {
+			return DeviceHealthPolicy{
+				MaxReallocatedSectors: in.MaxReallocatedSectors,
+				MaxMediaErrors:        in.MaxMediaErrors,
+				MaxPercentageUsed:     in.MaxPercentageUsed,
+			}
+		}),
+		OrphanedLogicalVolumePolicy: convertPointer(in.OrphanedLogicalVolumePolicy, func(in lvmv1alpha1.OrphanedLogicalVolumePolicy) OrphanedLogicalVolumePolicy {
+			return OrphanedLogicalVolumePolicy{
+				Action:      OrphanedLogicalVolumeAction(in.Action),
+				GracePeriod: in.GracePeriod,
+			}
+		}),
+		StorageClass: convertPointer(in.StorageClassOptions, func(in lvmv1alpha1.StorageClassOptions) StorageClass {
+			return StorageClass{
+				ReclaimPolicy:        in.ReclaimPolicy,
+				VolumeBindingMode:    in.VolumeBindingMode,
+				AdditionalParameters: in.AdditionalParameters,
+				AdditionalLabels:     in.AdditionalLabels,
+			}
+		}),
+		Alerts: convertPointer(in.Alerts, func(in lvmv1alpha1.DeviceClassAlerts) DeviceClassAlerts {
+			return DeviceClassAlerts{
+				VolumeGroupUsage:       convertPointer(in.VolumeGroupUsage, convertUsageAlertFromHub),
+				ThinPoolDataUsage:      convertPointer(in.ThinPoolDataUsage, convertUsageAlertFromHub),
+				ThinPoolMetadataUsage:  convertPointer(in.ThinPoolMetadataUsage, convertUsageAlertFromHub),
+				VolumeGroupStatus:      convertPointer(in.VolumeGroupStatus, convertAlertFromHub),
+				MissingPhysicalVolumes: convertPointer(in.MissingPhysicalVolumes, convertAlertFromHub),
+			}
+		}),
+	}
+}
+
+func convertAlertToHub(in Alert) lvmv1alpha1.AlertConfig {
+	return lvmv1alpha1.AlertConfig{Enabled: in.Enabled, For: in.For}
+}
+
+func convertAlertFromHub(in lvmv1alpha1.AlertConfig) Alert {
+	return Alert{Enabled: in.Enabled, For: in.For}
+}
+
+func convertUsageAlertToHub(in UsageAlert) lvmv1alpha1.UsageAlertConfig {
+	return lvmv1alpha1.UsageAlertConfig{
+		AlertConfig:     convertAlertToHub(in.Alert),
+		NearFullPercent: in.NearFullPercent,
+		CriticalPercent: in.CriticalPercent,
+	}
+}
+
+func convertUsageAlertFromHub(in lvmv1alpha1.UsageAlertConfig) UsageAlert {
+	return UsageAlert{
+		Alert:           convertAlertFromHub(in.AlertConfig),
+		NearFullPercent: in.NearFullPercent,
+		CriticalPercent: in.CriticalPercent,
+	}
+}
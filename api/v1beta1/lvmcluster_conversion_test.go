@@ -0,0 +1,150 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"sigs.k8s.io/randfill"
+)
+
+func newFiller(data []byte) *randfill.Filler {
+	return randfill.NewFromGoFuzz(data).NilChance(0.2).NumElements(0, 3).Funcs(
+		// quantities are only compared by value, so only their value is filled
+		func(q *resource.Quantity, c randfill.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1<<40), resource.BinarySI)
+		},
+	)
+}
+
+func FuzzLVMClusterConversion(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("lvmcluster"))
+	f.Add([]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f})
+	f.Add(bytes.Repeat([]byte{0xff}, 64))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		filler := newFiller(data)
+
+		hub := &lvmv1alpha1.LVMCluster{}
+		filler.Fill(hub)
+		hub.TypeMeta = metav1.TypeMeta{}
+		// the operator always sets the readiness together with the state
+		hub.Status.Ready = hub.Status.State == lvmv1alpha1.LVMStatusReady
+
+		spoke := &LVMCluster{}
+		require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+		hubAfterRoundTrip := &lvmv1alpha1.LVMCluster{}
+		require.NoError(t, spoke.ConvertTo(hubAfterRoundTrip))
+		if !apiequality.Semantic.DeepEqual(hub, hubAfterRoundTrip) {
+			t.Errorf("v1alpha1 -> v1beta1 -> v1alpha1 is not lossless:\n%s", diff.Diff(hub, hubAfterRoundTrip))
+		}
+
+		spoke = &LVMCluster{}
+		filler.Fill(spoke)
+		spoke.TypeMeta = metav1.TypeMeta{}
+
+		hub = &lvmv1alpha1.LVMCluster{}
+		require.NoError(t, spoke.DeepCopy().ConvertTo(hub))
+		spokeAfterRoundTrip := &LVMCluster{}
+		require.NoError(t, spokeAfterRoundTrip.ConvertFrom(hub))
+		if !apiequality.Semantic.DeepEqual(spoke, spokeAfterRoundTrip) {
+			t.Errorf("v1beta1 -> v1alpha1 -> v1beta1 is not lossless:\n%s", diff.Diff(spoke, spokeAfterRoundTrip))
+		}
+	})
+}
+
+func TestLVMClusterConversionWebhook(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+
+	ok, err := conversion.IsConvertible(scheme, &lvmv1alpha1.LVMCluster{})
+	require.NoError(t, err)
+	require.True(t, ok, "LVMCluster must be convertible between all of its versions")
+
+	hub := &lvmv1alpha1.LVMCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: lvmv1alpha1.GroupVersion.String(), Kind: "LVMCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "my-lvmcluster", Namespace: "openshift-lvm-storage"},
+		Spec: lvmv1alpha1.LVMClusterSpec{
+			Storage: lvmv1alpha1.Storage{DeviceClasses: []lvmv1alpha1.DeviceClass{{
+				Name:           "vg1",
+				Default:        true,
+				FilesystemType: lvmv1alpha1.FilesystemTypeXFS,
+				DeviceSelector: &lvmv1alpha1.DeviceSelector{Paths: []lvmv1alpha1.DevicePath{"/dev/sdb"}},
+				ThinPoolConfig: &lvmv1alpha1.ThinPoolConfig{Name: "thin-pool-1", SizePercent: 90, OverprovisionRatio: 10},
+				StorageClassOptions: &lvmv1alpha1.StorageClassOptions{
+					AdditionalLabels: map[string]string{"team": "storage"},
+				},
+			}}},
+		},
+		Status: lvmv1alpha1.LVMClusterStatus{
+			Ready: true,
+			State: lvmv1alpha1.LVMStatusReady,
+			DeviceClassStatuses: []lvmv1alpha1.DeviceClassStatus{{
+				Name: "vg1",
+				NodeStatus: []lvmv1alpha1.NodeStatus{{
+					Node:     "node1",
+					VGStatus: lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusReady, Devices: []string{"/dev/sdb"}},
+				}},
+			}},
+		},
+	}
+	raw, err := json.Marshal(hub)
+	require.NoError(t, err)
+
+	review := &apix.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apix.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apix.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: GroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	}
+	body, err := json.Marshal(review)
+	require.NoError(t, err)
+
+	handler := conversion.NewWebhookHandler(scheme, conversion.NewRegistry())
+	req := httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	response := &apix.ConversionReview{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+	require.NotNil(t, response.Response)
+	require.Equal(t, metav1.StatusSuccess, response.Response.Result.Status, response.Response.Result.Message)
+	require.Len(t, response.Response.ConvertedObjects, 1)
+
+	var converted map[string]any
+	require.NoError(t, json.Unmarshal(response.Response.ConvertedObjects[0].Raw, &converted))
+	assert.Equal(t, GroupVersion.String(), converted["apiVersion"])
+
+	spoke := &LVMCluster{}
+	require.NoError(t, json.Unmarshal(response.Response.ConvertedObjects[0].Raw, spoke))
+	require.Len(t, spoke.Spec.DeviceClasses, 1)
+	deviceClass := spoke.Spec.DeviceClasses[0]
+	assert.Equal(t, "vg1", deviceClass.Name)
+	assert.Equal(t, "thin-pool-1", deviceClass.ThinPool.Name)
+	assert.Equal(t, FilesystemTypeXFS, deviceClass.FilesystemType)
+	assert.Equal(t, map[string]string{"team": "storage"}, deviceClass.StorageClass.AdditionalLabels)
+	assert.Equal(t, []DevicePath{"/dev/sdb"}, deviceClass.DeviceSelector.Paths)
+	assert.Equal(t, LVMStateReady, spoke.Status.State)
+	require.Len(t, spoke.Status.DeviceClasses, 1)
+	require.Len(t, spoke.Status.DeviceClasses[0].Nodes, 1)
+	assert.Equal(t, "node1", spoke.Status.DeviceClasses[0].Nodes[0].Node)
+	assert.Equal(t, VolumeGroupStateReady, spoke.Status.DeviceClasses[0].Nodes[0].State)
+}
@@ -0,0 +1,545 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// LVMClusterSpec defines the desired state of LVMCluster
+type LVMClusterSpec struct {
+	// Tolerations to apply to nodes to act on
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// DeviceClasses contains the configuration to assign the local storage devices to the LVM volume groups that you can use to provision persistent volume claims (PVCs).
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	DeviceClasses []DeviceClass `json:"deviceClasses,omitempty"`
+
+	// VGManager configures the vg-manager DaemonSet that manages the volume groups on the nodes.
+	// As the DaemonSet is shared by all LVMClusters, only the configuration of the oldest LVMCluster is used.
+	// +optional
+	VGManager *VGManagerConfig `json:"vgManager,omitempty"`
+
+	// DriftPolicies configure how changes made by others to the resources managed by LVMS are handled.
+	// Such drift is reported through the ResourcesInSync condition, and overwritten unless the policy of the resource is Report.
+	// As the CSIDriver, the SecurityContextConstraints and the vg-manager DaemonSet are shared by all LVMClusters,
+	// only the policies of the oldest LVMCluster are used for them.
+	// +optional
+	DriftPolicies *DriftPolicies `json:"driftPolicies,omitempty"`
+}
+
+// DriftPolicy configures how changes made by others to a resource managed by LVMS are handled.
+// +kubebuilder:validation:Enum=Enforce;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyEnforce overwrites the changes with the desired state of the resource.
+	DriftPolicyEnforce DriftPolicy = "Enforce"
+	// DriftPolicyReport leaves the changes in place and only reports them.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// DriftPolicies configure the drift policy of every kind of resource managed by LVMS. All of them default to Enforce.
+type DriftPolicies struct {
+	// StorageClass is the drift policy of the StorageClasses of the device classes.
+	// +optional
+	StorageClass DriftPolicy `json:"storageClass,omitempty"`
+
+	// CSIDriver is the drift policy of the TopoLVM CSIDriver.
+	// +optional
+	CSIDriver DriftPolicy `json:"csiDriver,omitempty"`
+
+	// SecurityContextConstraints is the drift policy of the SecurityContextConstraints of vg-manager on OpenShift.
+	// +optional
+	SecurityContextConstraints DriftPolicy `json:"securityContextConstraints,omitempty"`
+
+	// VolumeSnapshotClass is the drift policy of the VolumeSnapshotClasses of the thin provisioned device classes.
+	// +optional
+	VolumeSnapshotClass DriftPolicy `json:"volumeSnapshotClass,omitempty"`
+
+	// DaemonSet is the drift policy of the vg-manager DaemonSet.
+	// +optional
+	DaemonSet DriftPolicy `json:"daemonSet,omitempty"`
+}
+
+// VGManagerConfig configures the resources, the scheduling and the logging of the vg-manager DaemonSet.
+type VGManagerConfig struct {
+	// Resources are the compute resources of the vg-manager container.
+	// Requests that are not set default to 5m CPU and 45Mi memory, and there are no limits by default.
+	// The memory limit of the Go runtime (GOMEMLIMIT) is set to the memory limit, or to the memory request if there is no limit.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the priority class of the vg-manager pods. Defaults to openshift-user-critical.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// UpdateStrategy configures how changes of the vg-manager DaemonSet are rolled out to the nodes.
+	// +optional
+	UpdateStrategy *VGManagerUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// NodeSelector restricts vg-manager to the nodes with the given labels, in addition to the node selectors of the device classes.
+	// The volume groups on nodes that do not match are no longer managed.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// LogLevel is the verbosity of the logs of vg-manager. 0 only logs informational messages,
+	// higher levels log debug messages of increasing verbosity. It overrides the log level passed down by the operator.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	LogLevel *int32 `json:"logLevel,omitempty"`
+
+	// Env are additional environment variables of the vg-manager container.
+	// They override the variables of the same name set by LVMS, like GOMEMLIMIT or GOMAXPROCS,
+	// except for NODE_NAME, NAMESPACE and NAME, which cannot be set.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// VGManagerUpdateStrategy configures the rollout of the vg-manager DaemonSet.
+// Changes are rolled out in batches of nodes. The next batch is only updated once vg-manager on every node
+// of the current batch is ready, reports its volume groups as ready and registered the CSI driver with the kubelet.
+type VGManagerUpdateStrategy struct {
+	// MaxUnavailable is the maximum number or percentage of nodes on which vg-manager is updated at the same time.
+	// Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// ProgressDeadline is the time vg-manager has to become healthy again on an updated node.
+	// If it does not, the rollout is halted and the LVMCluster is degraded until the node recovers
+	// or another change is rolled out. Defaults to 15m.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// DeviceClass configures a volume group on the nodes, and the StorageClass to provision volumes from it.
+type DeviceClass struct {
+	// Name specifies a name for the device class
+	// +kubebuilder:validation:MaxLength=245
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +required
+	Name string `json:"name"`
+
+	// Default is a flag to indicate that a device class is the default. You can configure only a single default device class.
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// DeviceSelector contains the configuration to specify paths to the devices that you want to add to the LVM volume group, and force wipe the selected devices.
+	// +optional
+	DeviceSelector *DeviceSelector `json:"deviceSelector,omitempty"`
+
+	// NodeSelector contains the configuration to choose the nodes on which you want to create the LVM volume group. If this field is not configured, all nodes without no-schedule taints are considered.
+	// +optional
+	NodeSelector *corev1.NodeSelector `json:"nodeSelector,omitempty"`
+
+	// ThinPool contains the configuration to create a thin pool in the LVM volume group. If you exclude this field, logical volumes are thick provisioned.
+	// +optional
+	ThinPool *ThinPool `json:"thinPool,omitempty"`
+
+	// FilesystemType sets the default filesystem type for persistent volumes created from this device class.
+	// This determines the filesystem used when provisioning PVCs with volumeMode: Filesystem.
+	// Select either `ext4` or `xfs`. This does not filter devices during discovery.
+	// +kubebuilder:validation:Enum=xfs;ext4
+	// +kubebuilder:default=xfs
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="oldSelf == self",message="filesystemType is immutable"
+	FilesystemType FilesystemType `json:"filesystemType,omitempty"`
+
+	// DeviceDiscoveryPolicy specifies the policy for discovering devices for this device class.
+	// Static means the volume group is created with devices found at install time; new devices are ignored.
+	// Dynamic means devices are continuously discovered and added to the volume group.
+	// When not set, new volume groups default to Static and existing volume groups default to Dynamic for backward compatibility.
+	// +kubebuilder:validation:Enum=Static;Dynamic
+	// +optional
+	DeviceDiscoveryPolicy *DeviceDiscoveryPolicy `json:"deviceDiscoveryPolicy,omitempty"`
+
+	// DeviceHealthPolicy excludes unhealthy devices from being added to the volume group of this device class.
+	// The health of the devices is always collected and reported, it is only used to exclude devices if this is set.
+	// +optional
+	DeviceHealthPolicy *DeviceHealthPolicy `json:"deviceHealthPolicy,omitempty"`
+
+	// OrphanedLogicalVolumePolicy configures how logical volumes in the volume group of this device class
+	// that are not backed by a TopoLVM LogicalVolume are handled. They are only reported if this is not set.
+	// +optional
+	OrphanedLogicalVolumePolicy *OrphanedLogicalVolumePolicy `json:"orphanedLogicalVolumePolicy,omitempty"`
+
+	// StorageClass allows customization of the StorageClass created for this device class.
+	// +optional
+	StorageClass *StorageClass `json:"storageClass,omitempty"`
+
+	// Alerts configures the alerts that LVMS creates for the device class when Prometheus is available in the cluster.
+	// All alerts are enabled with their default thresholds and durations unless configured otherwise.
+	// +optional
+	Alerts *DeviceClassAlerts `json:"alerts,omitempty"`
+}
+
+// DeviceSelector specifies the list of criteria that have to match before a device is assigned
+type DeviceSelector struct {
+	// Paths specify the device paths.
+	// +optional
+	Paths []DevicePath `json:"paths,omitempty"`
+
+	// OptionalPaths specify the optional device paths.
+	// +optional
+	OptionalPaths []DevicePath `json:"optionalPaths,omitempty"`
+
+	// ForceWipeDevicesAndDestroyAllData is a flag to force wipe the selected devices.
+	// This wipes the file signatures on the devices. Use this feature with caution.
+	// Force wipe the devices only when you know that they do not contain any important data.
+	// +optional
+	ForceWipeDevicesAndDestroyAllData *bool `json:"forceWipeDevicesAndDestroyAllData,omitempty"`
+
+	// PartitionFreeSpace is a flag to use the unpartitioned free space of selected devices that already carry a GPT partition table.
+	// When enabled, a new GPT partition spanning the largest free region of the device is created and used as the physical volume.
+	// Existing partitions are never modified, and devices holding the root filesystem are refused.
+	// This option cannot be combined with ForceWipeDevicesAndDestroyAllData and requires explicit device paths.
+	// +optional
+	PartitionFreeSpace *bool `json:"partitionFreeSpace,omitempty"`
+}
+
+// DevicePath is the path of a device on the node.
+type DevicePath string
+
+// ThinPool configures the thin pool of the volume group of a device class.
+type ThinPool struct {
+	// Name specifies a name for the thin pool.
+	// +kubebuilder:validation:Required
+	// +required
+	Name string `json:"name"`
+
+	// SizePercent specifies the percentage of space in the LVM volume group for creating the thin pool.
+	// If the size configuration is 100, the whole disk will be used.
+	// By default, 90% of the disk is used for the thin pool to allow for data or metadata expansion later on.
+	// +kubebuilder:default=90
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=100
+	SizePercent int `json:"sizePercent,omitempty"`
+
+	// OverProvisionRatio specifies a factor by which you can provision additional storage based on the available storage in the thin pool. To prevent over-provisioning through validation, set this field to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	// +required
+	OverprovisionRatio int `json:"overprovisionRatio"`
+
+	// ChunkSizeCalculationPolicy specifies the policy to calculate the chunk size for the underlying volume.
+	// When set to Host, the chunk size is calculated based on the lvm2 host setting on the node.
+	// When set to Static, the chunk size is calculated based on the static size attribute provided within ChunkSize.
+	// +kubebuilder:default=Static
+	// +kubebuilder:validation:Enum=Host;Static
+	// +optional
+	ChunkSizeCalculationPolicy ChunkSizeCalculationPolicy `json:"chunkSizeCalculationPolicy,omitempty"`
+
+	// ChunkSize specifies the statically calculated chunk size for the thin pool.
+	// Thus, It is only used when the ChunkSizeCalculationPolicy is set to Static.
+	// No ChunkSize with a ChunkSizeCalculationPolicy set to Static will result in a default chunk size of 128Ki.
+	// It can be between 64Ki and 1Gi due to the underlying limitations of lvm2.
+	// +optional
+	ChunkSize *resource.Quantity `json:"chunkSize,omitempty"`
+
+	// MetadataSize specifies metadata size for thin pool. It used only when MetadataSizeCalculationPolicy
+	// is set to Static. No MetadataSize with a MetadataSizeCalculationPolicy set to Static will result in
+	// default metadata size of 1Gi. It can be between 2Mi and 16Gi due to the underlying limitations of lvm2.
+	// +optional
+	MetadataSize *resource.Quantity `json:"metadataSize,omitempty"`
+
+	// MetadataSizeCalculationPolicy specifies the policy to calculate metadata size for the underlying volume.
+	// When set to Host, the metadata size is calculated based on lvm2 default settings
+	// When set to Static, the metadata size is calculated based on the static size attribute provided within MetadataSize
+	// +kubebuilder:default=Host
+	// +kubebuilder:validation:Enum=Host;Static
+	// +optional
+	MetadataSizeCalculationPolicy MetadataSizePolicy `json:"metadataSizeCalculationPolicy,omitempty"`
+}
+
+// ChunkSizeCalculationPolicy specifies the policy to calculate the chunk size for the underlying volume.
+// for more information, see man lvm.
+type ChunkSizeCalculationPolicy string
+
+const (
+	// ChunkSizeCalculationPolicyHost calculates the chunk size based on the lvm2 host setting on the node.
+	ChunkSizeCalculationPolicyHost ChunkSizeCalculationPolicy = "Host"
+	// ChunkSizeCalculationPolicyStatic calculates the chunk size based on a static size attribute.
+	ChunkSizeCalculationPolicyStatic ChunkSizeCalculationPolicy = "Static"
+)
+
+// MetadataSizePolicy specifies the policy to calculate the metadata size for the underlying volume.
+type MetadataSizePolicy string
+
+const (
+	// MetadataSizePolicyHost calculates the metadata size based on the lvm2 default settings.
+	MetadataSizePolicyHost MetadataSizePolicy = "Host"
+	// MetadataSizePolicyStatic calculates the metadata size based on a static size attribute.
+	MetadataSizePolicyStatic MetadataSizePolicy = "Static"
+)
+
+// FilesystemType is the filesystem of the persistent volumes of a device class.
+type FilesystemType string
+
+const (
+	FilesystemTypeExt4 FilesystemType = "ext4"
+	FilesystemTypeXFS  FilesystemType = "xfs"
+)
+
+// DeviceDiscoveryPolicy is the policy for discovering the devices of a device class.
+type DeviceDiscoveryPolicy string
+
+const (
+	// DeviceDiscoveryPolicyStatic means the volume group is created with devices found at install time; new devices are ignored.
+	DeviceDiscoveryPolicyStatic DeviceDiscoveryPolicy = "Static"
+	// DeviceDiscoveryPolicyDynamic means devices are continuously discovered and added to the volume group.
+	DeviceDiscoveryPolicyDynamic DeviceDiscoveryPolicy = "Dynamic"
+)
+
+// DeviceHealthPolicy configures the exclusion of devices based on their health as reported by SMART.
+// A device is unhealthy if it failed its SMART overall-health self-assessment or exceeds one of the thresholds.
+// Devices whose health could not be collected are not excluded, and devices that are already part of the volume group
+// are never removed from it.
+type DeviceHealthPolicy struct {
+	// MaxReallocatedSectors is the maximum number of reallocated sectors of an ATA or SCSI device.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReallocatedSectors *int64 `json:"maxReallocatedSectors,omitempty"`
+
+	// MaxMediaErrors is the maximum number of unrecovered media and data integrity errors of an NVMe device.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMediaErrors *int64 `json:"maxMediaErrors,omitempty"`
+
+	// MaxPercentageUsed is the maximum estimate of the used endurance of an NVMe device in percent.
+	// The estimate can exceed 100 once the rated endurance of the device is used up.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	MaxPercentageUsed *int64 `json:"maxPercentageUsed,omitempty"`
+}
+
+// OrphanedLogicalVolumeAction is the action taken for orphaned logical volumes.
+type OrphanedLogicalVolumeAction string
+
+const (
+	// OrphanedLogicalVolumeActionReport only reports orphaned logical volumes in the LVMVolumeGroupNodeStatus.
+	OrphanedLogicalVolumeActionReport OrphanedLogicalVolumeAction = "Report"
+	// OrphanedLogicalVolumeActionDelete reports orphaned logical volumes and deletes them after the grace period.
+	OrphanedLogicalVolumeActionDelete OrphanedLogicalVolumeAction = "Delete"
+)
+
+// OrphanedLogicalVolumePolicy configures the handling of orphaned logical volumes. A logical volume is orphaned
+// if it was provisioned by TopoLVM, but no TopoLVM LogicalVolume on the node refers to it anymore,
+// e.g. because the LogicalVolume was removed while the node was unavailable.
+type OrphanedLogicalVolumePolicy struct {
+	// Action is the action taken for orphaned logical volumes.
+	// Report only reports them, Delete deletes them once they were orphaned for longer than the grace period.
+	// +kubebuilder:validation:Enum=Report;Delete
+	// +kubebuilder:default=Report
+	// +optional
+	Action OrphanedLogicalVolumeAction `json:"action,omitempty"`
+
+	// GracePeriod is the time an orphaned logical volume is retained after it was first found to be orphaned
+	// before it is deleted. Defaults to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// StorageClass defines optional overrides for the StorageClass generated by LVMS for a device class.
+type StorageClass struct {
+	// ReclaimPolicy sets the reclaim policy for PVs provisioned by this device class.
+	// When set to Retain, PVs and their underlying logical volumes are preserved when PVCs are deleted.
+	// +optional
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:validation:XValidation:rule="oldSelf == self",message="reclaimPolicy is immutable once set"
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// VolumeBindingMode sets the binding mode for PVs provisioned by this device class.
+	// +optional
+	// +kubebuilder:default=WaitForFirstConsumer
+	// +kubebuilder:validation:Enum=WaitForFirstConsumer;Immediate
+	// +kubebuilder:validation:XValidation:rule="oldSelf == self",message="volumeBindingMode is immutable once set"
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+
+	// AdditionalParameters sets additional parameters on the StorageClass.
+	// LVMS-owned keys (topolvm.io/device-class, csi.storage.k8s.io/fstype) cannot be overridden.
+	// This field is immutable after creation.
+	// +optional
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:MaxProperties=16
+	// +kubebuilder:validation:XValidation:rule="oldSelf == self",message="additionalParameters is immutable once set"
+	AdditionalParameters map[string]string `json:"additionalParameters,omitempty"`
+
+	// AdditionalLabels sets additional labels on the StorageClass.
+	// This is the only StorageClass field that can be changed after creation.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=16
+	AdditionalLabels map[string]string `json:"additionalLabels,omitempty"`
+}
+
+// DeviceClassAlerts configures the alerts on the capacity and the health of a device class.
+type DeviceClassAlerts struct {
+	// VolumeGroupUsage configures the alerts on the used capacity of the volume group.
+	// +optional
+	VolumeGroupUsage *UsageAlert `json:"volumeGroupUsage,omitempty"`
+
+	// ThinPoolDataUsage configures the alerts on the data usage of the thin pool.
+	// It is only used if the device class has a ThinPool.
+	// +optional
+	ThinPoolDataUsage *UsageAlert `json:"thinPoolDataUsage,omitempty"`
+
+	// ThinPoolMetadataUsage configures the alerts on the metadata usage of the thin pool.
+	// It is only used if the device class has a ThinPool.
+	// +optional
+	ThinPoolMetadataUsage *UsageAlert `json:"thinPoolMetadataUsage,omitempty"`
+
+	// VolumeGroupStatus configures the alerts on volume groups that are Degraded or Failed on a node.
+	// +optional
+	VolumeGroupStatus *Alert `json:"volumeGroupStatus,omitempty"`
+
+	// MissingPhysicalVolumes configures the alert on physical volumes of the volume group that are missing on a node.
+	// +optional
+	MissingPhysicalVolumes *Alert `json:"missingPhysicalVolumes,omitempty"`
+}
+
+// Alert configures whether an alert is created and how long its condition has to hold before it fires.
+type Alert struct {
+	// Enabled is a flag to create the alert. Alerts are enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// For is the duration the condition of the alert has to hold before the alert fires. Defaults to 5m.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+}
+
+// UsageAlert configures a pair of warning and critical alerts on a usage percentage.
+type UsageAlert struct {
+	Alert `json:",inline"`
+
+	// NearFullPercent is the usage percentage above which the warning alert fires. Defaults to 75.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	NearFullPercent *int `json:"nearFullPercent,omitempty"`
+
+	// CriticalPercent is the usage percentage above which the critical alert fires. Defaults to 85.
+	// It has to be greater than NearFullPercent.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CriticalPercent *int `json:"criticalPercent,omitempty"`
+}
+
+// LVMState summarizes the conditions of an LVMCluster.
+type LVMState string
+
+const (
+	// LVMStateProgressing means that the LVMCluster is being created or updated
+	LVMStateProgressing LVMState = "Progressing"
+	// LVMStateReady means that the LVMCluster has been created and is ready
+	LVMStateReady LVMState = "Ready"
+	// LVMStateFailed means that the LVMCluster could not be created
+	LVMStateFailed LVMState = "Failed"
+	// LVMStateDegraded means that the LVMCluster has been created but is not using the specified configuration
+	LVMStateDegraded LVMState = "Degraded"
+	// LVMStateUnknown means that the state of the LVMCluster is unknown
+	LVMStateUnknown LVMState = "Unknown"
+)
+
+// LVMClusterStatus defines the observed state of LVMCluster
+type LVMClusterStatus struct {
+	// State summarizes the conditions of the LVMCluster. The LVMCluster is ready if the state is Ready.
+	// +optional
+	State LVMState `json:"state,omitempty"`
+
+	// Conditions describes the state of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// DeviceClasses describes the status of all device classes
+	// +optional
+	DeviceClasses []DeviceClassStatus `json:"deviceClasses,omitempty"`
+}
+
+// DeviceClassStatus defines the observed status of the device class across all nodes
+type DeviceClassStatus struct {
+	// Name is the name of the device class
+	Name string `json:"name,omitempty"`
+	// Nodes describes the volume group of the device class on every node
+	// +optional
+	Nodes []NodeStatus `json:"nodes,omitempty"`
+	// Size is the total capacity of the volume groups of the device class across all nodes
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the volume groups of the device class across all nodes
+	// that is not allocated to any logical volume
+	// +optional
+	Free *resource.Quantity `json:"free,omitempty"`
+	// ThinPoolSize is the total capacity of the thin pools of the device class across all nodes
+	// +optional
+	ThinPoolSize *resource.Quantity `json:"thinPoolSize,omitempty"`
+	// ThinPoolUsed is the data space in use in the thin pools of the device class across all nodes
+	// +optional
+	ThinPoolUsed *resource.Quantity `json:"thinPoolUsed,omitempty"`
+}
+
+// NodeStatus defines the observed state of the volume group of a device class on the node
+type NodeStatus struct {
+	// Node is the name of the node
+	Node              string `json:"node,omitempty"`
+	VolumeGroupStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.state`
+
+// LVMCluster is the Schema for the lvmclusters API
+type LVMCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LVMClusterSpec   `json:"spec,omitempty"`
+	Status LVMClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LVMClusterList contains a list of LVMCluster
+type LVMClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LVMCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LVMCluster{}, &LVMClusterList{})
+}
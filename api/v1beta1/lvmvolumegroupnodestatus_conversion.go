@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+	"strings"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this LVMVolumeGroupNodeStatus to the Hub version (v1alpha1).
+// Volume groups with a state are converted to the volume group statuses in the spec of v1alpha1,
+// and volume groups that are reconciled by vg-manager or have no state to the volume groups in its status.
+func (src *LVMVolumeGroupNodeStatus) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*lvmv1alpha1.LVMVolumeGroupNodeStatus)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.LVMVGStatus = nil
+	dst.Status.VolumeGroups = nil
+	for _, vg := range src.Status.VolumeGroups {
+		if vg.State != "" {
+			dst.Spec.LVMVGStatus = append(dst.Spec.LVMVGStatus, convertVolumeGroupStatusToHub(vg.VolumeGroupStatus))
+		}
+		if vg.State == "" || isReconciled(vg) {
+			dst.Status.VolumeGroups = append(dst.Status.VolumeGroups, lvmv1alpha1.VolumeGroupConditions{
+				Name:              vg.Name,
+				Conditions:        vg.Conditions,
+				LastReconcileTime: vg.LastReconcileTime,
+				VGManagerVersion:  vg.VGManagerVersion,
+				OrphanedLogicalVolumes: convertSlice(vg.OrphanedLogicalVolumes, func(in OrphanedLogicalVolume) lvmv1alpha1.OrphanedLogicalVolume {
+					return lvmv1alpha1.OrphanedLogicalVolume{
+						Name:          in.Name,
+						Size:          in.Size,
+						CreationTime:  in.CreationTime,
+						OrphanedSince: in.OrphanedSince,
+						DeletionTime:  in.DeletionTime,
+					}
+				}),
+				MissingLogicalVolumes: convertSlice(vg.MissingLogicalVolumes, func(in MissingLogicalVolume) lvmv1alpha1.MissingLogicalVolume {
+					return lvmv1alpha1.MissingLogicalVolume{Name: in.Name, VolumeID: in.VolumeID}
+				}),
+			})
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+// The volume group statuses in the spec and the volume groups in the status of v1alpha1 are merged by name,
+// and sorted by name, as their order is not significant.
+func (dst *LVMVolumeGroupNodeStatus) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*lvmv1alpha1.LVMVolumeGroupNodeStatus)
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Status.VolumeGroups = nil
+	indexes := make(map[string]int, len(src.Spec.LVMVGStatus))
+	for _, vg := range src.Spec.LVMVGStatus {
+		indexes[vg.Name] = len(dst.Status.VolumeGroups)
+		dst.Status.VolumeGroups = append(dst.Status.VolumeGroups, NodeVolumeGroupStatus{VolumeGroupStatus: convertVolumeGroupStatusFromHub(vg)})
+	}
+	for _, vg := range src.Status.VolumeGroups {
+		i, ok := indexes[vg.Name]
+		if !ok {
+			i = len(dst.Status.VolumeGroups)
+			dst.Status.VolumeGroups = append(dst.Status.VolumeGroups, NodeVolumeGroupStatus{VolumeGroupStatus: VolumeGroupStatus{Name: vg.Name}})
+		}
+		volumeGroup := &dst.Status.VolumeGroups[i]
+		volumeGroup.Conditions = vg.Conditions
+		volumeGroup.LastReconcileTime = vg.LastReconcileTime
+		volumeGroup.VGManagerVersion = vg.VGManagerVersion
+		volumeGroup.OrphanedLogicalVolumes = convertSlice(vg.OrphanedLogicalVolumes, func(in lvmv1alpha1.OrphanedLogicalVolume) OrphanedLogicalVolume {
+			return OrphanedLogicalVolume{
+				Name:          in.Name,
+				Size:          in.Size,
+				CreationTime:  in.CreationTime,
+				OrphanedSince: in.OrphanedSince,
+				DeletionTime:  in.DeletionTime,
+			}
+		})
+		volumeGroup.MissingLogicalVolumes = convertSlice(vg.MissingLogicalVolumes, func(in lvmv1alpha1.MissingLogicalVolume) MissingLogicalVolume {
+			return MissingLogicalVolume{Name: in.Name, VolumeID: in.VolumeID}
+		})
+	}
+	slices.SortStableFunc(dst.Status.VolumeGroups, func(a, b NodeVolumeGroupStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return nil
+}
+
+// isReconciled returns whether the volume group carries the state of its reconciliation by vg-manager.
+func isReconciled(vg NodeVolumeGroupStatus) bool {
+	return len(vg.Conditions) > 0 || vg.LastReconcileTime != nil || vg.VGManagerVersion != "" ||
+		len(vg.OrphanedLogicalVolumes) > 0 || len(vg.MissingLogicalVolumes) > 0
+}
+
+func convertVolumeGroupStatusToHub(in VolumeGroupStatus) lvmv1alpha1.VGStatus {
+	return lvmv1alpha1.VGStatus{
+		Name:    in.Name,
+		Status:  lvmv1alpha1.VGStatusType(in.State),
+		Reason:  in.Reason,
+		Devices: in.Devices,
+		Size:    in.Size,
+		Free:    in.Free,
+		PhysicalVolumes: convertSlice(in.PhysicalVolumes, func(in PhysicalVolumeStatus) lvmv1alpha1.PhysicalVolumeStatus {
+			return lvmv1alpha1.PhysicalVolumeStatus{
+				Name:       in.Name,
+				Size:       in.Size,
+				Free:       in.Free,
+				DeviceSize: in.DeviceSize,
+				Missing:    in.Missing,
+				Health: convertPointer(in.Health, func(in DeviceHealth) lvmv1alpha1.DeviceHealth {
+					return lvmv1alpha1.DeviceHealth{
+						Device:             in.Device,
+						Healthy:            in.Healthy,
+						ReallocatedSectors: in.ReallocatedSectors,
+						MediaErrors:        in.MediaErrors,
+						PercentageUsed:     in.PercentageUsed,
+						Temperature:        in.Temperature,
+					}
+				}),
+			}
+		}),
+		ThinPool: convertPointer(in.ThinPool, func(in ThinPoolStatus) lvmv1alpha1.ThinPoolStatus {
+			return lvmv1alpha1.ThinPoolStatus{
+				Name:               in.Name,
+				Size:               in.Size,
+				DataPercent:        in.DataPercent,
+				MetadataPercent:    in.MetadataPercent,
+				ChunkSize:          in.ChunkSize,
+				LogicalVolumeCount: in.LogicalVolumeCount,
+			}
+		}),
+		Excluded: convertSlice(in.Excluded, func(in ExcludedDevice) lvmv1alpha1.ExcludedDevice {
+			return lvmv1alpha1.ExcludedDevice{Name: in.Name, Reasons: in.Reasons}
+		}),
+		Multipath: convertSlice(in.Multipath, func(in MultipathDevice) lvmv1alpha1.MultipathDevice {
+			return lvmv1alpha1.MultipathDevice{
+				Name: in.Name,
+				Paths: convertSlice(in.Paths, func(in MultipathPath) lvmv1alpha1.MultipathPath {
+					return lvmv1alpha1.MultipathPath{Name: in.Name, State: in.State, Healthy: in.Healthy}
+				}),
+			}
+		}),
+		DeviceDiscoveryPolicy: lvmv1alpha1.DeviceDiscoveryPolicyStatus(in.DeviceDiscoveryPolicy),
+	}
+}
+
+func convertVolumeGroupStatusFromHub(in lvmv1alpha1.VGStatus) VolumeGroupStatus {
+	return VolumeGroupStatus{
+		Name:    in.Name,
+		State:   VolumeGroupState(in.Status),
+		Reason:  in.Reason,
+		Devices: in.Devices,
+		Size:    in.Size,
+		Free:    in.Free,
+		PhysicalVolumes: convertSlice(in.PhysicalVolumes, func(in lvmv1alpha1.PhysicalVolumeStatus) PhysicalVolumeStatus {
+			return PhysicalVolumeStatus{
+				Name:       in.Name,
+				Size:       in.Size,
+				Free:       in.Free,
+				DeviceSize: in.DeviceSize,
+				Missing:    in.Missing,
+				Health: convertPointer(in.Health, func(in lvmv1alpha1.DeviceHealth) DeviceHealth {
+					return DeviceHealth{
+						Device:             in.Device,
+						Healthy:            in.Healthy,
+						ReallocatedSectors: in.ReallocatedSectors,
+						MediaErrors:        in.MediaErrors,
+						PercentageUsed:     in.PercentageUsed,
+						Temperature:        in.Temperature,
+					}
+				}),
+			}
+		}),
+		ThinPool: convertPointer(in.ThinPool, func(in lvmv1alpha1.ThinPoolStatus) ThinPoolStatus {
+			return ThinPoolStatus{
+				Name:               in.Name,
+				Size:               in.Size,
+				DataPercent:        in.DataPercent,
+				MetadataPercent:    in.MetadataPercent,
+				ChunkSize:          in.ChunkSize,
+				LogicalVolumeCount: in.LogicalVolumeCount,
+			}
+		}),
+		Excluded: convertSlice(in.Excluded, func(in lvmv1alpha1.ExcludedDevice) ExcludedDevice {
+			return ExcludedDevice{Name: in.Name, Reasons: in.Reasons}
+		}),
+		Multipath: convertSlice(in.Multipath, func(in lvmv1alpha1.MultipathDevice) MultipathDevice {
+			return MultipathDevice{
+				Name: in.Name,
+				Paths: convertSlice(in.Paths, func(in lvmv1alpha1.MultipathPath) MultipathPath {
+					return MultipathPath{Name: in.Name, State: in.State, Healthy: in.Healthy}
+				}),
+			}
+		}),
+		DeviceDiscoveryPolicy: DeviceDiscoveryPolicyStatus(in.DeviceDiscoveryPolicy),
+	}
+}
@@ -0,0 +1,128 @@
+package v1beta1
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// uniqueByName sorts the list by name and removes all but the first entry of every name,
+// as the volume groups are a map keyed by their name.
+func uniqueByName[T any](list []T, name func(T) string) []T {
+	seen := map[string]bool{}
+	list = slices.DeleteFunc(list, func(item T) bool {
+		duplicate := seen[name(item)]
+		seen[name(item)] = true
+		return duplicate
+	})
+	slices.SortStableFunc(list, func(a, b T) int { return strings.Compare(name(a), name(b)) })
+	return list
+}
+
+func FuzzLVMVolumeGroupNodeStatusConversion(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("lvmvolumegroupnodestatus"))
+	f.Add([]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f})
+	f.Add(bytes.Repeat([]byte{0xff}, 64))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		filler := newFiller(data)
+
+		hub := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+		filler.Fill(hub)
+		hub.TypeMeta = metav1.TypeMeta{}
+		// vg-manager always reports a status for the volume groups in the spec,
+		// and its version for the volume groups in the status
+		hub.Spec.LVMVGStatus = uniqueByName(hub.Spec.LVMVGStatus, func(vg lvmv1alpha1.VGStatus) string { return vg.Name })
+		for i := range hub.Spec.LVMVGStatus {
+			if hub.Spec.LVMVGStatus[i].Status == "" {
+				hub.Spec.LVMVGStatus[i].Status = lvmv1alpha1.VGStatusReady
+			}
+		}
+		hub.Status.VolumeGroups = uniqueByName(hub.Status.VolumeGroups, func(vg lvmv1alpha1.VolumeGroupConditions) string { return vg.Name })
+		for i := range hub.Status.VolumeGroups {
+			if hub.Status.VolumeGroups[i].VGManagerVersion == "" {
+				hub.Status.VolumeGroups[i].VGManagerVersion = "4.19.0"
+			}
+		}
+
+		spoke := &LVMVolumeGroupNodeStatus{}
+		require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+		hubAfterRoundTrip := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+		require.NoError(t, spoke.ConvertTo(hubAfterRoundTrip))
+		if !apiequality.Semantic.DeepEqual(hub, hubAfterRoundTrip) {
+			t.Errorf("v1alpha1 -> v1beta1 -> v1alpha1 is not lossless:\n%s", diff.Diff(hub, hubAfterRoundTrip))
+		}
+
+		spoke = &LVMVolumeGroupNodeStatus{}
+		filler.Fill(spoke)
+		spoke.TypeMeta = metav1.TypeMeta{}
+		// a volume group without a state is only known from its reconciliation
+		spoke.Status.VolumeGroups = uniqueByName(spoke.Status.VolumeGroups, func(vg NodeVolumeGroupStatus) string { return vg.Name })
+		for i, vg := range spoke.Status.VolumeGroups {
+			if vg.State == "" {
+				spoke.Status.VolumeGroups[i].VolumeGroupStatus = VolumeGroupStatus{Name: vg.Name}
+			}
+		}
+
+		hub = &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+		require.NoError(t, spoke.DeepCopy().ConvertTo(hub))
+		spokeAfterRoundTrip := &LVMVolumeGroupNodeStatus{}
+		require.NoError(t, spokeAfterRoundTrip.ConvertFrom(hub))
+		if !apiequality.Semantic.DeepEqual(spoke, spokeAfterRoundTrip) {
+			t.Errorf("v1beta1 -> v1alpha1 -> v1beta1 is not lossless:\n%s", diff.Diff(spoke, spokeAfterRoundTrip))
+		}
+	})
+}
+
+func TestLVMVolumeGroupNodeStatusConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, AddToScheme(scheme))
+	ok, err := conversion.IsConvertible(scheme, &lvmv1alpha1.LVMVolumeGroupNodeStatus{})
+	require.NoError(t, err)
+	require.True(t, ok, "LVMVolumeGroupNodeStatus must be convertible between all of its versions")
+
+	now := metav1.Now()
+	hub := &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openshift-lvm-storage"},
+		Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{
+			{Name: "vg2", Status: lvmv1alpha1.VGStatusFailed, Reason: "no devices"},
+			{Name: "vg1", Status: lvmv1alpha1.VGStatusReady, Devices: []string{"/dev/sdb"}},
+		}},
+		Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{
+			{Name: "vg1", LastReconcileTime: &now, VGManagerVersion: "4.19.0"},
+			{Name: "vg3", VGManagerVersion: "4.19.0"},
+		}},
+	}
+
+	spoke := &LVMVolumeGroupNodeStatus{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+	require.Len(t, spoke.Status.VolumeGroups, 3, "the volume groups of the spec and the status must be merged by name")
+	vg1, vg2, vg3 := spoke.Status.VolumeGroups[0], spoke.Status.VolumeGroups[1], spoke.Status.VolumeGroups[2]
+	assert.Equal(t, "vg1", vg1.Name)
+	assert.Equal(t, VolumeGroupStateReady, vg1.State)
+	assert.Equal(t, []string{"/dev/sdb"}, vg1.Devices)
+	assert.Equal(t, "4.19.0", vg1.VGManagerVersion)
+	assert.NotNil(t, vg1.LastReconcileTime)
+	assert.Equal(t, "vg2", vg2.Name)
+	assert.Equal(t, VolumeGroupStateFailed, vg2.State)
+	assert.Empty(t, vg2.VGManagerVersion)
+	assert.Equal(t, "vg3", vg3.Name)
+	assert.Empty(t, vg3.State)
+
+	converted := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+	require.NoError(t, spoke.ConvertTo(converted))
+	assert.ElementsMatch(t, hub.Spec.LVMVGStatus, converted.Spec.LVMVGStatus)
+	assert.ElementsMatch(t, hub.Status.VolumeGroups, converted.Status.VolumeGroups)
+}
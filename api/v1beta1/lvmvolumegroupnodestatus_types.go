@@ -0,0 +1,278 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LVMVolumeGroupNodeStatusStatus defines the observed state of the volume groups on a node
+type LVMVolumeGroupNodeStatusStatus struct {
+	// VolumeGroups describes the volume groups on the node
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VolumeGroups []NodeVolumeGroupStatus `json:"volumeGroups,omitempty"`
+}
+
+// NodeVolumeGroupStatus defines the observed state of a volume group on the node,
+// together with the state of its reconciliation by vg-manager
+type NodeVolumeGroupStatus struct {
+	VolumeGroupStatus `json:",inline"`
+	// Conditions describe the state of the volume group on the node. The observedGeneration of a condition
+	// refers to the generation of the LVMVolumeGroup it was set for.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// LastReconcileTime is the time vg-manager last reconciled the volume group on the node.
+	// It is refreshed at a coarse interval and serves as heartbeat of vg-manager.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+	// VGManagerVersion is the version of vg-manager that last reconciled the volume group on the node
+	// +optional
+	VGManagerVersion string `json:"vgManagerVersion,omitempty"`
+	// OrphanedLogicalVolumes are the logical volumes provisioned by TopoLVM in the volume group
+	// that no TopoLVM LogicalVolume refers to
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	OrphanedLogicalVolumes []OrphanedLogicalVolume `json:"orphanedLogicalVolumes,omitempty"`
+	// MissingLogicalVolumes are the TopoLVM LogicalVolumes of the volume group on the node
+	// whose logical volume does not exist in the volume group
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	MissingLogicalVolumes []MissingLogicalVolume `json:"missingLogicalVolumes,omitempty"`
+}
+
+// VolumeGroupState is the state of a volume group on a node.
+type VolumeGroupState string
+
+const (
+	// VolumeGroupStateProgressing means that the volume group creation is still in progress
+	VolumeGroupStateProgressing VolumeGroupState = "Progressing"
+	// VolumeGroupStateReady means that the volume group has been created and is ready
+	VolumeGroupStateReady VolumeGroupState = "Ready"
+	// VolumeGroupStateFailed means that the volume group could not be created
+	VolumeGroupStateFailed VolumeGroupState = "Failed"
+	// VolumeGroupStateDegraded means that the volume group has been created but is not using the specified configuration
+	VolumeGroupStateDegraded VolumeGroupState = "Degraded"
+	// VolumeGroupStateOrphaned means that the node no longer matches the node selector of the device class,
+	// but the volume group is retained because it still holds persistent or logical volumes
+	VolumeGroupStateOrphaned VolumeGroupState = "Orphaned"
+	// VolumeGroupStateUnknown means that the state of the volume group was not refreshed by vg-manager for too long,
+	// e.g. because vg-manager is not running on the node or the node is not reachable
+	VolumeGroupStateUnknown VolumeGroupState = "Unknown"
+)
+
+// DeviceDiscoveryPolicyStatus is the effective device discovery policy of a volume group.
+type DeviceDiscoveryPolicyStatus string
+
+const (
+	// DeviceDiscoveryPolicyPreconfigured indicates the devices are preconfigured through explicit DeviceSelector paths.
+	DeviceDiscoveryPolicyPreconfigured DeviceDiscoveryPolicyStatus = "Preconfigured"
+	// DeviceDiscoveryPolicyRuntimeDynamic indicates the devices are discovered and added to the volume group dynamically.
+	DeviceDiscoveryPolicyRuntimeDynamic DeviceDiscoveryPolicyStatus = "RuntimeDynamic"
+	// DeviceDiscoveryPolicyRuntimeStatic indicates the volume group is created with devices discovered at install time.
+	DeviceDiscoveryPolicyRuntimeStatic DeviceDiscoveryPolicyStatus = "RuntimeStatic"
+)
+
+// VolumeGroupStatus defines the observed state of a volume group on a node
+type VolumeGroupStatus struct {
+	// Name is the name of the volume group
+	Name string `json:"name,omitempty"`
+	// State tells if the volume group was created on the node
+	// +optional
+	State VolumeGroupState `json:"state,omitempty"`
+	// Reason provides more detail on the state of the volume group
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Devices is the list of devices used by the volume group
+	// +optional
+	Devices []string `json:"devices,omitempty"`
+	// Size is the total capacity of the volume group
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the volume group that is not allocated to any logical volume
+	// +optional
+	Free *resource.Quantity `json:"free,omitempty"`
+	// PhysicalVolumes contains the capacity and state of the physical volumes of the volume group
+	// +optional
+	PhysicalVolumes []PhysicalVolumeStatus `json:"physicalVolumes,omitempty"`
+	// ThinPool contains the usage of the thin pool of the volume group, if one is configured
+	// +optional
+	ThinPool *ThinPoolStatus `json:"thinPool,omitempty"`
+	// Excluded contains the devices that were picked up via selector, but were not used for other reasons.
+	// +optional
+	Excluded []ExcludedDevice `json:"excluded,omitempty"`
+	// Multipath contains the dm-multipath devices that were picked up for the volume group
+	// together with the health of their underlying paths.
+	// +optional
+	Multipath []MultipathDevice `json:"multipath,omitempty"`
+	// DeviceDiscoveryPolicy is the effective device discovery policy of the volume group.
+	// Preconfigured indicates explicit DeviceSelector paths are configured and the discovery policy is not applicable.
+	// RuntimeDynamic indicates devices are discovered and added dynamically at runtime (no explicit paths, Dynamic policy).
+	// RuntimeStatic indicates devices were discovered at install time and new devices are ignored (no explicit paths, Static policy).
+	// +kubebuilder:validation:Enum=Preconfigured;RuntimeDynamic;RuntimeStatic
+	// +optional
+	DeviceDiscoveryPolicy DeviceDiscoveryPolicyStatus `json:"deviceDiscoveryPolicy,omitempty"`
+}
+
+// ExcludedDevice is a device that was not used for the volume group
+type ExcludedDevice struct {
+	// Name is the device that was filtered
+	Name string `json:"name"`
+	// Reasons are the human-readable reasons why the device was excluded from the volume group
+	Reasons []string `json:"reasons"`
+}
+
+// PhysicalVolumeStatus defines the observed state of a physical volume of the volume group
+type PhysicalVolumeStatus struct {
+	// Name is the path of the device backing the physical volume
+	Name string `json:"name"`
+	// Size is the total capacity of the physical volume
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// Free is the capacity of the physical volume that is not allocated to any logical volume
+	// +optional
+	Free *resource.Quantity `json:"free,omitempty"`
+	// DeviceSize is the size of the underlying device, which can exceed the size of the physical volume
+	// if the device was grown after the physical volume was created
+	// +optional
+	DeviceSize *resource.Quantity `json:"deviceSize,omitempty"`
+	// Missing tells if the device of the physical volume can no longer be found on the node
+	// +optional
+	Missing bool `json:"missing,omitempty"`
+	// Health is the health of the disk backing the physical volume as reported by SMART,
+	// if it could be collected
+	// +optional
+	Health *DeviceHealth `json:"health,omitempty"`
+}
+
+// DeviceHealth is the health of a disk as reported by SMART
+type DeviceHealth struct {
+	// Device is the disk the health was collected for, e.g. the disk a partition is part of
+	Device string `json:"device"`
+	// Healthy tells if the disk passed its SMART overall-health self-assessment
+	Healthy bool `json:"healthy"`
+	// ReallocatedSectors is the number of reallocated sectors of an ATA or SCSI disk
+	// +optional
+	ReallocatedSectors *int64 `json:"reallocatedSectors,omitempty"`
+	// MediaErrors is the number of unrecovered media and data integrity errors of an NVMe disk
+	// +optional
+	MediaErrors *int64 `json:"mediaErrors,omitempty"`
+	// PercentageUsed is the estimate of the used endurance of an NVMe disk in percent
+	// +optional
+	PercentageUsed *int64 `json:"percentageUsed,omitempty"`
+	// Temperature is the current temperature of the disk in degrees Celsius
+	// +optional
+	Temperature *int64 `json:"temperature,omitempty"`
+}
+
+// ThinPoolStatus defines the observed state of the thin pool of the volume group
+type ThinPoolStatus struct {
+	// Name is the name of the thin pool logical volume
+	Name string `json:"name"`
+	// Size is the capacity of the thin pool
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// DataPercent is the percentage of the thin pool data space in use, as reported by lvm2
+	// +optional
+	DataPercent string `json:"dataPercent,omitempty"`
+	// MetadataPercent is the percentage of the thin pool metadata space in use, as reported by lvm2
+	// +optional
+	MetadataPercent string `json:"metadataPercent,omitempty"`
+	// ChunkSize is the chunk size of the thin pool
+	// +optional
+	ChunkSize *resource.Quantity `json:"chunkSize,omitempty"`
+	// LogicalVolumeCount is the number of thin logical volumes provisioned from the thin pool
+	LogicalVolumeCount int `json:"logicalVolumeCount"`
+}
+
+// MultipathDevice is a dm-multipath device of the volume group
+type MultipathDevice struct {
+	// Name is the path of the multipath device
+	Name string `json:"name"`
+	// Paths are the underlying paths of the multipath device
+	// +optional
+	Paths []MultipathPath `json:"paths,omitempty"`
+}
+
+// MultipathPath is an underlying path of a dm-multipath device
+type MultipathPath struct {
+	// Name is the path of the underlying device
+	Name string `json:"name"`
+	// State is the state of the path as reported by the kernel, e.g. running or offline
+	// +optional
+	State string `json:"state,omitempty"`
+	// Healthy tells if the path is usable for I/O
+	Healthy bool `json:"healthy"`
+}
+
+// OrphanedLogicalVolume is a logical volume provisioned by TopoLVM that no TopoLVM LogicalVolume refers to
+type OrphanedLogicalVolume struct {
+	// Name is the name of the logical volume
+	Name string `json:"name"`
+	// Size is the size of the logical volume
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// CreationTime is the time the logical volume was created as recorded by lvm2
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+	// OrphanedSince is the time the logical volume was first found to be orphaned,
+	// from which the grace period of the OrphanedLogicalVolumePolicy is measured
+	// +optional
+	OrphanedSince *metav1.Time `json:"orphanedSince,omitempty"`
+	// DeletionTime is the time the logical volume is deleted at according to the OrphanedLogicalVolumePolicy
+	// +optional
+	DeletionTime *metav1.Time `json:"deletionTime,omitempty"`
+}
+
+// MissingLogicalVolume is a TopoLVM LogicalVolume whose logical volume does not exist in the volume group
+type MissingLogicalVolume struct {
+	// Name is the name of the TopoLVM LogicalVolume
+	Name string `json:"name"`
+	// VolumeID is the name of the logical volume that is missing in the volume group
+	VolumeID string `json:"volumeID"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// LVMVolumeGroupNodeStatus is the Schema for the lvmvolumegroupnodestatuses API.
+// Unlike v1alpha1, it has no spec, as it only reports the observed state of the volume groups on the node.
+type LVMVolumeGroupNodeStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status LVMVolumeGroupNodeStatusStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LVMVolumeGroupNodeStatusList contains a list of LVMVolumeGroupNodeStatus
+type LVMVolumeGroupNodeStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LVMVolumeGroupNodeStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LVMVolumeGroupNodeStatus{}, &LVMVolumeGroupNodeStatusList{})
+}
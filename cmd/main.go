@@ -11,6 +11,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	secv1 "github.com/openshift/api/security/v1"
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	lvmv1beta1 "github.com/openshift/lvm-operator/v4/api/v1beta1"
 	"github.com/openshift/lvm-operator/v4/cmd/operator"
 	"github.com/openshift/lvm-operator/v4/cmd/vgmanager"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
@@ -42,6 +43,7 @@ func NewCmd(setupLog logr.Logger) *cobra.Command {
 
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(lvmv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(lvmv1beta1.AddToScheme(scheme))
 	utilruntime.Must(topolvmv1.AddToScheme(scheme))
 	utilruntime.Must(snapapi.AddToScheme(scheme))
 	utilruntime.Must(secv1.Install(scheme))
@@ -34,12 +34,15 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/logpassthrough"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmvolumegroup"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/node/removal"
 	persistent_volume "github.com/openshift/lvm-operator/v4/internal/controllers/persistent-volume"
 	persistent_volume_claim "github.com/openshift/lvm-operator/v4/internal/controllers/persistent-volume-claim"
 	internalCSI "github.com/openshift/lvm-operator/v4/internal/csi"
+	"github.com/openshift/lvm-operator/v4/internal/migration"
 	"github.com/openshift/lvm-operator/v4/internal/migration/microlvms"
 	wipe_refactor "github.com/openshift/lvm-operator/v4/internal/migration/wipe-refactor"
+	"github.com/openshift/lvm-operator/v4/internal/version"
 	"github.com/spf13/cobra"
 	topolvmcontrollers "github.com/topolvm/topolvm/pkg/controller"
 	"github.com/topolvm/topolvm/pkg/driver"
@@ -74,6 +77,7 @@ const (
 	DefaultDiagnosticsAddr      = ":8443"
 	DefaultProbeAddr            = ":8081"
 	DefaultEnableLeaderElection = false
+	DefaultNodeStatusStaleAfter = 15 * time.Minute
 )
 
 var DefaultVGManagerCommand = []string{"/lvms", "vgmanager"}
@@ -88,8 +92,9 @@ type Options struct {
 
 	LogPassthroughOptions *logpassthrough.Options
 
-	vgManagerCommand []string
-	Metrics          *connection.ExtendedCSIMetricsManager
+	vgManagerCommand     []string
+	nodeStatusStaleAfter time.Duration
+	Metrics              *connection.ExtendedCSIMetricsManager
 }
 
 // NewCmd creates a new CLI command
@@ -122,6 +127,10 @@ func NewCmd(opts *Options) *cobra.Command {
 	cmd.Flags().StringSliceVar(
 		&opts.vgManagerCommand, "vgmanager-cmd", DefaultVGManagerCommand, "The command that should be used to start vgmanager on the node. Useful for debugging purposes but normally not changed.",
 	)
+	cmd.Flags().DurationVar(
+		&opts.nodeStatusStaleAfter, "node-status-stale-after", DefaultNodeStatusStaleAfter,
+		"The duration after which the volume group status reported by vgmanager on a node is considered stale if it was not refreshed. Set to 0 to disable.",
+	)
 
 	return cmd
 }
@@ -180,12 +189,26 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 		}
 	}
 
-	if err := microlvms.NewCleanup(setupClient, operatorNamespace).RemovePreMicroLVMSComponents(ctx); err != nil {
-		return fmt.Errorf("failed to run pre 4.16 MicroLVMS cleanup: %w", err)
+	// upgrade migrations run before the manager is created, so that the operator only becomes ready once they completed
+	migrations, err := migration.NewRegistry(setupClient, operatorNamespace, version.Get(),
+		migration.Migration{
+			ID:  "remove-pre-microlvms-components",
+			Run: microlvms.NewCleanup(setupClient, operatorNamespace).RemovePreMicroLVMSComponents,
+		},
+		migration.Migration{
+			ID:  "annotate-wiped-volume-groups",
+			Run: wipe_refactor.NewWipeRefactor(setupClient, operatorNamespace).AnnotateExistingLVMVolumeGroupsIfWipingEnabled,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register upgrade migrations: %w", err)
 	}
-
-	if err := wipe_refactor.NewWipeRefactor(setupClient, operatorNamespace).AnnotateExistingLVMVolumeGroupsIfWipingEnabled(ctx); err != nil {
-		return fmt.Errorf("failed to run wipe migration logic: %w", err)
+	err = migrations.Run(ctx)
+	for _, result := range migrations.Results() {
+		opts.SetupLog.Info("upgrade migration", "migration", result.ID, "state", result.State, "ran", result.Ran)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run upgrade migrations: %w", err)
 	}
 
 	tlsProfile, err := ctrlRuntimeCommon.FetchAPIServerTLSProfile(ctx, setupClient)
@@ -266,6 +289,7 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 		EnableSnapshotting:               enableSnapshotting,
 		LogPassthroughOptions:            opts.LogPassthroughOptions,
 		VGManagerCommand:                 opts.vgManagerCommand,
+		NodeStatusStaleAfter:             opts.nodeStatusStaleAfter,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create LVMCluster controller: %w", err)
 	}
@@ -275,16 +299,29 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 		return fmt.Errorf("unable to create NodeRemovalController controller: %w", err)
 	}
 
+	opts.SetupLog.Info("starting LVMVolumeGroup status controller")
+	if err = lvmvolumegroup.NewReconciler(mgr.GetClient(), operatorNamespace).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create LVMVolumeGroup status controller: %w", err)
+	}
+
 	if err = (&lvmv1alpha1.LVMCluster{}).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create LVMCluster webhook: %w", err)
 	}
 
+	if err = (&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create LVMVolumeGroupNodeStatus webhook: %w", err)
+	}
+
+	if err = persistent_volume.SetupWebhookWithManager(mgr, operatorNamespace); err != nil {
+		return fmt.Errorf("unable to create PersistentVolume webhook: %w", err)
+	}
+
 	pvController := persistent_volume.NewReconciler(mgr.GetClient(), mgr.GetEventRecorder("lvms-pv-controller"))
 	if err := pvController.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create PersistentVolume controller: %w", err)
 	}
 
-	pvcController := persistent_volume_claim.NewReconciler(mgr.GetClient(), mgr.GetEventRecorder("lvms-pvc-controller"))
+	pvcController := persistent_volume_claim.NewReconciler(mgr.GetClient(), mgr.GetEventRecorder("lvms-pvc-controller"), operatorNamespace)
 	if err := pvcController.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create PersistentVolumeClaim controller: %w", err)
 	}
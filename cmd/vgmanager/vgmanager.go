@@ -42,6 +42,10 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvmd"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvstats"
+	vgmanagermetrics "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/partition"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/util"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/wipefs"
 	icsi "github.com/openshift/lvm-operator/v4/internal/csi"
@@ -52,6 +56,8 @@ import (
 	"github.com/topolvm/topolvm/pkg/runners"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	registerapi "k8s.io/kubelet/pkg/apis/pluginregistration/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -65,6 +71,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -108,6 +115,90 @@ func NewCmd(opts *Options) *cobra.Command {
 	cmd.Flags().StringVar(
 		&opts.healthProbeAddr, "health-probe-bind-address", DefaultHealthProbeAddr, "The address the probe endpoint binds to.",
 	)
+
+	cmd.AddCommand(NewRestoreMetadataCmd(opts))
+	cmd.AddCommand(NewRecoverCmd(opts))
+	return cmd
+}
+
+// NewRestoreMetadataCmd creates the CLI command restoring the metadata of a volume group on the node from a backup.
+// It is run inside the vg-manager pod of the node.
+func NewRestoreMetadataCmd(opts *Options) *cobra.Command {
+	var vgName string
+	var revision int
+	cmd := &cobra.Command{
+		Use:   "restore-metadata",
+		Short: "Restore the LVM metadata of a volume group on this node from a backup",
+		Long: fmt.Sprintf("Restores the LVM metadata of a volume group on this node with vgcfgrestore from a backup Secret. "+
+			"The Secret has to be annotated with %s=<volume group> to confirm the restore.",
+			constants.MetadataRestoreConfirmationAnnotation),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			namespace, err := cluster.GetOperatorNamespace()
+			if err != nil {
+				return fmt.Errorf("unable to get operatorNamespace: %w", err)
+			}
+			c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: opts.Scheme})
+			if err != nil {
+				return fmt.Errorf("unable to initialize client: %w", err)
+			}
+			ctx := log.IntoContext(cmd.Context(), opts.SetupLog)
+			return vgmanager.RestoreMetadata(ctx, c, lvm.NewDefaultHostLVM(), constants.MetadataBackupDir,
+				namespace, os.Getenv("NODE_NAME"), vgName, revision)
+		},
+	}
+	cmd.Flags().StringVar(&vgName, "volume-group", "", "The name of the volume group to restore.")
+	cmd.Flags().IntVar(&revision, "revision", 0, "The revision of the backup to restore, defaults to the latest backup.")
+	_ = cmd.MarkFlagRequired("volume-group")
+	return cmd
+}
+
+// NewRecoverCmd creates the CLI command recovering the LogicalVolumes and PersistentVolumes of the logical volumes
+// on the node after they were lost in the cluster. It is run inside the vg-manager pod of the node.
+func NewRecoverCmd(opts *Options) *cobra.Command {
+	var apply bool
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Recover the LogicalVolumes and PersistentVolumes of the logical volumes on this node",
+		Long: "Matches the logical volumes provisioned by TopoLVM in the volume groups on this node with the " +
+			"LogicalVolumes and PersistentVolumes of the cluster and reports the ones that are missing, for example " +
+			"after the cluster was restored from an etcd backup. With --apply, the missing LogicalVolumes and " +
+			"PersistentVolumes are created. The PersistentVolumes are created with the Retain reclaim policy and " +
+			"pre-bound to the claim recorded in the tags of the logical volume, if any.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			namespace, err := cluster.GetOperatorNamespace()
+			if err != nil {
+				return fmt.Errorf("unable to get operatorNamespace: %w", err)
+			}
+			c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: opts.Scheme})
+			if err != nil {
+				return fmt.Errorf("unable to initialize client: %w", err)
+			}
+			ctx := log.IntoContext(cmd.Context(), opts.SetupLog)
+
+			recovery := &vgmanager.Recovery{
+				Client:    c,
+				LVM:       lvm.NewDefaultHostLVM(),
+				LSBLK:     lsblk.NewDefaultHostLSBLK(),
+				NodeName:  os.Getenv("NODE_NAME"),
+				Namespace: namespace,
+			}
+			items, err := recovery.Plan(ctx)
+			if err != nil {
+				return err
+			}
+			if err := vgmanager.WriteRecoveryReport(cmd.OutOrStdout(), items); err != nil {
+				return err
+			}
+			if !apply {
+				return nil
+			}
+			return recovery.Apply(ctx, items)
+		},
+	}
+	cmd.Flags().BoolVar(&apply, "apply", false,
+		"Create the missing LogicalVolumes and PersistentVolumes instead of only reporting them.")
 	return cmd
 }
 
@@ -167,6 +258,11 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 	}
 	tlsOpts = append(tlsOpts, tlsConfig)
 
+	// the vgmanager metrics are served next to the controller-runtime metrics on the secure diagnostics endpoint
+	if err := vgmanagermetrics.Register(ctrlmetrics.Registry); err != nil {
+		return fmt.Errorf("unable to register vgmanager metrics: %w", err)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: opts.Scheme,
 		Metrics: metricsserver.Options{
@@ -187,6 +283,13 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 			},
 			ByObject: map[client.Object]cache.ByObject{
 				&v1.APIServer{}: {},
+				// only the metadata backups of this node are read
+				&corev1.Secret{}: {Label: labels.SelectorFromSet(labels.Set{constants.MetadataBackupNodeLabel: vgmanager.MetadataBackupLabelValue(nodeName)})},
+				// only the claims that import a logical volume on this node are read
+				&corev1.PersistentVolumeClaim{}: {
+					Label:      labels.SelectorFromSet(labels.Set{constants.ImportNodeLabel: nodeName}),
+					Namespaces: map[string]cache.Config{cache.AllNamespaces: {}},
+				},
 			},
 		},
 		GracefulShutdownTimeout: ptr.To(time.Duration(-1)),
@@ -266,7 +369,9 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 		LSBLK:            lsblk.NewDefaultHostLSBLK(),
 		Wipefs:           wipefs.NewDefaultHostWipefs(),
 		Dmsetup:          dmsetup.NewDefaultHostDmsetup(),
+		Partitioner:      partition.NewDefaultHostPartitioner(),
 		LVM:              lvm.NewDefaultHostLVM(),
+		SMART:            smart.NewCachingSMART(smart.NewDefaultHostSMART(), smart.DefaultCollectionInterval),
 		NodeName:         nodeName,
 		Namespace:        operatorNamespace,
 		Filters:          filter.DefaultFilters,
@@ -275,6 +380,79 @@ func run(cmd *cobra.Command, _ []string, opts *Options) error {
 		return fmt.Errorf("unable to create controller VGManager: %w", err)
 	}
 
+	if err = (&vgmanager.ImportReconciler{
+		Client:        mgr.GetClient(),
+		EventRecorder: mgr.GetEventRecorder(vgmanager.ImportControllerName),
+		LVM:           lvm.NewDefaultHostLVM(),
+		NodeName:      nodeName,
+		Namespace:     operatorNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller for logical volume imports: %w", err)
+	}
+
+	if err = (&vgmanager.LogicalVolumeTagReconciler{
+		Client:     mgr.GetClient(),
+		LVM:        lvm.NewDefaultHostLVM(),
+		NodeName:   nodeName,
+		SymlinkDir: constants.LogicalVolumeSymlinkDir,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller for logical volume tags: %w", err)
+	}
+
+	if err := mgr.Add(&vgmanager.Inventory{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		LSBLK:            lsblk.NewDefaultHostLSBLK(),
+		LVM:              lvm.NewDefaultHostLVM(),
+		Filters:          filter.DefaultFilters,
+		NodeName:         nodeName,
+		Namespace:        operatorNamespace,
+		SymlinkResolveFn: filepath.EvalSymlinks,
+		ByIDPath:         vgmanager.DefaultByIDPath,
+		Interval:         vgmanager.DefaultInventoryInterval,
+	}); err != nil {
+		return fmt.Errorf("could not add device inventory: %w", err)
+	}
+
+	if err := mgr.Add(&vgmanager.Heartbeat{
+		Client:    mgr.GetClient(),
+		NodeName:  nodeName,
+		Namespace: operatorNamespace,
+		Interval:  vgmanager.DefaultHeartbeatInterval,
+	}); err != nil {
+		return fmt.Errorf("could not add heartbeat: %w", err)
+	}
+
+	if err := mgr.Add(&vgmanager.MetadataBackup{
+		Client:    mgr.GetClient(),
+		LVM:       lvm.NewDefaultHostLVM(),
+		NodeName:  nodeName,
+		Namespace: operatorNamespace,
+		Dir:       constants.MetadataBackupDir,
+		Interval:  vgmanager.DefaultMetadataBackupInterval,
+		History:   vgmanager.DefaultMetadataBackupHistory,
+	}); err != nil {
+		return fmt.Errorf("could not add metadata backup: %w", err)
+	}
+
+	if err := mgr.Add(&vgmanager.LogicalVolumeAudit{
+		Client:    mgr.GetClient(),
+		LVM:       lvm.NewDefaultHostLVM(),
+		NodeName:  nodeName,
+		Namespace: operatorNamespace,
+		Interval:  vgmanager.DefaultLogicalVolumeAuditInterval,
+	}); err != nil {
+		return fmt.Errorf("could not add logical volume audit: %w", err)
+	}
+
+	lvStatsCollector := lvstats.NewCollector(mgr.GetClient(), lvm.NewDefaultHostLVM(), nodeName)
+	if err := mgr.Add(lvStatsCollector); err != nil {
+		return fmt.Errorf("could not add logical volume statistics: %w", err)
+	}
+	if err := ctrlmetrics.Registry.Register(lvStatsCollector); err != nil {
+		return fmt.Errorf("unable to register logical volume metrics: %w", err)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return fmt.Errorf("unable to set up health check: %w", err)
 	}
@@ -0,0 +1,38 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// vgmanager-alert-rules prints the PrometheusRule with the alerts on the vgmanager metrics.
+// It is used by `make build-vgmanager-alert-rules` to keep config/prometheus in sync with the Go definitions.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+)
+
+func main() {
+	out, err := metrics.PrometheusRuleYAML()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render vgmanager alert rules: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := os.Stdout.Write(out); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write vgmanager alert rules: %v\n", err)
+		os.Exit(1)
+	}
+}
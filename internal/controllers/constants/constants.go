@@ -30,9 +30,25 @@ const (
 
 	DefaultCSISocket              = "/run/topolvm/csi-topolvm.sock"
 	DeviceClassKey                = "topolvm.io/device-class"
+	TopologyNodeKey               = "topology.topolvm.io/node"
 	FsTypeKey                     = "csi.storage.k8s.io/fstype"
 	DefaultPluginRegistrationPath = "/registration"
 
+	// MetadataBackupDir is the directory on the host in which vg-manager stores the metadata backups
+	// of the volume groups while they are transferred from and to the backup Secrets
+	MetadataBackupDir = "/var/lib/lvms/metadata-backup"
+
+	// LogicalVolumeSymlinkDir is the directory on the host in which vg-manager maintains the symlinks
+	// <namespace>/<claim> to the logical volumes of PersistentVolumeClaims
+	LogicalVolumeSymlinkDir = "/dev/lvms"
+
+	// LVM tags of the logical volumes provisioned by TopoLVM, set as <tag>=<value>
+	LogicalVolumeTagPrefix         = "lvms.openshift.io/"
+	LogicalVolumeClaimNamespaceTag = LogicalVolumeTagPrefix + "pvc-namespace"
+	LogicalVolumeClaimNameTag      = LogicalVolumeTagPrefix + "pvc-name"
+	LogicalVolumeClaimUIDTag       = LogicalVolumeTagPrefix + "pvc-uid"
+	LogicalVolumeDeviceClassTag    = LogicalVolumeTagPrefix + "device-class"
+
 	// name of the lvm-operator container
 	LVMOperatorContainerName = "manager"
 
@@ -42,6 +58,37 @@ const (
 	WorkloadPartitioningManagementAnnotation = "target.workload.openshift.io/management"
 	ManagementAnnotationVal                  = `{"effect": "PreferredDuringScheduling"}`
 
+	// MetadataRestoreConfirmationAnnotation confirms the restore of the volume group metadata from a backup Secret.
+	// Its value must be the name of the volume group.
+	MetadataRestoreConfirmationAnnotation = "lvms.openshift.io/confirm-metadata-restore"
+
+	// ReclaimActionAnnotation requests LVMS to reclaim a Released PersistentVolume with the Retain reclaim policy.
+	// Its value is either ReclaimActionDelete or ReclaimActionRelease.
+	ReclaimActionAnnotation = "lvms.openshift.io/reclaim-action"
+	// ReclaimClaimAnnotation optionally names the PersistentVolumeClaim as <namespace>/<name>
+	// that a PersistentVolume released with ReclaimActionRelease is reserved for.
+	ReclaimClaimAnnotation = "lvms.openshift.io/reclaim-claim"
+
+	// ImportLogicalVolumeAnnotation names the existing logical volume that is imported for a PersistentVolumeClaim.
+	// The node of the logical volume is set with ImportNodeLabel.
+	ImportLogicalVolumeAnnotation = "lvms.openshift.io/import-logical-volume"
+
+	// RecoveredLogicalVolumeAnnotation names the logical volume that a LogicalVolume or PersistentVolume was
+	// recreated for by the recovery of vg-manager.
+	RecoveredLogicalVolumeAnnotation = "lvms.openshift.io/recovered-logical-volume"
+
+	// VGManagerTemplateHashAnnotation is the hash of the pod template of the vg-manager DaemonSet that a vg-manager pod
+	// was created from. Pods with a different hash are outdated and replaced by the staged rollout of the operator.
+	VGManagerTemplateHashAnnotation = "lvms.openshift.io/vg-manager-template-hash"
+
+	// VGManagerAppliedTemplateHashAnnotation is the hash of the pod template of the vg-manager DaemonSet as it was last
+	// applied by the operator. It tells changes of the template by others apart from pending changes of the operator.
+	VGManagerAppliedTemplateHashAnnotation = "lvms.openshift.io/vg-manager-applied-template-hash"
+
+	// DesiredStateHashAnnotation is the hash of the desired state of a resource managed by the operator when it was last applied.
+	// As long as the desired state does not change, differences between the resource and its desired state are drift.
+	DesiredStateHashAnnotation = "lvms.openshift.io/desired-state-hash"
+
 	// DevicesWipedAnnotationPrefix is an annotation prefix that marks when a device has been wiped on a certain node
 	DevicesWipedAnnotationPrefix = "wiped.devices.lvms.openshift.io/"
 
@@ -56,6 +103,23 @@ const (
 	// AppKubernetesComponentLabel is the Kubernetes recommended component label
 	AppKubernetesComponentLabel = "app.kubernetes.io/component"
 
+	// MetadataBackupNodeLabel is the label of the metadata backup Secrets with the node of the volume group
+	MetadataBackupNodeLabel = "lvms.openshift.io/metadata-backup-node"
+	// MetadataBackupVolumeGroupLabel is the label of the metadata backup Secrets with the name of the volume group
+	MetadataBackupVolumeGroupLabel = "lvms.openshift.io/metadata-backup-volume-group"
+	// MetadataBackupVolumeGroupIDLabel is the label of the metadata backup Secrets with the LVM UUID of the volume group
+	MetadataBackupVolumeGroupIDLabel = "lvms.openshift.io/metadata-backup-volume-group-id"
+	// MetadataBackupRevisionLabel is the label of the metadata backup Secrets with the sequence number of the metadata
+	MetadataBackupRevisionLabel = "lvms.openshift.io/metadata-backup-revision"
+
+	// ImportNodeLabel is the label of a PersistentVolumeClaim with the node of the logical volume that is imported for it
+	ImportNodeLabel = "lvms.openshift.io/import-node"
+
+	// ReclaimActionDelete deletes the logical volume of the PersistentVolume on its node and then the PersistentVolume
+	ReclaimActionDelete = "Delete"
+	// ReclaimActionRelease clears the claim of the PersistentVolume, so that it becomes Available for rebinding
+	ReclaimActionRelease = "Release"
+
 	VGManagerLabelVal = "vg-manager"
 	ManagedByLabelVal = "lvms-operator"
 	PartOfLabelVal    = "lvms-provisioner"
@@ -80,6 +144,14 @@ var ReservedStorageClassLabelKeys = map[string]struct{}{
 	AppKubernetesComponentLabel: {},
 }
 
+// ReservedVGManagerEnvVars are the environment variables through which vg-manager learns the identity of its pod.
+// They cannot be set through the additional environment variables of the vg-manager configuration.
+var ReservedVGManagerEnvVars = map[string]struct{}{
+	"NODE_NAME": {},
+	"NAMESPACE": {},
+	"NAME":      {},
+}
+
 // these constants are derived from the TopoLVM recommendations but maintained separately to allow easy override.
 // see https://github.com/topolvm/topolvm/blob/a967c95da14f80955332a00ebb258e319c6c39ac/cmd/topolvm-controller/app/root.go#L17-L28
 const (
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
@@ -52,6 +54,10 @@ type EventReasonError string
 const (
 	EventReasonErrorDeletionPending                  EventReasonError = "DeletionPending"
 	EventReasonErrorResourceReconciliationIncomplete EventReasonError = "ResourceReconciliationIncomplete"
+	EventReasonErrorVGStatusStale                    EventReasonError = "VGStatusStale"
+	EventReasonErrorVGManagerRolloutHalted           EventReasonError = "VGManagerRolloutHalted"
+	EventReasonErrorResourceDriftDetected            EventReasonError = "ResourceDriftDetected"
+	EventReasonErrorResourceDriftCorrected           EventReasonError = "ResourceDriftCorrected"
 	EventReasonResourceReconciliationSuccess         EventReasonInfo  = "ResourceReconciliationSuccess"
 
 	lvmClusterFinalizer = "lvmcluster.topolvm.io"
@@ -76,6 +82,10 @@ type Reconciler struct {
 
 	// LogPassthroughOptions define multiple settings for passing down log settings to created resources
 	LogPassthroughOptions *logpassthrough.Options
+
+	// NodeStatusStaleAfter is the duration after which the status reported by vgmanager on a node is considered stale
+	// if it did not refresh its heartbeat. Zero disables the detection of stale nodes.
+	NodeStatusStaleAfter time.Duration
 }
 
 func (r *Reconciler) GetNamespace() string {
@@ -141,6 +151,7 @@ func (r *Reconciler) GetLogPassthroughOptions() *logpassthrough.Options {
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents/status,verbs=update;patch
 //+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
 
@@ -153,15 +164,6 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	logger := log.FromContext(ctx)
 	logger.V(2).Info("reconciling")
 
-	// Checks that only a single LVMCluster instance exists
-	lvmClusterList := &lvmv1alpha1.LVMClusterList{}
-	if err := r.List(context.TODO(), lvmClusterList, &client.ListOptions{}); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to list LVMCluster instances: %w", err)
-	}
-	if size := len(lvmClusterList.Items); size > 1 {
-		return ctrl.Result{}, fmt.Errorf("there should be a single LVMCluster but multiple were found, %d clusters found", size)
-	}
-
 	// get lvmcluster
 	lvmCluster := &lvmv1alpha1.LVMCluster{}
 	if err := r.Get(ctx, req.NamespacedName, lvmCluster); err != nil {
@@ -229,6 +231,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMCluster) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	// the conditions are reset below, the reported VolumeGroupsReady condition is kept to only emit events on transitions
+	reportedVGsReady := meta.FindStatusCondition(instance.Status.Conditions, lvmv1alpha1.VolumeGroupsReady).DeepCopy()
 	setResourcesAvailableConditionInProgress(instance)
 	setVolumeGroupsReadyConditionInProgress(instance)
 
@@ -247,6 +251,7 @@ func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMClu
 		resource.TopoLVMStorageClass(),
 		resource.CSINode(),
 		resource.ServiceMonitor(),
+		resource.PrometheusRule(),
 	}
 
 	if r.ClusterType == cluster.TypeOCP {
@@ -257,10 +262,24 @@ func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMClu
 		resources = append(resources, resource.TopoLVMVolumeSnapshotClass())
 	}
 
+	type result struct {
+		drifts []resource.Drift
+		err    error
+	}
 	resourceSyncStart := time.Now()
-	results := make(chan error, len(resources))
+	results := make(chan result, len(resources))
 	create := func(i int) {
-		results <- resources[i].EnsureCreated(r, ctx, instance)
+		// drift is detected before the resources are applied, as they are overwritten unless it is only reported
+		if detector, ok := resources[i].(resource.DriftDetector); ok {
+			drifts, err := detector.DetectDrift(r, ctx, instance)
+			if err != nil {
+				results <- result{err: fmt.Errorf("failed to detect drift of %s: %w", resources[i].GetName(), err)}
+				return
+			}
+			results <- result{drifts: drifts, err: resources[i].EnsureCreated(r, ctx, instance)}
+			return
+		}
+		results <- result{err: resources[i].EnsureCreated(r, ctx, instance)}
 	}
 
 	for i := range resources {
@@ -268,18 +287,29 @@ func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMClu
 	}
 
 	var errs []error
+	var drifts []resource.Drift
 	for i := 0; i < len(resources); i++ {
-		if err := <-results; err != nil {
-			errs = append(errs, err)
+		res := <-results
+		if res.err != nil {
+			errs = append(errs, res.err)
 		}
+		drifts = append(drifts, res.drifts...)
 	}
+	r.reportDrift(ctx, instance, drifts)
 
 	resourceSyncElapsedTime := time.Since(resourceSyncStart)
 	if len(errs) > 0 {
 		err := fmt.Errorf("LVMCluster's resources are not yet fully synchronized: %w", errors.Join(errs...))
 		r.WarningEvent(ctx, instance, EventReasonErrorResourceReconciliationIncomplete, err)
 		setResourcesAvailableConditionFalse(instance, err)
-		statusErr := r.updateLVMClusterStatus(ctx, instance)
+		var rolloutHalted *resource.VGManagerRolloutHaltedError
+		if errors.As(err, &rolloutHalted) {
+			r.WarningEvent(ctx, instance, EventReasonErrorVGManagerRolloutHalted, rolloutHalted)
+			setVGManagerRolloutConditionDegraded(instance, rolloutHalted)
+		} else if errors.Is(err, resource.ErrVGManagerRolloutInProgress) {
+			setVGManagerRolloutConditionInProgress(instance, err)
+		}
+		statusErr := r.updateLVMClusterStatus(ctx, instance, reportedVGsReady)
 		if statusErr != nil {
 			logger.Error(statusErr, "failed to update LVMCluster status")
 		}
@@ -295,7 +325,8 @@ func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMClu
 	logger.Info(msg, "resourceSyncElapsedTime", resourceSyncElapsedTime)
 	r.NormalEvent(ctx, instance, EventReasonResourceReconciliationSuccess, msg)
 	setResourcesAvailableConditionTrue(instance)
-	statusErr := r.updateLVMClusterStatus(ctx, instance)
+	setVGManagerRolloutConditionComplete(instance)
+	statusErr := r.updateLVMClusterStatus(ctx, instance, reportedVGsReady)
 	if statusErr != nil {
 		return ctrl.Result{}, statusErr
 	}
@@ -303,7 +334,46 @@ func (r *Reconciler) reconcile(ctx context.Context, instance *lvmv1alpha1.LVMClu
 	return ctrl.Result{Requeue: true, RequeueAfter: 1 * time.Minute}, nil
 }
 
-func (r *Reconciler) updateLVMClusterStatus(ctx context.Context, instance *lvmv1alpha1.LVMCluster) error {
+// reportDrift records the drift of the resources on the LVMCluster. Events are only emitted when the
+// ResourcesInSync condition changes, as drift left in place is detected again on every reconciliation.
+func (r *Reconciler) reportDrift(ctx context.Context, instance *lvmv1alpha1.LVMCluster, drifts []resource.Drift) {
+	slices.SortFunc(drifts, func(a, b resource.Drift) int {
+		return strings.Compare(a.Kind+"/"+a.Name, b.Kind+"/"+b.Name)
+	})
+	reported := meta.FindStatusCondition(instance.Status.Conditions, lvmv1alpha1.ResourcesInSync).DeepCopy()
+
+	var detected, corrected []string
+	for _, drift := range drifts {
+		if drift.Policy == lvmv1alpha1.DriftPolicyReport {
+			detected = append(detected, drift.String())
+		} else {
+			corrected = append(corrected, drift.String())
+		}
+	}
+	switch {
+	case len(detected) > 0:
+		setResourcesInSyncConditionDriftDetected(instance, detected)
+	case len(corrected) > 0:
+		setResourcesInSyncConditionDriftCorrected(instance, corrected)
+	default:
+		setResourcesInSyncConditionTrue(instance)
+	}
+
+	if !resourcesInSyncChanged(reported, meta.FindStatusCondition(instance.Status.Conditions, lvmv1alpha1.ResourcesInSync)) {
+		return
+	}
+	for _, drift := range drifts {
+		if drift.Policy == lvmv1alpha1.DriftPolicyReport {
+			r.WarningEvent(ctx, instance, EventReasonErrorResourceDriftDetected,
+				fmt.Errorf("%s drifted from its desired state and is left in place", drift))
+		} else {
+			r.WarningEvent(ctx, instance, EventReasonErrorResourceDriftCorrected,
+				fmt.Errorf("%s drifted from its desired state and was overwritten", drift))
+		}
+	}
+}
+
+func (r *Reconciler) updateLVMClusterStatus(ctx context.Context, instance *lvmv1alpha1.LVMCluster, reportedVGsReady *metav1.Condition) error {
 	logger := log.FromContext(ctx)
 
 	if len(instance.Spec.Storage.DeviceClasses) == 0 {
@@ -319,8 +389,19 @@ func (r *Reconciler) updateLVMClusterStatus(ctx context.Context, instance *lvmv1
 		if err := r.List(ctx, nodes); err != nil {
 			return fmt.Errorf("failed to list Nodes: %w", err)
 		}
-		setVolumeGroupsReadyCondition(ctx, instance, nodes, vgNodeStatusList)
-		instance.Status.DeviceClassStatuses = computeDeviceClassStatuses(vgNodeStatusList)
+		volumeGroups := &lvmv1alpha1.LVMVolumeGroupList{}
+		if err := r.List(ctx, volumeGroups, client.InNamespace(r.Namespace)); err != nil {
+			return fmt.Errorf("failed to list LVMVolumeGroups: %w", err)
+		}
+		// the node statuses and volume groups are shared by all LVMClusters, only the own device classes are reported
+		vgNodeStatusList = nodeStatusesOfCluster(vgNodeStatusList, instance)
+		volumeGroups = volumeGroupsOfCluster(volumeGroups, instance)
+		staleNodes := getStaleNodes(vgNodeStatusList, time.Now(), r.NodeStatusStaleAfter)
+		setVolumeGroupsReadyCondition(ctx, instance, nodes, volumeGroups, vgNodeStatusList, r.NodeStatusStaleAfter, staleNodes)
+		if current := meta.FindStatusCondition(instance.Status.Conditions, lvmv1alpha1.VolumeGroupsReady); volumeGroupsBecameStale(reportedVGsReady, current) {
+			r.WarningEvent(ctx, instance, EventReasonErrorVGStatusStale, errors.New(current.Message))
+		}
+		instance.Status.DeviceClassStatuses = computeDeviceClassStatuses(vgNodeStatusList, r.NodeStatusStaleAfter, staleNodes)
 	}
 
 	instance.Status.State, instance.Status.Ready = computeLVMClusterReadiness(instance.Status.Conditions)
@@ -531,6 +612,7 @@ func (r *Reconciler) processDelete(ctx context.Context, instance *lvmv1alpha1.LV
 			resource.VGManager(r.ClusterType),
 			resource.CSINode(),
 			resource.ServiceMonitor(),
+			resource.PrometheusRule(),
 		}
 
 		if r.ClusterType == cluster.TypeOCP {
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodestatus contains the helpers shared by the controllers that aggregate the LVMVolumeGroupNodeStatus
+// reported by vgmanager into the status of the LVMCluster and the LVMVolumeGroup.
+package nodestatus
+
+import (
+	"cmp"
+	"slices"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// volumeGroupSteps are the condition types reported by vgmanager in the order of the steps it reconciles.
+var volumeGroupSteps = []string{
+	lvmv1alpha1.DevicesWiped,
+	lvmv1alpha1.DevicesDiscovered,
+	lvmv1alpha1.VolumeGroupCreated,
+	lvmv1alpha1.ThinPoolReady,
+	lvmv1alpha1.LVMDConfigApplied,
+}
+
+// VolumeGroupConditions returns the conditions reported by vgmanager for the volume group on the node.
+func VolumeGroupConditions(nodeStatus *lvmv1alpha1.LVMVolumeGroupNodeStatus, name string) []metav1.Condition {
+	for _, vg := range nodeStatus.Status.VolumeGroups {
+		if vg.Name == name {
+			return vg.Conditions
+		}
+	}
+	return nil
+}
+
+// ConditionsOutdated checks if any of the conditions up to and including the first one that is not True was set
+// for a generation older than the given one. vgmanager stops at the first step that did not succeed and the
+// conditions of the later steps keep their generation, so they are not taken into account.
+func ConditionsOutdated(conditions []metav1.Condition, generation int64) bool {
+	conditions = slices.Clone(conditions)
+	slices.SortStableFunc(conditions, func(a, b metav1.Condition) int {
+		return cmp.Compare(stepIndex(a.Type), stepIndex(b.Type))
+	})
+	for _, condition := range conditions {
+		if condition.ObservedGeneration < generation {
+			return true
+		}
+		if condition.Status != metav1.ConditionTrue {
+			return false
+		}
+	}
+	return false
+}
+
+// stepIndex returns the position of the condition type in volumeGroupSteps, unknown types are sorted last.
+func stepIndex(conditionType string) int {
+	if i := slices.Index(volumeGroupSteps, conditionType); i >= 0 {
+		return i
+	}
+	return len(volumeGroupSteps)
+}
+
+// AddQuantity adds the value to the total, which is initialized with the first value that is not nil.
+func AddQuantity(total **resource.Quantity, value *resource.Quantity) {
+	if value == nil {
+		return
+	}
+	if *total == nil {
+		*total = ptr.To(value.DeepCopy())
+		return
+	}
+	(*total).Add(*value)
+}
@@ -0,0 +1,84 @@
+package nodestatus
+
+import (
+	"testing"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestConditionsOutdated(t *testing.T) {
+	condition := func(conditionType string, status metav1.ConditionStatus, generation int64) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status, ObservedGeneration: generation}
+	}
+	testTable := []struct {
+		desc       string
+		conditions []metav1.Condition
+		expected   bool
+	}{
+		{
+			desc: "all conditions of the current generation",
+			conditions: []metav1.Condition{
+				condition(lvmv1alpha1.DevicesDiscovered, metav1.ConditionTrue, 2),
+				condition(lvmv1alpha1.VolumeGroupCreated, metav1.ConditionTrue, 2),
+			},
+		},
+		{
+			desc: "true condition of an older generation",
+			conditions: []metav1.Condition{
+				condition(lvmv1alpha1.DevicesDiscovered, metav1.ConditionTrue, 2),
+				condition(lvmv1alpha1.VolumeGroupCreated, metav1.ConditionTrue, 1),
+			},
+			expected: true,
+		},
+		{
+			desc: "failed condition of the current generation followed by older ones",
+			conditions: []metav1.Condition{
+				condition(lvmv1alpha1.DevicesDiscovered, metav1.ConditionTrue, 2),
+				condition(lvmv1alpha1.VolumeGroupCreated, metav1.ConditionFalse, 2),
+				condition(lvmv1alpha1.ThinPoolReady, metav1.ConditionTrue, 1),
+				condition(lvmv1alpha1.LVMDConfigApplied, metav1.ConditionUnknown, 1),
+			},
+		},
+		{
+			desc: "failed condition of an older generation",
+			conditions: []metav1.Condition{
+				condition(lvmv1alpha1.DevicesDiscovered, metav1.ConditionTrue, 2),
+				condition(lvmv1alpha1.VolumeGroupCreated, metav1.ConditionFalse, 1),
+			},
+			expected: true,
+		},
+		{
+			desc: "conditions are compared in the order of the steps",
+			conditions: []metav1.Condition{
+				condition(lvmv1alpha1.LVMDConfigApplied, metav1.ConditionTrue, 1),
+				condition(lvmv1alpha1.VolumeGroupCreated, metav1.ConditionFalse, 2),
+				condition(lvmv1alpha1.DevicesDiscovered, metav1.ConditionTrue, 2),
+			},
+		},
+		{
+			desc:       "no conditions",
+			conditions: nil,
+		},
+	}
+	for _, testCase := range testTable {
+		t.Run(testCase.desc, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, ConditionsOutdated(testCase.conditions, 2))
+		})
+	}
+}
+
+func TestAddQuantity(t *testing.T) {
+	var total *resource.Quantity
+	AddQuantity(&total, nil)
+	assert.Nil(t, total)
+
+	first := resource.MustParse("1Gi")
+	AddQuantity(&total, &first)
+	AddQuantity(&total, ptr.To(resource.MustParse("2Gi")))
+	assert.Equal(t, "3Gi", total.String())
+	assert.Equal(t, "1Gi", first.String(), "the first value must not be modified")
+}
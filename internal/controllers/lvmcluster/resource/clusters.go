@@ -0,0 +1,166 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Some resources, like the vg-manager daemonset or the CSIDriver, are shared by all LVMClusters.
+// They are rendered from all LVMClusters that are not being deleted and owned by the oldest of them, the primary.
+// When an LVMCluster is deleted, shared resources are handed over to the remaining LVMClusters instead of being removed.
+
+// sharedClusters returns the LVMClusters sharing the resources with the given LVMCluster, with the primary first.
+// The given LVMCluster is part of the result unless it is being deleted.
+func sharedClusters(ctx context.Context, r Reconciler, current *lvmv1alpha1.LVMCluster) ([]lvmv1alpha1.LVMCluster, error) {
+	clusters, err := activeClusters(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if current.DeletionTimestamp.IsZero() && indexOfCluster(clusters, current) < 0 {
+		// the cache might not have observed the LVMCluster yet
+		clusters = append(clusters, *current)
+		sortClusters(clusters)
+	}
+	for i := range clusters {
+		if isSameCluster(&clusters[i], current) {
+			// prefer the given LVMCluster over the cached one, as it might be more recent
+			clusters[i] = *current
+		}
+	}
+	return clusters, nil
+}
+
+// remainingClusters returns the LVMClusters that keep using the shared resources once the given LVMCluster is deleted.
+func remainingClusters(ctx context.Context, r Reconciler, deleted *lvmv1alpha1.LVMCluster) ([]lvmv1alpha1.LVMCluster, error) {
+	clusters, err := activeClusters(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if i := indexOfCluster(clusters, deleted); i >= 0 {
+		clusters = append(clusters[:i], clusters[i+1:]...)
+	}
+	return clusters, nil
+}
+
+// handOver passes the shared resources of the manager to the remaining LVMClusters when the given LVMCluster is deleted.
+// It returns true if there are remaining LVMClusters, in which case the resources must not be removed.
+func handOver(ctx context.Context, r Reconciler, m Manager, deleted *lvmv1alpha1.LVMCluster) (bool, error) {
+	remaining, err := remainingClusters(ctx, r, deleted)
+	if err != nil {
+		return false, err
+	}
+	if len(remaining) == 0 {
+		return false, nil
+	}
+	log.FromContext(ctx).Info("handing over shared resources", "resourceManager", m.GetName(), "LVMCluster", remaining[0].Name)
+	if err := m.EnsureCreated(r, ctx, &remaining[0]); err != nil {
+		return true, fmt.Errorf("failed to hand over %s to LVMCluster %s: %w", m.GetName(), remaining[0].Name, err)
+	}
+	return true, nil
+}
+
+// setSharedControllerReference makes the primary LVMCluster the controller of a shared resource,
+// removing the references to the other LVMClusters.
+func setSharedControllerReference(primary *lvmv1alpha1.LVMCluster, obj metav1.Object, scheme *runtime.Scheme) error {
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "LVMCluster" && ref.Name != primary.Name {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	obj.SetOwnerReferences(refs)
+	return controllerutil.SetControllerReference(primary, obj, scheme)
+}
+
+// activeClusters returns the LVMClusters that are not being deleted, ordered by their creation.
+func activeClusters(ctx context.Context, r Reconciler) ([]lvmv1alpha1.LVMCluster, error) {
+	list := &lvmv1alpha1.LVMClusterList{}
+	if err := r.List(ctx, list, client.InNamespace(r.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list LVMClusters: %w", err)
+	}
+	var clusters []lvmv1alpha1.LVMCluster
+	for _, cluster := range list.Items {
+		if cluster.DeletionTimestamp.IsZero() {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sortClusters(clusters)
+	return clusters, nil
+}
+
+func sortClusters(clusters []lvmv1alpha1.LVMCluster) {
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if !clusters[i].CreationTimestamp.Equal(&clusters[j].CreationTimestamp) {
+			return clusters[i].CreationTimestamp.Before(&clusters[j].CreationTimestamp)
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+}
+
+func indexOfCluster(clusters []lvmv1alpha1.LVMCluster, cluster *lvmv1alpha1.LVMCluster) int {
+	for i := range clusters {
+		if isSameCluster(&clusters[i], cluster) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isSameCluster(a, b *lvmv1alpha1.LVMCluster) bool {
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// hasImplicitDefault checks if the only device class of the LVMCluster is the default one without being explicitly
+// marked as such. This applies only if no device class of any LVMCluster is explicitly the default one, and only to
+// the oldest LVMCluster with a single device class, so that creating another LVMCluster never takes it away.
+func hasImplicitDefault(clusters []lvmv1alpha1.LVMCluster, current *lvmv1alpha1.LVMCluster) bool {
+	if len(current.Spec.Storage.DeviceClasses) != 1 {
+		return false
+	}
+	if slices.ContainsFunc(deviceClassesOf(clusters), func(deviceClass lvmv1alpha1.DeviceClass) bool {
+		return deviceClass.Default
+	}) {
+		return false
+	}
+	for i := range clusters {
+		if len(clusters[i].Spec.Storage.DeviceClasses) == 1 {
+			return isSameCluster(&clusters[i], current)
+		}
+	}
+	return false
+}
+
+// deviceClassesOf returns the device classes of all given LVMClusters.
+func deviceClassesOf(clusters []lvmv1alpha1.LVMCluster) []lvmv1alpha1.DeviceClass {
+	var deviceClasses []lvmv1alpha1.DeviceClass
+	for _, cluster := range clusters {
+		deviceClasses = append(deviceClasses, cluster.Spec.Storage.DeviceClasses...)
+	}
+	return deviceClasses
+}
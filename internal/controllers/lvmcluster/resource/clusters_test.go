@@ -0,0 +1,191 @@
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func testSharedCluster(name string, created time.Time, nodeLabel string, deviceClass string) *lvmv1alpha1.LVMCluster {
+	return &lvmv1alpha1.LVMCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name + "-uid"),
+			CreationTimestamp: metav1.NewTime(created),
+			Finalizers:        []string{"lvmcluster.topolvm.io"},
+		},
+		Spec: lvmv1alpha1.LVMClusterSpec{
+			Storage: lvmv1alpha1.Storage{DeviceClasses: []lvmv1alpha1.DeviceClass{{
+				Name: deviceClass,
+				NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      nodeLabel,
+						Operator: corev1.NodeSelectorOpExists,
+					}},
+				}}},
+			}}},
+		},
+	}
+}
+
+func TestSharedClusters(t *testing.T) {
+	scheme := newTestScheme(t)
+	now := time.Now().Truncate(time.Second)
+	older := testSharedCluster("older", now.Add(-time.Hour), "a", "vg-a")
+	newer := testSharedCluster("newer", now, "b", "vg-b")
+	r := newFakeStorageClassReconciler(t, scheme, newer, older)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	clusters, err := sharedClusters(ctx, r, newer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 2 || clusters[0].Name != "older" || clusters[1].Name != "newer" {
+		t.Fatalf("expected the older LVMCluster to be the primary, got %v", clusterNames(clusters))
+	}
+
+	// an LVMCluster that is not yet in the cache is still part of the shared clusters
+	uncached := testSharedCluster("uncached", now.Add(time.Hour), "c", "vg-c")
+	clusters, err = sharedClusters(ctx, r, uncached)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 3 || clusters[2].Name != "uncached" {
+		t.Fatalf("expected the uncached LVMCluster to be included, got %v", clusterNames(clusters))
+	}
+
+	remaining, err := remainingClusters(ctx, r, older)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "newer" {
+		t.Fatalf("expected only the newer LVMCluster to remain, got %v", clusterNames(remaining))
+	}
+}
+
+func TestLVMVGNodeStatusSharedByLVMClusters(t *testing.T) {
+	scheme := newTestScheme(t)
+	now := time.Now().Truncate(time.Second)
+	older := testSharedCluster("older", now.Add(-time.Hour), "a", "vg-a")
+	newer := testSharedCluster("newer", now, "b", "vg-b")
+	nodeA := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"a": ""}}}
+	nodeB := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"b": ""}}}
+	r := newFakeStorageClassReconciler(t, scheme, older, newer, nodeA, nodeB)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	manager := LVMVGNodeStatus()
+	if err := manager.EnsureCreated(r, ctx, newer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, node := range []string{"node-a", "node-b"} {
+		if owner := nodeStatusOwner(t, r, node); owner != "older" {
+			t.Errorf("expected node status of %s to be owned by the primary LVMCluster, got %q", node, owner)
+		}
+	}
+
+	// deleting the primary LVMCluster removes the node statuses of its nodes and hands over the others
+	if err := r.Delete(ctx, older); err != nil {
+		t.Fatalf("failed to delete LVMCluster: %v", err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(older), older); err != nil {
+		t.Fatalf("failed to get LVMCluster: %v", err)
+	}
+	if err := manager.EnsureDeleted(r, ctx, older); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := nodeStatusOwner(t, r, "node-a"); owner != "" {
+		t.Errorf("expected node status of node-a to be removed, got owner %q", owner)
+	}
+	if owner := nodeStatusOwner(t, r, "node-b"); owner != "newer" {
+		t.Errorf("expected node status of node-b to be handed over to the remaining LVMCluster, got %q", owner)
+	}
+}
+
+func TestLVMVolumeGroupImplicitDefault(t *testing.T) {
+	scheme := newTestScheme(t)
+	now := time.Now().Truncate(time.Second)
+	older := testSharedCluster("older", now.Add(-time.Hour), "a", "vg-a")
+	newer := testSharedCluster("newer", now, "b", "vg-b")
+	r := newFakeStorageClassReconciler(t, scheme, older)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	isDefault := func(name string) bool {
+		vg := &lvmv1alpha1.LVMVolumeGroup{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: r.GetNamespace()}, vg); err != nil {
+			t.Fatalf("failed to get LVMVolumeGroup %s: %v", name, err)
+		}
+		return vg.Spec.Default
+	}
+
+	if err := LVMVGs().EnsureCreated(r, ctx, older); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isDefault("vg-a") {
+		t.Fatal("expected the only device class to be the default one")
+	}
+
+	// creating another LVMCluster must not take the implicit default away from the existing one
+	if err := r.Create(ctx, newer); err != nil {
+		t.Fatalf("failed to create LVMCluster: %v", err)
+	}
+	for _, cluster := range []*lvmv1alpha1.LVMCluster{older, newer} {
+		if err := LVMVGs().EnsureCreated(r, ctx, cluster); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !isDefault("vg-a") || isDefault("vg-b") {
+		t.Errorf("expected only the device class of the oldest LVMCluster to be the default one, got vg-a: %v, vg-b: %v",
+			isDefault("vg-a"), isDefault("vg-b"))
+	}
+
+	clusters := []lvmv1alpha1.LVMCluster{*older, *newer}
+	clusters[1].Spec.Storage.DeviceClasses[0].Default = true
+	if hasImplicitDefault(clusters, &clusters[0]) {
+		t.Error("expected no implicit default if a device class is explicitly the default one")
+	}
+}
+
+func TestPrometheusRuleSpecOfSharedClusters(t *testing.T) {
+	now := time.Now()
+	clusters := []lvmv1alpha1.LVMCluster{
+		*testSharedCluster("older", now.Add(-time.Hour), "a", "vg-a"),
+		*testSharedCluster("newer", now, "b", "vg-b"),
+	}
+	rules := rulesByAlert(prometheusRuleSpec(deviceClassesOf(clusters)))
+	if len(rules[AlertVolumeGroupUsageNearFull]) != 2 {
+		t.Errorf("expected %s for both device classes, got %d rules",
+			AlertVolumeGroupUsageNearFull, len(rules[AlertVolumeGroupUsageNearFull]))
+	}
+}
+
+func nodeStatusOwner(t *testing.T, r client.Client, node string) string {
+	t.Helper()
+	status := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: node, Namespace: "default"}, status); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ""
+		}
+		t.Fatalf("failed to get node status: %v", err)
+	}
+	if owner := metav1.GetControllerOf(status); owner != nil {
+		return owner.Name
+	}
+	return "<none>"
+}
+
+func clusterNames(clusters []lvmv1alpha1.LVMCluster) []string {
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
@@ -54,12 +54,27 @@ func (c csiNode) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvmv1
 	return err
 }
 
+// EnsureDeleted waits for the driver registrations to be removed from the nodes of the LVMCluster,
+// except for the nodes that are still used by other LVMClusters.
 func (c csiNode) EnsureDeleted(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName())
 
+	remaining, err := remainingClusters(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+	nodeList := &v1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return err
+	}
+	remainingNodes, _ := selector.ValidNodesOfClusters(remaining, nodeList)
+
 	csiNodes, err := c.GetAllCSINodeCandidates(ctx, r, cluster)
 
 	for _, csiNode := range csiNodes {
+		if isValidNode(csiNode.Name, remainingNodes) {
+			continue
+		}
 		found := false
 		for _, driver := range csiNode.Spec.Drivers {
 			if driver.Name == constants.TopolvmCSIDriverName {
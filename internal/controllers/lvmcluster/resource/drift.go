@@ -0,0 +1,203 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftDetector is implemented by the managers whose resources can be changed by others than the operator.
+// NOTE: when updating this, please also update docs/design/lvm-operator-manager.md
+type DriftDetector interface {
+	// DetectDrift should compare the resources managed by this unit with their desired state
+	DetectDrift(Reconciler, context.Context, *lvmv1alpha1.LVMCluster) ([]Drift, error)
+}
+
+// Drift describes a resource that was changed by others than the operator and no longer matches its desired state.
+type Drift struct {
+	Kind string
+	Name string
+	// Fields are the paths of the fields that differ from the desired state
+	Fields []string
+	// Policy decides whether the operator overwrites the resource with its desired state
+	Policy lvmv1alpha1.DriftPolicy
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s %s (%s)", d.Kind, d.Name, strings.Join(d.Fields, ", "))
+}
+
+// driftPoliciesOf returns the drift policies of the LVMCluster, defaulting to Enforce for every resource.
+func driftPoliciesOf(cluster *lvmv1alpha1.LVMCluster) lvmv1alpha1.DriftPolicies {
+	policies := lvmv1alpha1.DriftPolicies{}
+	if cluster.Spec.DriftPolicies != nil {
+		policies = *cluster.Spec.DriftPolicies
+	}
+	for _, policy := range []*lvmv1alpha1.DriftPolicy{
+		&policies.StorageClass,
+		&policies.CSIDriver,
+		&policies.SecurityContextConstraints,
+		&policies.VolumeSnapshotClass,
+		&policies.DaemonSet,
+	} {
+		if *policy == "" {
+			*policy = lvmv1alpha1.DriftPolicyEnforce
+		}
+	}
+	return policies
+}
+
+// hashOf returns a hash of the JSON representation of the given object.
+func hashOf(obj any) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+	return fmt.Sprintf("%x", hash.Sum64()), nil
+}
+
+// setDesiredStateHash records the hash of the desired state of a resource on it.
+func setDesiredStateHash(desired client.Object) error {
+	hash, err := hashOf(desired)
+	if err != nil {
+		return fmt.Errorf("failed to hash the desired state of %q: %w", desired.GetName(), err)
+	}
+	copyDesiredStateHash(hash, desired)
+	return nil
+}
+
+// copyDesiredStateHash records the given hash of the desired state on the resource that it is applied to.
+func copyDesiredStateHash(hash string, obj client.Object) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[constants.DesiredStateHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+}
+
+// detectDrift gets the resource of the desired one into live and returns the fields in which it drifted.
+// A resource that does not exist did not drift, it is created again.
+func detectDrift(ctx context.Context, r Reconciler, desired, live client.Object, policy lvmv1alpha1.DriftPolicy) ([]string, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), live); k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %q to detect drift: %w", desired.GetName(), err)
+	}
+	return driftedFields(desired, live, policy)
+}
+
+// driftedFields returns the paths of the fields of the live resource that differ from the expected one.
+// Only the labels and annotations of the metadata are compared, and fields that are not set on the expected
+// resource, like the defaults of the API server, are ignored. If the desired state changed since the resource
+// was last applied, the differences can be pending changes of the operator instead of drift. Under the Enforce
+// policy they are applied anyway, so none are returned. Under the Report policy the resource is left in place,
+// and as pending changes cannot be told apart from changes made by others, the differences are returned as well.
+func driftedFields(expected, live client.Object, policy lvmv1alpha1.DriftPolicy) ([]string, error) {
+	hash := expected.GetAnnotations()[constants.DesiredStateHashAnnotation]
+	if policy != lvmv1alpha1.DriftPolicyReport &&
+		(hash == "" || live.GetAnnotations()[constants.DesiredStateHashAnnotation] != hash) {
+		return nil, nil
+	}
+
+	expectedFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(expected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the expected state of %q: %w", expected.GetName(), err)
+	}
+	liveFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the live state of %q: %w", live.GetName(), err)
+	}
+
+	var fields []string
+	for key, value := range expectedFields {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			expectedMeta, _ := value.(map[string]any)
+			liveMeta, _ := liveFields[key].(map[string]any)
+			for _, metaKey := range []string{"labels", "annotations"} {
+				expectedValue := expectedMeta[metaKey]
+				if annotations, ok := expectedValue.(map[string]any); ok && metaKey == "annotations" {
+					// the hash of the desired state changes with it and is no drift itself
+					annotations = maps.Clone(annotations)
+					delete(annotations, constants.DesiredStateHashAnnotation)
+					expectedValue = annotations
+				}
+				fields = compareFields(fields, key+"."+metaKey, expectedValue, liveMeta[metaKey])
+			}
+		default:
+			fields = compareFields(fields, key, value, liveFields[key])
+		}
+	}
+	slices.Sort(fields)
+	return fields, nil
+}
+
+// compareFields appends the paths below path at which the live value differs from the expected one.
+func compareFields(fields []string, path string, expected, live any) []string {
+	switch expected := expected.(type) {
+	case nil:
+	case map[string]any:
+		live, _ := live.(map[string]any)
+		for key, value := range expected {
+			fields = compareFields(fields, path+"."+key, value, live[key])
+		}
+	case []any:
+		live, _ := live.([]any)
+		if len(expected) != len(live) {
+			return append(fields, path)
+		}
+		for i := range expected {
+			fields = compareFields(fields, fmt.Sprintf("%s[%d]", path, i), expected[i], live[i])
+		}
+	default:
+		// zero values are omitted by the API server
+		if live == nil && reflect.ValueOf(expected).IsZero() {
+			break
+		}
+		if !reflect.DeepEqual(expected, live) {
+			fields = append(fields, path)
+		}
+	}
+	return fields
+}
+
+// driftOf returns the drift of a resource, if any of its fields drifted.
+func driftOf(kind string, obj metav1.Object, fields []string, policy lvmv1alpha1.DriftPolicy) []Drift {
+	if len(fields) == 0 {
+		return nil
+	}
+	return []Drift{{Kind: kind, Name: obj.GetName(), Fields: fields, Policy: policy}}
+}
@@ -0,0 +1,285 @@
+package resource
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestDriftedFields(t *testing.T) {
+	desired := getCSIDriverResource()
+	desired.Labels = map[string]string{"app": "lvms"}
+	if err := setDesiredStateHash(desired); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		desc     string
+		policy   lvmv1alpha1.DriftPolicy
+		mutate   func(live *storagev1.CSIDriver)
+		expected []string
+	}{
+		{
+			desc: "defaults of the API server are ignored",
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Labels["other"] = "label"
+				live.Spec.RequiresRepublish = ptr.To(false)
+				live.Spec.FSGroupPolicy = ptr.To(storagev1.ReadWriteOnceWithFSTypeFSGroupPolicy)
+			},
+		},
+		{
+			desc: "changed fields are reported",
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Spec.PodInfoOnMount = ptr.To(false)
+				delete(live.Labels, "app")
+			},
+			expected: []string{"metadata.labels.app", "spec.podInfoOnMount"},
+		},
+		{
+			desc: "changed lists are reported",
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Spec.VolumeLifecycleModes = append(live.Spec.VolumeLifecycleModes, storagev1.VolumeLifecycleEphemeral)
+			},
+			expected: []string{"spec.volumeLifecycleModes"},
+		},
+		{
+			desc:   "changes of the desired state are no drift under Enforce",
+			policy: lvmv1alpha1.DriftPolicyEnforce,
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Annotations[constants.DesiredStateHashAnnotation] = "previous"
+				live.Spec.PodInfoOnMount = ptr.To(false)
+			},
+		},
+		{
+			desc:   "changes of the desired state are drift under Report",
+			policy: lvmv1alpha1.DriftPolicyReport,
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Annotations[constants.DesiredStateHashAnnotation] = "previous"
+				live.Spec.PodInfoOnMount = ptr.To(false)
+			},
+			expected: []string{"spec.podInfoOnMount"},
+		},
+		{
+			desc:   "the hash of the desired state is no drift under Report",
+			policy: lvmv1alpha1.DriftPolicyReport,
+			mutate: func(live *storagev1.CSIDriver) {
+				live.Annotations[constants.DesiredStateHashAnnotation] = "previous"
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			live := desired.DeepCopy()
+			tc.mutate(live)
+			fields, err := driftedFields(desired, live, tc.policy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(fields, tc.expected) {
+				t.Fatalf("expected drifted fields %v, got %v", tc.expected, fields)
+			}
+		})
+	}
+}
+
+func TestCSIDriverDrift(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	for _, policy := range []lvmv1alpha1.DriftPolicy{lvmv1alpha1.DriftPolicyEnforce, lvmv1alpha1.DriftPolicyReport} {
+		t.Run(string(policy), func(t *testing.T) {
+			cluster := testSharedCluster("cluster", time.Now(), "a", "vg-a")
+			cluster.Spec.DriftPolicies = &lvmv1alpha1.DriftPolicies{CSIDriver: policy}
+			r := newFakeStorageClassReconciler(t, newTestScheme(t), cluster)
+
+			if err := (csiDriver{}).EnsureCreated(r, ctx, cluster); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if drifts, err := (csiDriver{}).DetectDrift(r, ctx, cluster); err != nil || len(drifts) > 0 {
+				t.Fatalf("expected no drift after creation, got %v, %v", drifts, err)
+			}
+
+			driver := &storagev1.CSIDriver{}
+			if err := r.Get(ctx, client.ObjectKey{Name: constants.TopolvmCSIDriverName}, driver); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			driver.Spec.StorageCapacity = ptr.To(false)
+			if err := r.Update(ctx, driver); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			drifts, err := (csiDriver{}).DetectDrift(r, ctx, cluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(drifts) != 1 || drifts[0].Policy != policy || !slices.Equal(drifts[0].Fields, []string{"spec.storageCapacity"}) {
+				t.Fatalf("expected the storage capacity to drift, got %v", drifts)
+			}
+
+			if err := (csiDriver{}).EnsureCreated(r, ctx, cluster); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := r.Get(ctx, client.ObjectKey{Name: constants.TopolvmCSIDriverName}, driver); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if overwritten := *driver.Spec.StorageCapacity; overwritten != (policy == lvmv1alpha1.DriftPolicyEnforce) {
+				t.Fatalf("expected the drift to be overwritten only with the Enforce policy, storage capacity is %v", overwritten)
+			}
+		})
+	}
+}
+
+func TestStorageClassDriftReported(t *testing.T) {
+	scheme := newTestScheme(t)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	cluster := testCluster(lvmv1alpha1.DeviceClass{
+		Name:           "vg1",
+		FilesystemType: lvmv1alpha1.FilesystemTypeXFS,
+	})
+	cluster.Spec.DriftPolicies = &lvmv1alpha1.DriftPolicies{StorageClass: lvmv1alpha1.DriftPolicyReport}
+
+	r := newFakeStorageClassReconciler(t, scheme)
+	storageClasses, err := (topolvmStorageClass{}).desiredStorageClasses(r, ctx, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	live := storageClasses[0].DeepCopy()
+	live.ResourceVersion = ""
+	live.Labels["team"] = "storage"
+	live.Parameters["custom"] = "value"
+	live.AllowVolumeExpansion = ptr.To(false)
+
+	patched := false
+	r.Client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(live).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patched = true
+				return nil
+			},
+		}).
+		Build()
+
+	drifts, err := (topolvmStorageClass{}).DetectDrift(r, ctx, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(drifts) != 1 || !slices.Equal(drifts[0].Fields, []string{"allowVolumeExpansion"}) {
+		t.Fatalf("expected only the volume expansion to drift, got %v", drifts)
+	}
+
+	if err := (topolvmStorageClass{}).EnsureCreated(r, ctx, cluster); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched {
+		t.Fatal("expected the drifted StorageClass to be left in place")
+	}
+}
+
+func TestVGManagerUpdateTemplateHash(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	scheme := newTestScheme(t)
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding appsv1 to scheme: %v", err)
+	}
+
+	applied := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg-manager", Namespace: "default"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{constants.VGManagerTemplateHashAnnotation: "desired"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "vg-manager", Image: "vg-manager:desired"}}},
+			},
+		},
+	}
+	copyDesiredStateHash("desired", applied)
+	r := newFakeStorageClassReconciler(t, scheme, applied.DeepCopy())
+	if err := (vgManager{}).recordAppliedTemplateHash(ctx, r, applied); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(applied), applied); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied.Annotations[constants.DesiredStateHashAnnotation] != "desired" ||
+		applied.Annotations[constants.VGManagerAppliedTemplateHashAnnotation] == "" {
+		t.Fatalf("expected the applied template hash to be recorded next to the other annotations, got %v", applied.Annotations)
+	}
+	applied.ResourceVersion = ""
+
+	testCases := []struct {
+		desc    string
+		mutate  func(ds *appsv1.DaemonSet)
+		updated bool
+	}{
+		{
+			desc:   "a template that was not changed since it was applied is not rolled out",
+			mutate: func(ds *appsv1.DaemonSet) {},
+		},
+		{
+			desc: "a template changed by others is rolled out",
+			mutate: func(ds *appsv1.DaemonSet) {
+				ds.Spec.Template.Spec.Containers[0].Image = "vg-manager:edited"
+			},
+			updated: true,
+		},
+		{
+			desc: "a template that was never recorded as applied is not rolled out",
+			mutate: func(ds *appsv1.DaemonSet) {
+				delete(ds.Annotations, constants.VGManagerAppliedTemplateHashAnnotation)
+				ds.Spec.Template.Spec.Containers[0].Image = "vg-manager:edited"
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ds := applied.DeepCopy()
+			tc.mutate(ds)
+			r := newFakeStorageClassReconciler(t, scheme, ds)
+			if err := r.Get(ctx, client.ObjectKeyFromObject(ds), ds); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := (vgManager{}).updateTemplateHash(ctx, r, ds); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			live := &appsv1.DaemonSet{}
+			if err := r.Get(ctx, client.ObjectKeyFromObject(ds), live); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			templateHash := live.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+			if updated := templateHash != "desired"; updated != tc.updated {
+				t.Fatalf("expected the template hash to be updated: %v, got %q", tc.updated, templateHash)
+			}
+			if live.Annotations[constants.DesiredStateHashAnnotation] != "desired" {
+				t.Fatalf("expected the other annotations to be kept, got %v", live.Annotations)
+			}
+			if !tc.updated {
+				return
+			}
+			// the updated template is recorded as applied, so it is only rolled out once
+			if err := (vgManager{}).updateTemplateHash(ctx, r, live); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if again := live.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]; again != templateHash {
+				t.Fatalf("expected the template hash to stay %q, got %q", templateHash, again)
+			}
+		})
+	}
+}
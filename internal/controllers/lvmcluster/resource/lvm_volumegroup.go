@@ -50,7 +50,11 @@ func (c lvmVG) GetName() string {
 func (c lvmVG) EnsureCreated(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("topolvmNode", c.GetName())
 
-	lvmVolumeGroups := lvmVolumeGroups(r.GetNamespace(), lvmCluster.Spec.Storage.DeviceClasses)
+	clusters, err := sharedClusters(ctx, r, lvmCluster)
+	if err != nil {
+		return err
+	}
+	lvmVolumeGroups := lvmVolumeGroups(r.GetNamespace(), lvmCluster.Spec.Storage.DeviceClasses, hasImplicitDefault(clusters, lvmCluster))
 
 	for _, volumeGroup := range lvmVolumeGroups {
 		existingVolumeGroup := &lvmv1alpha1.LVMVolumeGroup{
@@ -88,7 +92,7 @@ func (c lvmVG) EnsureCreated(r Reconciler, ctx context.Context, lvmCluster *lvmv
 
 func (c lvmVG) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName())
-	vgcrs := lvmVolumeGroups(r.GetNamespace(), lvmCluster.Spec.Storage.DeviceClasses)
+	vgcrs := lvmVolumeGroups(r.GetNamespace(), lvmCluster.Spec.Storage.DeviceClasses, false)
 
 	var volumeGroupsPendingDelete []string
 
@@ -127,7 +131,9 @@ func (c lvmVG) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv
 	return nil
 }
 
-func lvmVolumeGroups(namespace string, deviceClasses []lvmv1alpha1.DeviceClass) []*lvmv1alpha1.LVMVolumeGroup {
+// lvmVolumeGroups returns the LVMVolumeGroups of the device classes. If implicitDefault is set, the only device class
+// is the default one even if it is not explicitly marked as such.
+func lvmVolumeGroups(namespace string, deviceClasses []lvmv1alpha1.DeviceClass, implicitDefault bool) []*lvmv1alpha1.LVMVolumeGroup {
 
 	lvmVolumeGroups := make([]*lvmv1alpha1.LVMVolumeGroup, 0, len(deviceClasses))
 
@@ -138,11 +144,13 @@ func lvmVolumeGroups(namespace string, deviceClasses []lvmv1alpha1.DeviceClass)
 				Namespace: namespace,
 			},
 			Spec: lvmv1alpha1.LVMVolumeGroupSpec{
-				NodeSelector:          deviceClass.NodeSelector,
-				DeviceSelector:        deviceClass.DeviceSelector,
-				ThinPoolConfig:        deviceClass.ThinPoolConfig,
-				Default:               len(deviceClasses) == 1 || deviceClass.Default, // True if there is only one device class or default is explicitly set.
-				DeviceDiscoveryPolicy: deviceClass.DeviceDiscoveryPolicy,
+				NodeSelector:                deviceClass.NodeSelector,
+				DeviceSelector:              deviceClass.DeviceSelector,
+				ThinPoolConfig:              deviceClass.ThinPoolConfig,
+				Default:                     implicitDefault || deviceClass.Default, // True if it is the implicit default device class or default is explicitly set.
+				DeviceDiscoveryPolicy:       deviceClass.DeviceDiscoveryPolicy,
+				DeviceHealthPolicy:          deviceClass.DeviceHealthPolicy,
+				OrphanedLogicalVolumePolicy: deviceClass.OrphanedLogicalVolumePolicy,
 			},
 		}
 		lvmVolumeGroups = append(lvmVolumeGroups, lvmVolumeGroup)
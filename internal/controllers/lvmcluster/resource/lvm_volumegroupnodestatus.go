@@ -25,7 +25,6 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/selector"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	cutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -55,7 +54,14 @@ func (l lvmVGNodeStatus) EnsureCreated(r Reconciler, ctx context.Context, cluste
 		return fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	validNodes, err := selector.ValidNodes(cluster, &nodes)
+	// the node statuses are shared by all LVMClusters and owned by the primary one
+	clusters, err := sharedClusters(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+	primary := &clusters[0]
+
+	validNodes, err := selector.ValidNodesOfClusters(clusters, &nodes)
 
 	logger.Info("nodes considered for LVMCluster",
 		"nodes", nodesToStringSummary(validNodes),
@@ -70,7 +76,7 @@ func (l lvmVGNodeStatus) EnsureCreated(r Reconciler, ctx context.Context, cluste
 			},
 		}
 		result, err := cutil.CreateOrUpdate(ctx, r, lvmVGNodeStatus, func() error {
-			if err := ctrl.SetControllerReference(cluster, lvmVGNodeStatus, r.Scheme()); err != nil {
+			if err := setSharedControllerReference(primary, lvmVGNodeStatus, r.Scheme()); err != nil {
 				return fmt.Errorf("failed to set controller reference: %w", err)
 			}
 			if !hasDeleteProtectionFinalizer(lvmVGNodeStatus.Finalizers) {
@@ -91,7 +97,18 @@ func (l lvmVGNodeStatus) EnsureCreated(r Reconciler, ctx context.Context, cluste
 	return err
 }
 
+// EnsureDeleted removes the node statuses of the nodes that are not valid for any remaining LVMCluster
+// and hands over the others to the remaining LVMClusters.
 func (l lvmVGNodeStatus) EnsureDeleted(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
+	remaining, err := remainingClusters(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		if err := l.EnsureCreated(r, ctx, &remaining[0]); err != nil {
+			return fmt.Errorf("failed to hand over %s to LVMCluster %s: %w", l.GetName(), remaining[0].Name, err)
+		}
+	}
 
 	nodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{}
 	if err := r.List(ctx, nodeStatusList, client.InNamespace(r.GetNamespace())); err != nil {
@@ -104,9 +121,10 @@ func (l lvmVGNodeStatus) EnsureDeleted(r Reconciler, ctx context.Context, cluste
 	}
 
 	validNodes, err := selector.ValidNodes(cluster, &nodeList)
+	remainingNodes, _ := selector.ValidNodesOfClusters(remaining, &nodeList)
 
 	for _, status := range nodeStatusList.Items {
-		if isValidNode(status.Name, validNodes) {
+		if isValidNode(status.Name, validNodes) && !isValidNode(status.Name, remainingNodes) {
 			if err := l.deleteNodeStatus(r, ctx, status); err != nil {
 				return err
 			}
@@ -137,8 +155,10 @@ func (l lvmVGNodeStatus) deleteNodeStatus(r Reconciler, ctx context.Context, sta
 		logger.Info("initiated LVMVolumeGroupNodeStatus deletion", "nodeStatus", client.ObjectKeyFromObject(&status))
 	}
 
+	// the deletion above changed the resource version, so the finalizer is removed with a patch
+	original := status.DeepCopy()
 	if removeDeleteProtectionFinalizer(&status) {
-		if err := r.Update(ctx, &status); err != nil {
+		if err := r.Patch(ctx, &status, client.MergeFrom(original)); err != nil {
 			return fmt.Errorf("failed to remove finalizer from LVMVolumeGroupNodeStatus: %w", err)
 		}
 	}
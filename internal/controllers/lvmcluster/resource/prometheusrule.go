@@ -0,0 +1,367 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/labels"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus/common/model"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	cutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func PrometheusRule() Manager {
+	return prometheusRuleManager{}
+}
+
+type prometheusRuleManager struct{}
+
+var _ Manager = prometheusRuleManager{}
+
+const (
+	prometheusRuleManagerName = "prometheusRule"
+
+	// prometheusRuleName is the name of the PrometheusRule that was shipped with the bundle before the alerts
+	// became configurable, so that the operator takes over the existing resource on upgrade.
+	prometheusRuleName = "prometheus-lvmo-rules"
+)
+
+const (
+	AlertVolumeGroupUsageNearFull      = "VolumeGroupUsageAtThresholdNearFull"
+	AlertVolumeGroupUsageCritical      = "VolumeGroupUsageAtThresholdCritical"
+	AlertThinPoolDataUsageNearFull     = "ThinPoolDataUsageAtThresholdNearFull"
+	AlertThinPoolDataUsageCritical     = "ThinPoolDataUsageAtThresholdCritical"
+	AlertThinPoolMetadataUsageNearFull = "ThinPoolMetaDataUsageAtThresholdNearFull"
+	AlertThinPoolMetadataUsageCritical = "ThinPoolMetaDataUsageAtThresholdCritical"
+	AlertVolumeGroupDegraded           = "VolumeGroupDegraded"
+	AlertVolumeGroupFailed             = "VolumeGroupFailed"
+	AlertPhysicalVolumeMissing         = "PhysicalVolumeMissing"
+)
+
+const (
+	volumeGroupAlertRuleGroupName       = "vg-alert.rules"
+	thinPoolAlertRuleGroupName          = "thin-pool-alert.rules"
+	volumeGroupStatusAlertRuleGroupName = "vg-status-alert.rules"
+
+	severityWarning  = "warning"
+	severityCritical = "critical"
+
+	// metrics exported by TopoLVM
+	metricVolumeGroupSizeBytes      = "topolvm_volumegroup_size_bytes"
+	metricVolumeGroupAvailableBytes = "topolvm_volumegroup_available_bytes"
+	metricThinPoolDataPercent       = "topolvm_thinpool_data_percent"
+	metricThinPoolMetadataPercent   = "topolvm_thinpool_metadata_percent"
+
+	// metrics exported by vg-manager
+	metricVolumeGroupStatus      = "lvms_vgmanager_volume_group_status"
+	metricMissingPhysicalVolumes = "lvms_vgmanager_missing_physical_volumes"
+)
+
+func (p prometheusRuleManager) GetName() string {
+	return prometheusRuleManagerName
+}
+
+func (p prometheusRuleManager) EnsureCreated(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
+	logger := log.FromContext(ctx).WithValues("resourceManager", p.GetName())
+
+	isPrometheusAvailable, err := p.IsPrometheusAvailable(r, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if Prometheus is available: %w", err)
+	}
+
+	if !isPrometheusAvailable {
+		logger.V(2).Info("PrometheusRule CRD not available, skipping PrometheusRule creation")
+		return nil
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prometheusRuleName,
+			Namespace: r.GetNamespace(),
+		},
+	}
+
+	// the rules are shared by all LVMClusters and labeled with the primary one
+	clusters, err := sharedClusters(ctx, r, lvmCluster)
+	if err != nil {
+		return err
+	}
+
+	result, err := cutil.CreateOrUpdate(ctx, r, prometheusRule, func() error {
+		labels.SetManagedLabels(r.Scheme(), prometheusRule, &clusters[0])
+		prometheusRule.Spec = prometheusRuleSpec(deviceClassesOf(clusters))
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s failed to reconcile: %w", p.GetName(), err)
+	}
+
+	if result != cutil.OperationResultNone {
+		logger.V(2).Info("PrometheusRule applied to cluster", "operation", result, "name", prometheusRule.Name)
+	}
+
+	return nil
+}
+
+func (p prometheusRuleManager) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
+	logger := log.FromContext(ctx).WithValues("resourceManager", p.GetName())
+
+	isPrometheusAvailable, err := p.IsPrometheusAvailable(r, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check if Prometheus is available: %w", err)
+	}
+
+	if !isPrometheusAvailable {
+		logger.V(2).Info("PrometheusRule CRD not available, skipping PrometheusRule deletion")
+		return nil
+	}
+
+	if shared, err := handOver(ctx, r, p, lvmCluster); shared || err != nil {
+		return err
+	}
+
+	name := types.NamespacedName{Name: prometheusRuleName, Namespace: r.GetNamespace()}
+	prometheusRule := &monitoringv1.PrometheusRule{}
+
+	if err := r.Get(ctx, name, prometheusRule); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get PrometheusRule: %w", err)
+		}
+		// PrometheusRule doesn't exist, nothing to delete
+		return nil
+	}
+
+	if !prometheusRule.GetDeletionTimestamp().IsZero() {
+		return fmt.Errorf("PrometheusRule %s is still present, waiting for deletion", prometheusRule.Name)
+	}
+
+	if err := r.Delete(ctx, prometheusRule); err != nil {
+		return fmt.Errorf("failed to delete PrometheusRule %s: %w", prometheusRule.Name, err)
+	}
+
+	logger.Info("initiated PrometheusRule deletion")
+	return nil
+}
+
+func (p prometheusRuleManager) IsPrometheusAvailable(r Reconciler, ctx context.Context) (bool, error) {
+	logger := log.FromContext(ctx).WithValues("resourceManager", p.GetName())
+
+	logger.V(2).Info("Checking if PrometheusRule CRD is available")
+	if err := r.Get(ctx, types.NamespacedName{Name: "prometheusrules.monitoring.coreos.com"}, &apiextensionsv1.CustomResourceDefinition{}); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to get PrometheusRule CRD: %w", err)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// prometheusRuleSpec renders the alerts of all device classes. Every device class gets its own copy of the rules,
+// filtered by its name, so that thresholds and durations can differ between device classes.
+func prometheusRuleSpec(deviceClasses []lvmv1alpha1.DeviceClass) monitoringv1.PrometheusRuleSpec {
+	var vgRules, thinPoolRules, statusRules []monitoringv1.Rule
+
+	for _, deviceClass := range deviceClasses {
+		alerts := ptr.Deref(deviceClass.Alerts, lvmv1alpha1.DeviceClassAlerts{})
+		vgRules = append(vgRules, volumeGroupUsageRules(deviceClass, alerts)...)
+		if deviceClass.ThinPoolConfig != nil {
+			thinPoolRules = append(thinPoolRules, thinPoolUsageRules(deviceClass.Name, alerts)...)
+		}
+		statusRules = append(statusRules, volumeGroupStatusRules(deviceClass.Name, alerts)...)
+	}
+
+	spec := monitoringv1.PrometheusRuleSpec{}
+	for _, group := range []monitoringv1.RuleGroup{
+		{Name: volumeGroupAlertRuleGroupName, Rules: vgRules},
+		{Name: thinPoolAlertRuleGroupName, Rules: thinPoolRules},
+		{Name: volumeGroupStatusAlertRuleGroupName, Rules: statusRules},
+	} {
+		// groups without rules are left out, e.g. when no device class is thin provisioned
+		if len(group.Rules) > 0 {
+			spec.Groups = append(spec.Groups, group)
+		}
+	}
+	return spec
+}
+
+// volumeGroupUsageRules alerts on the used capacity of the volume group. The thin pool of a thin provisioned
+// device class allocates most of the volume group up front, so for those the alerts also require the thin pool
+// data usage to cross its thresholds.
+func volumeGroupUsageRules(deviceClass lvmv1alpha1.DeviceClass, alerts lvmv1alpha1.DeviceClassAlerts) []monitoringv1.Rule {
+	config := ptr.Deref(alerts.VolumeGroupUsage, lvmv1alpha1.UsageAlertConfig{})
+	if !alertEnabled(config.AlertConfig) {
+		return nil
+	}
+	selector := deviceClassSelector(deviceClass.Name)
+	usage := fmt.Sprintf("100 * (%[1]s%[3]s - %[2]s%[3]s) / %[1]s%[3]s",
+		metricVolumeGroupSizeBytes, metricVolumeGroupAvailableBytes, selector)
+	nearFull, critical := usageThresholds(config)
+
+	nearFullExpr := fmt.Sprintf("%[1]s > %[2]d and %[1]s <= %[3]d", usage, nearFull, critical)
+	criticalExpr := fmt.Sprintf("%s > %d", usage, critical)
+	if deviceClass.ThinPoolConfig != nil {
+		thinPoolNearFull, thinPoolCritical := usageThresholds(ptr.Deref(alerts.ThinPoolDataUsage, lvmv1alpha1.UsageAlertConfig{}))
+		nearFullExpr = fmt.Sprintf("%[1]s and %[2]s%[3]s > %[4]d and %[2]s%[3]s <= %[5]d",
+			nearFullExpr, metricThinPoolDataPercent, selector, thinPoolNearFull, thinPoolCritical)
+		criticalExpr = fmt.Sprintf("%s and %s%s > %d",
+			criticalExpr, metricThinPoolDataPercent, selector, thinPoolCritical)
+	}
+
+	return []monitoringv1.Rule{
+		{
+			Alert:  AlertVolumeGroupUsageNearFull,
+			Expr:   intstr.FromString(nearFullExpr),
+			For:    alertFor(config.AlertConfig),
+			Labels: map[string]string{"severity": severityWarning},
+			Annotations: map[string]string{
+				"description": "VolumeGroup is nearing full. Data deletion or VolumeGroup expansion is required.",
+				"message": fmt.Sprintf("VolumeGroup {{ $labels.device_class }} utilization has crossed %d %% on node {{ $labels.node }}. "+
+					"Free up some space or expand the VolumeGroup.", nearFull),
+			},
+		},
+		{
+			Alert:  AlertVolumeGroupUsageCritical,
+			Expr:   intstr.FromString(criticalExpr),
+			For:    alertFor(config.AlertConfig),
+			Labels: map[string]string{"severity": severityCritical},
+			Annotations: map[string]string{
+				"description": "VolumeGroup is critically full. Data deletion or VolumeGroup expansion is required.",
+				"message": fmt.Sprintf("VolumeGroup {{ $labels.device_class }} utilization has crossed %d %% on node {{ $labels.node }}. "+
+					"Free up some space or expand the VolumeGroup immediately.", critical),
+			},
+		},
+	}
+}
+
+// thinPoolUsageRules alerts on the data and metadata usage of the thin pool.
+func thinPoolUsageRules(deviceClassName string, alerts lvmv1alpha1.DeviceClassAlerts) []monitoringv1.Rule {
+	var rules []monitoringv1.Rule
+	for _, usage := range []struct {
+		config                  lvmv1alpha1.UsageAlertConfig
+		metric, kind            string
+		nearFullAlert, critical string
+	}{
+		{ptr.Deref(alerts.ThinPoolDataUsage, lvmv1alpha1.UsageAlertConfig{}), metricThinPoolDataPercent, "data",
+			AlertThinPoolDataUsageNearFull, AlertThinPoolDataUsageCritical},
+		{ptr.Deref(alerts.ThinPoolMetadataUsage, lvmv1alpha1.UsageAlertConfig{}), metricThinPoolMetadataPercent, "metadata",
+			AlertThinPoolMetadataUsageNearFull, AlertThinPoolMetadataUsageCritical},
+	} {
+		if !alertEnabled(usage.config.AlertConfig) {
+			continue
+		}
+		metric := usage.metric + deviceClassSelector(deviceClassName)
+		nearFull, critical := usageThresholds(usage.config)
+		rules = append(rules,
+			monitoringv1.Rule{
+				Alert:  usage.nearFullAlert,
+				Expr:   intstr.FromString(fmt.Sprintf("%[1]s > %[2]d and %[1]s <= %[3]d", metric, nearFull, critical)),
+				For:    alertFor(usage.config.AlertConfig),
+				Labels: map[string]string{"severity": severityWarning},
+				Annotations: map[string]string{
+					"description": fmt.Sprintf("Thin pool %s in the VolumeGroup is nearing full. Data deletion or thin pool expansion is required.", usage.kind),
+					"message": fmt.Sprintf("Thin Pool %s utilization in the VolumeGroup {{ $labels.device_class }} has crossed %d %% on node {{ $labels.node }}. "+
+						"Free up some space or expand the thin pool.", usage.kind, nearFull),
+				},
+			},
+			monitoringv1.Rule{
+				Alert:  usage.critical,
+				Expr:   intstr.FromString(fmt.Sprintf("%s > %d", metric, critical)),
+				For:    alertFor(usage.config.AlertConfig),
+				Labels: map[string]string{"severity": severityCritical},
+				Annotations: map[string]string{
+					"description": fmt.Sprintf("Thin pool %s in the VolumeGroup is critically full. Data deletion or thin pool expansion is required.", usage.kind),
+					"message": fmt.Sprintf("Thin Pool %s utilization in the VolumeGroup {{ $labels.device_class }} has crossed %d %% on node {{ $labels.node }}. "+
+						"Free up some space or expand the thin pool immediately.", usage.kind, critical),
+				},
+			},
+		)
+	}
+	return rules
+}
+
+// volumeGroupStatusRules alerts on the status that vg-manager reports for the volume group on a node.
+func volumeGroupStatusRules(deviceClassName string, alerts lvmv1alpha1.DeviceClassAlerts) []monitoringv1.Rule {
+	var rules []monitoringv1.Rule
+
+	if config := ptr.Deref(alerts.VolumeGroupStatus, lvmv1alpha1.AlertConfig{}); alertEnabled(config) {
+		for _, status := range []struct {
+			alert    string
+			status   lvmv1alpha1.VGStatusType
+			severity string
+		}{
+			{AlertVolumeGroupDegraded, lvmv1alpha1.VGStatusDegraded, severityWarning},
+			{AlertVolumeGroupFailed, lvmv1alpha1.VGStatusFailed, severityCritical},
+		} {
+			rules = append(rules, monitoringv1.Rule{
+				Alert: status.alert,
+				Expr: intstr.FromString(fmt.Sprintf(`%s{volume_group=%q,status=%q} == 1`,
+					metricVolumeGroupStatus, deviceClassName, status.status)),
+				For:    alertFor(config),
+				Labels: map[string]string{"severity": status.severity},
+				Annotations: map[string]string{
+					"description": fmt.Sprintf("VolumeGroup is %s on a node. Check the reason in the LVMVolumeGroupNodeStatus of the node.", status.status),
+					"message":     fmt.Sprintf("VolumeGroup {{ $labels.volume_group }} is %s on node {{ $labels.node }}.", status.status),
+				},
+			})
+		}
+	}
+
+	if config := ptr.Deref(alerts.MissingPhysicalVolumes, lvmv1alpha1.AlertConfig{}); alertEnabled(config) {
+		rules = append(rules, monitoringv1.Rule{
+			Alert:  AlertPhysicalVolumeMissing,
+			Expr:   intstr.FromString(fmt.Sprintf(`%s{volume_group=%q} > 0`, metricMissingPhysicalVolumes, deviceClassName)),
+			For:    alertFor(config),
+			Labels: map[string]string{"severity": severityCritical},
+			Annotations: map[string]string{
+				"description": "Physical volumes of the VolumeGroup can no longer be found on the node. Data on the affected logical volumes might be lost.",
+				"message":     "VolumeGroup {{ $labels.volume_group }} is missing {{ $value }} physical volume(s) on node {{ $labels.node }}.",
+			},
+		})
+	}
+
+	return rules
+}
+
+func deviceClassSelector(deviceClassName string) string {
+	return fmt.Sprintf(`{device_class=%q}`, deviceClassName)
+}
+
+func alertEnabled(config lvmv1alpha1.AlertConfig) bool {
+	return ptr.Deref(config.Enabled, true)
+}
+
+func alertFor(config lvmv1alpha1.AlertConfig) *monitoringv1.Duration {
+	duration := ptr.Deref(config.For, lvmv1alpha1.AlertForDefault)
+	return ptr.To(monitoringv1.Duration(model.Duration(duration.Duration).String()))
+}
+
+func usageThresholds(config lvmv1alpha1.UsageAlertConfig) (nearFull, critical int) {
+	return ptr.Deref(config.NearFullPercent, lvmv1alpha1.AlertNearFullPercentDefault),
+		ptr.Deref(config.CriticalPercent, lvmv1alpha1.AlertCriticalPercentDefault)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func rulesByAlert(spec monitoringv1.PrometheusRuleSpec) map[string][]monitoringv1.Rule {
+	rules := make(map[string][]monitoringv1.Rule)
+	for _, group := range spec.Groups {
+		for _, rule := range group.Rules {
+			rules[rule.Alert] = append(rules[rule.Alert], rule)
+		}
+	}
+	return rules
+}
+
+func TestPrometheusRuleSpec_Defaults(t *testing.T) {
+	cluster := testCluster(
+		lvmv1alpha1.DeviceClass{Name: "thick"},
+		lvmv1alpha1.DeviceClass{Name: "thin", ThinPoolConfig: &lvmv1alpha1.ThinPoolConfig{Name: "pool"}},
+	)
+
+	spec := prometheusRuleSpec(cluster.Spec.Storage.DeviceClasses)
+	if len(spec.Groups) != 3 {
+		t.Fatalf("expected 3 rule groups, got %d", len(spec.Groups))
+	}
+
+	rules := rulesByAlert(spec)
+	for alert, count := range map[string]int{
+		AlertVolumeGroupUsageNearFull:      2,
+		AlertVolumeGroupUsageCritical:      2,
+		AlertThinPoolDataUsageNearFull:     1,
+		AlertThinPoolDataUsageCritical:     1,
+		AlertThinPoolMetadataUsageNearFull: 1,
+		AlertThinPoolMetadataUsageCritical: 1,
+		AlertVolumeGroupDegraded:           2,
+		AlertVolumeGroupFailed:             2,
+		AlertPhysicalVolumeMissing:         2,
+	} {
+		if len(rules[alert]) != count {
+			t.Errorf("expected %d %s rules, got %d", count, alert, len(rules[alert]))
+		}
+	}
+
+	thickNearFull := rules[AlertVolumeGroupUsageNearFull][0]
+	if expr := thickNearFull.Expr.String(); !strings.Contains(expr, `{device_class="thick"}`) ||
+		!strings.Contains(expr, "> 75") || !strings.Contains(expr, "<= 85") || strings.Contains(expr, metricThinPoolDataPercent) {
+		t.Errorf("unexpected expression for thick volume group usage: %s", expr)
+	}
+	if thickNearFull.For == nil || *thickNearFull.For != "5m" {
+		t.Errorf("expected default for duration of 5m, got %v", thickNearFull.For)
+	}
+
+	thinCritical := rules[AlertVolumeGroupUsageCritical][1]
+	if expr := thinCritical.Expr.String(); !strings.Contains(expr, metricThinPoolDataPercent+`{device_class="thin"} > 85`) {
+		t.Errorf("expected thin volume group usage to depend on the thin pool data usage, got %s", expr)
+	}
+}
+
+func TestPrometheusRuleSpec_Configured(t *testing.T) {
+	cluster := testCluster(lvmv1alpha1.DeviceClass{
+		Name:           "thin",
+		ThinPoolConfig: &lvmv1alpha1.ThinPoolConfig{Name: "pool"},
+		Alerts: &lvmv1alpha1.DeviceClassAlerts{
+			ThinPoolDataUsage: &lvmv1alpha1.UsageAlertConfig{
+				AlertConfig:     lvmv1alpha1.AlertConfig{For: &metav1.Duration{Duration: 90 * time.Second}},
+				NearFullPercent: ptr.To(90),
+				CriticalPercent: ptr.To(95),
+			},
+			ThinPoolMetadataUsage:  &lvmv1alpha1.UsageAlertConfig{AlertConfig: lvmv1alpha1.AlertConfig{Enabled: ptr.To(false)}},
+			MissingPhysicalVolumes: &lvmv1alpha1.AlertConfig{Enabled: ptr.To(false)},
+		},
+	})
+
+	rules := rulesByAlert(prometheusRuleSpec(cluster.Spec.Storage.DeviceClasses))
+	if len(rules[AlertThinPoolMetadataUsageNearFull]) != 0 || len(rules[AlertPhysicalVolumeMissing]) != 0 {
+		t.Errorf("expected disabled alerts to be left out")
+	}
+
+	nearFull := rules[AlertThinPoolDataUsageNearFull]
+	if len(nearFull) != 1 {
+		t.Fatalf("expected 1 %s rule, got %d", AlertThinPoolDataUsageNearFull, len(nearFull))
+	}
+	if expr := nearFull[0].Expr.String(); !strings.Contains(expr, "> 90") || !strings.Contains(expr, "<= 95") {
+		t.Errorf("expected configured thresholds in expression, got %s", expr)
+	}
+	if nearFull[0].For == nil || *nearFull[0].For != "1m30s" {
+		t.Errorf("expected for duration of 1m30s, got %v", nearFull[0].For)
+	}
+
+	// the volume group usage alert follows the thresholds of the thin pool data usage
+	if expr := rules[AlertVolumeGroupUsageCritical][0].Expr.String(); !strings.Contains(expr, metricThinPoolDataPercent+`{device_class="thin"} > 95`) {
+		t.Errorf("expected configured thin pool threshold in volume group usage expression, got %s", expr)
+	}
+}
+
+func TestPrometheusRuleEnsureCreated(t *testing.T) {
+	scheme := newTestScheme(t)
+	if err := monitoringv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding monitoringv1 to scheme: %v", err)
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding apiextensionsv1 to scheme: %v", err)
+	}
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	cluster := testCluster(lvmv1alpha1.DeviceClass{Name: "vg1"})
+	key := types.NamespacedName{Name: prometheusRuleName, Namespace: "default"}
+
+	// without the CRD, nothing is created
+	r := newFakeStorageClassReconciler(t, scheme)
+	if err := PrometheusRule().EnsureCreated(r, ctx, cluster); err != nil {
+		t.Fatalf("EnsureCreated failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &monitoringv1.PrometheusRule{}); !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected no PrometheusRule without the CRD, got %v", err)
+	}
+
+	// an outdated rule, e.g. shipped by a previous bundle, is taken over
+	r = newFakeStorageClassReconciler(t, scheme,
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "prometheusrules.monitoring.coreos.com"}},
+		&monitoringv1.PrometheusRule{
+			ObjectMeta: metav1.ObjectMeta{Name: prometheusRuleName, Namespace: "default"},
+			Spec:       monitoringv1.PrometheusRuleSpec{Groups: []monitoringv1.RuleGroup{{Name: "outdated"}}},
+		},
+	)
+	if err := PrometheusRule().EnsureCreated(r, ctx, cluster); err != nil {
+		t.Fatalf("EnsureCreated failed: %v", err)
+	}
+	rule := &monitoringv1.PrometheusRule{}
+	if err := r.Get(ctx, key, rule); err != nil {
+		t.Fatalf("expected PrometheusRule to exist: %v", err)
+	}
+	if len(rule.Spec.Groups) != 2 || rule.Spec.Groups[0].Name != volumeGroupAlertRuleGroupName {
+		t.Errorf("expected the rule groups to be updated, got %v", rule.Spec.Groups)
+	}
+
+	if err := PrometheusRule().EnsureDeleted(r, ctx, cluster); err != nil {
+		t.Fatalf("EnsureDeleted failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &monitoringv1.PrometheusRule{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected PrometheusRule to be deleted, got %v", err)
+	}
+}
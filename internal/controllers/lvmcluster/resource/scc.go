@@ -44,6 +44,7 @@ type openshiftSccs struct{}
 
 // openshiftSccs unit satisfies resourceManager interface
 var _ Manager = openshiftSccs{}
+var _ DriftDetector = openshiftSccs{}
 
 func (c openshiftSccs) GetName() string {
 	return sccName
@@ -53,8 +54,28 @@ func (c openshiftSccs) GetName() string {
 
 func (c openshiftSccs) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName())
-	sccs := getAllSCCs(r.GetNamespace())
+
+	// the SecurityContextConstraints are shared by all LVMClusters and labeled with the primary one
+	clusters, err := sharedClusters(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+
+	sccs, err := desiredSCCs(r, &clusters[0])
+	if err != nil {
+		return err
+	}
+	policy := driftPoliciesOf(&clusters[0]).SecurityContextConstraints
 	for _, template := range sccs {
+		fields, err := detectDrift(ctx, r, template, &secv1.SecurityContextConstraints{}, policy)
+		if err != nil {
+			return err
+		}
+		if len(fields) > 0 && policy == lvmv1alpha1.DriftPolicyReport {
+			logger.V(2).Info("SecurityContextConstraint drifted from its desired state and is not overwritten", "name", template.Name, "fields", fields)
+			continue
+		}
+
 		scc := &secv1.SecurityContextConstraints{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: template.Name,
@@ -62,10 +83,17 @@ func (c openshiftSccs) EnsureCreated(r Reconciler, ctx context.Context, cluster
 		}
 
 		result, err := cutil.CreateOrUpdate(ctx, r, scc, func() error {
+			// the fields that are not set by the operator are defaulted, so they are only
+			// overwritten at creation and when the SecurityContextConstraint drifted
 			if scc.CreationTimestamp.IsZero() {
 				template.DeepCopyInto(scc)
+			} else if len(fields) > 0 {
+				objectMeta := scc.ObjectMeta
+				template.DeepCopyInto(scc)
+				scc.ObjectMeta = objectMeta
 			}
-			labels.SetManagedLabels(r.Scheme(), scc, cluster)
+			labels.SetManagedLabels(r.Scheme(), scc, &clusters[0])
+			copyDesiredStateHash(template.Annotations[constants.DesiredStateHashAnnotation], scc)
 			scc.Users = template.Users
 			return nil
 		})
@@ -80,8 +108,46 @@ func (c openshiftSccs) EnsureCreated(r Reconciler, ctx context.Context, cluster
 	return nil
 }
 
-func (c openshiftSccs) EnsureDeleted(r Reconciler, ctx context.Context, _ *lvmv1alpha1.LVMCluster) error {
+func (c openshiftSccs) DetectDrift(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) ([]Drift, error) {
+	clusters, err := sharedClusters(ctx, r, cluster)
+	if err != nil {
+		return nil, err
+	}
+	sccs, err := desiredSCCs(r, &clusters[0])
+	if err != nil {
+		return nil, err
+	}
+	policy := driftPoliciesOf(&clusters[0]).SecurityContextConstraints
+	var drifts []Drift
+	for _, template := range sccs {
+		fields, err := detectDrift(ctx, r, template, &secv1.SecurityContextConstraints{}, policy)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, driftOf("SecurityContextConstraints", template, fields, policy)...)
+	}
+	return drifts, nil
+}
+
+// desiredSCCs returns the SecurityContextConstraints labeled with the primary LVMCluster.
+func desiredSCCs(r Reconciler, primary *lvmv1alpha1.LVMCluster) ([]*secv1.SecurityContextConstraints, error) {
+	sccs := getAllSCCs(r.GetNamespace())
+	for _, scc := range sccs {
+		labels.SetManagedLabels(r.Scheme(), scc, primary)
+		if err := setDesiredStateHash(scc); err != nil {
+			return nil, err
+		}
+	}
+	return sccs, nil
+}
+
+func (c openshiftSccs) EnsureDeleted(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName())
+
+	if shared, err := handOver(ctx, r, c, cluster); shared || err != nil {
+		return err
+	}
+
 	sccs := getAllSCCs(r.GetNamespace())
 	for _, scc := range sccs {
 		name := types.NamespacedName{Name: scName}
@@ -94,6 +94,13 @@ func (s serviceMonitorManager) EnsureCreated(r Reconciler, ctx context.Context,
 						},
 						CAFile: "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt",
 					},
+					// the vg-manager metrics describe the node they are scraped from, which the alerts refer to
+					RelabelConfigs: []monitoringv1.RelabelConfig{
+						{
+							SourceLabels: []monitoringv1.LabelName{"__meta_kubernetes_pod_node_name"},
+							TargetLabel:  "node",
+						},
+					},
 				},
 			},
 			Selector: metav1.LabelSelector{
@@ -104,10 +111,16 @@ func (s serviceMonitorManager) EnsureCreated(r Reconciler, ctx context.Context,
 		},
 	}
 
+	// the ServiceMonitor is shared by all LVMClusters and labeled with the primary one
+	clusters, err := sharedClusters(ctx, r, lvmCluster)
+	if err != nil {
+		return err
+	}
+
 	// Create or update the ServiceMonitor
 	result, err := cutil.CreateOrUpdate(ctx, r, serviceMonitor, func() error {
 		// Set managed labels
-		labels.SetManagedLabels(r.Scheme(), serviceMonitor, lvmCluster)
+		labels.SetManagedLabels(r.Scheme(), serviceMonitor, &clusters[0])
 		return nil
 	})
 
@@ -135,6 +148,10 @@ func (s serviceMonitorManager) EnsureDeleted(r Reconciler, ctx context.Context,
 		return nil
 	}
 
+	if shared, err := handOver(ctx, r, s, lvmCluster); shared || err != nil {
+		return err
+	}
+
 	name := types.NamespacedName{Name: s.GetName(), Namespace: r.GetNamespace()}
 	serviceMonitor := &monitoringv1.ServiceMonitor{}
 
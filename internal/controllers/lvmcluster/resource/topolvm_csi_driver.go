@@ -44,6 +44,7 @@ type csiDriver struct{}
 
 // csiDriver unit satisfies resourceManager interface
 var _ Manager = csiDriver{}
+var _ DriftDetector = csiDriver{}
 
 func (c csiDriver) GetName() string {
 	return driverName
@@ -53,11 +54,36 @@ func (c csiDriver) GetName() string {
 
 func (c csiDriver) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName())
-	csiDriverResource := getCSIDriverResource()
 
+	// the driver is shared by all LVMClusters and labeled with the primary one
+	clusters, err := sharedClusters(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+
+	desired, err := desiredCSIDriver(r, &clusters[0])
+	if err != nil {
+		return err
+	}
+	if policy := driftPoliciesOf(&clusters[0]).CSIDriver; policy == lvmv1alpha1.DriftPolicyReport {
+		fields, err := detectDrift(ctx, r, desired, &storagev1.CSIDriver{}, policy)
+		if err != nil {
+			return err
+		}
+		if len(fields) > 0 {
+			logger.V(2).Info("CSIDriver drifted from its desired state and is not overwritten", "name", desired.Name, "fields", fields)
+			return nil
+		}
+	}
+
+	csiDriverResource := desired.DeepCopy()
 	result, err := cutil.CreateOrUpdate(ctx, r, csiDriverResource, func() error {
-		labels.SetManagedLabels(r.Scheme(), csiDriverResource, cluster)
-		// no need to mutate any field
+		labels.SetManagedLabels(r.Scheme(), csiDriverResource, &clusters[0])
+		copyDesiredStateHash(desired.Annotations[constants.DesiredStateHashAnnotation], csiDriverResource)
+		csiDriverResource.Spec.AttachRequired = desired.Spec.AttachRequired
+		csiDriverResource.Spec.PodInfoOnMount = desired.Spec.PodInfoOnMount
+		csiDriverResource.Spec.StorageCapacity = desired.Spec.StorageCapacity
+		csiDriverResource.Spec.VolumeLifecycleModes = desired.Spec.VolumeLifecycleModes
 		return nil
 	})
 
@@ -70,7 +96,38 @@ func (c csiDriver) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvm
 	return nil
 }
 
-func (c csiDriver) EnsureDeleted(r Reconciler, ctx context.Context, _ *lvmv1alpha1.LVMCluster) error {
+func (c csiDriver) DetectDrift(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) ([]Drift, error) {
+	clusters, err := sharedClusters(ctx, r, cluster)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := desiredCSIDriver(r, &clusters[0])
+	if err != nil {
+		return nil, err
+	}
+	policy := driftPoliciesOf(&clusters[0]).CSIDriver
+	fields, err := detectDrift(ctx, r, desired, &storagev1.CSIDriver{}, policy)
+	if err != nil {
+		return nil, err
+	}
+	return driftOf("CSIDriver", desired, fields, policy), nil
+}
+
+// desiredCSIDriver returns the driver labeled with the primary LVMCluster.
+func desiredCSIDriver(r Reconciler, primary *lvmv1alpha1.LVMCluster) (*storagev1.CSIDriver, error) {
+	desired := getCSIDriverResource()
+	labels.SetManagedLabels(r.Scheme(), desired, primary)
+	if err := setDesiredStateHash(desired); err != nil {
+		return nil, err
+	}
+	return desired, nil
+}
+
+func (c csiDriver) EnsureDeleted(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
+	if shared, err := handOver(ctx, r, c, cluster); shared || err != nil {
+		return err
+	}
+
 	name := types.NamespacedName{Name: constants.TopolvmCSIDriverName}
 	logger := log.FromContext(ctx).WithValues("resourceManager", c.GetName(), "CSIDriver", constants.TopolvmCSIDriverName)
 	csiDriverResource := &storagev1.CSIDriver{}
@@ -44,6 +44,7 @@ type topolvmVolumeSnapshotClass struct{}
 
 // topolvmVolumeSnapshotClass unit satisfies resourceManager interface
 var _ Manager = topolvmVolumeSnapshotClass{}
+var _ DriftDetector = topolvmVolumeSnapshotClass{}
 
 func (s topolvmVolumeSnapshotClass) GetName() string {
 	return vscName
@@ -54,11 +55,29 @@ func (s topolvmVolumeSnapshotClass) GetName() string {
 func (s topolvmVolumeSnapshotClass) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", s.GetName())
 	// one volume snapshot class for every deviceClass based on CR is created
-	topolvmSnapshotClasses := getTopolvmSnapshotClasses(cluster)
-	for _, vsc := range topolvmSnapshotClasses {
-		// we anticipate no edits to volume snapshot class
+	topolvmSnapshotClasses, err := desiredSnapshotClasses(r, cluster)
+	if err != nil {
+		return err
+	}
+	policy := driftPoliciesOf(cluster).VolumeSnapshotClass
+	for _, desired := range topolvmSnapshotClasses {
+		if policy == lvmv1alpha1.DriftPolicyReport {
+			fields, err := detectDrift(ctx, r, desired, &snapapi.VolumeSnapshotClass{}, policy)
+			if err != nil {
+				return err
+			}
+			if len(fields) > 0 {
+				logger.V(2).Info("VolumeSnapshotClass drifted from its desired state and is not overwritten", "name", desired.Name, "fields", fields)
+				continue
+			}
+		}
+
+		vsc := desired.DeepCopy()
 		result, err := cutil.CreateOrUpdate(ctx, r, vsc, func() error {
 			labels.SetManagedLabels(r.Scheme(), vsc, cluster)
+			copyDesiredStateHash(desired.Annotations[constants.DesiredStateHashAnnotation], vsc)
+			vsc.Driver = desired.Driver
+			vsc.DeletionPolicy = desired.DeletionPolicy
 			return nil
 		})
 		if err != nil {
@@ -71,6 +90,35 @@ func (s topolvmVolumeSnapshotClass) EnsureCreated(r Reconciler, ctx context.Cont
 	return nil
 }
 
+func (s topolvmVolumeSnapshotClass) DetectDrift(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) ([]Drift, error) {
+	topolvmSnapshotClasses, err := desiredSnapshotClasses(r, cluster)
+	if err != nil {
+		return nil, err
+	}
+	policy := driftPoliciesOf(cluster).VolumeSnapshotClass
+	var drifts []Drift
+	for _, desired := range topolvmSnapshotClasses {
+		fields, err := detectDrift(ctx, r, desired, &snapapi.VolumeSnapshotClass{}, policy)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, driftOf("VolumeSnapshotClass", desired, fields, policy)...)
+	}
+	return drifts, nil
+}
+
+// desiredSnapshotClasses returns the volume snapshot classes of the LVMCluster labeled with it.
+func desiredSnapshotClasses(r Reconciler, cluster *lvmv1alpha1.LVMCluster) ([]*snapapi.VolumeSnapshotClass, error) {
+	topolvmSnapshotClasses := getTopolvmSnapshotClasses(cluster)
+	for _, vsc := range topolvmSnapshotClasses {
+		labels.SetManagedLabels(r.Scheme(), vsc, cluster)
+		if err := setDesiredStateHash(vsc); err != nil {
+			return nil, err
+		}
+	}
+	return topolvmSnapshotClasses, nil
+}
+
 func (s topolvmVolumeSnapshotClass) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", s.GetName())
 
@@ -48,6 +48,7 @@ type topolvmStorageClass struct{}
 
 // topolvmStorageClass unit satisfies resourceManager interface
 var _ Manager = topolvmStorageClass{}
+var _ DriftDetector = topolvmStorageClass{}
 
 func (s topolvmStorageClass) GetName() string {
 	return scName
@@ -58,9 +59,24 @@ func (s topolvmStorageClass) GetName() string {
 func (s topolvmStorageClass) EnsureCreated(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", s.GetName())
 
-	topolvmStorageClasses := s.getTopolvmStorageClasses(r, ctx, cluster)
+	topolvmStorageClasses, err := s.desiredStorageClasses(r, ctx, cluster)
+	if err != nil {
+		return err
+	}
 
+	policy := driftPoliciesOf(cluster).StorageClass
 	for _, sc := range topolvmStorageClasses {
+		if policy == lvmv1alpha1.DriftPolicyReport {
+			fields, err := detectDrift(ctx, r, sc, &storagev1.StorageClass{}, policy)
+			if err != nil {
+				return err
+			}
+			if len(fields) > 0 {
+				logger.V(2).Info("StorageClass drifted from its desired state and is not overwritten", "name", sc.Name, "fields", fields)
+				continue
+			}
+		}
+
 		if err := r.Patch(ctx, sc,
 			client.Apply, //nolint:staticcheck // TODO: migrate to client.Client.Apply() with typed apply configurations
 			client.FieldOwner(storageClassFieldOwner),
@@ -73,6 +89,34 @@ func (s topolvmStorageClass) EnsureCreated(r Reconciler, ctx context.Context, cl
 	return nil
 }
 
+func (s topolvmStorageClass) DetectDrift(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) ([]Drift, error) {
+	topolvmStorageClasses, err := s.desiredStorageClasses(r, ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	policy := driftPoliciesOf(cluster).StorageClass
+	var drifts []Drift
+	for _, sc := range topolvmStorageClasses {
+		fields, err := detectDrift(ctx, r, sc, &storagev1.StorageClass{}, policy)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, driftOf("StorageClass", sc, fields, policy)...)
+	}
+	return drifts, nil
+}
+
+// desiredStorageClasses returns the storage classes of the LVMCluster with the hash of their desired state.
+func (s topolvmStorageClass) desiredStorageClasses(r Reconciler, ctx context.Context, cluster *lvmv1alpha1.LVMCluster) ([]*storagev1.StorageClass, error) {
+	topolvmStorageClasses := s.getTopolvmStorageClasses(r, ctx, cluster)
+	for _, sc := range topolvmStorageClasses {
+		if err := setDesiredStateHash(sc); err != nil {
+			return nil, err
+		}
+	}
+	return topolvmStorageClasses, nil
+}
+
 func (s topolvmStorageClass) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", s.GetName())
 
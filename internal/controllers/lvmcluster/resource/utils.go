@@ -46,8 +46,9 @@ func GetVolumeSnapshotClassName(deviceName string) string {
 }
 
 func VerifyDaemonSetReadiness(ds *appsv1.DaemonSet) error {
-	// If the update strategy is not a rolling update, there will be nothing to wait for
-	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+	// If the update strategy is neither a rolling update nor an update on deletion, there will be nothing to wait for
+	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType &&
+		ds.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType {
 		return nil
 	}
 
@@ -55,12 +56,18 @@ func VerifyDaemonSetReadiness(ds *appsv1.DaemonSet) error {
 	if ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
 		return fmt.Errorf("the DaemonSet is not ready: %s/%s. %d out of %d expected pods have been scheduled", ds.Namespace, ds.Name, ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
 	}
-	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable, int(ds.Status.DesiredNumberScheduled), true)
-	if err != nil {
-		// If for some reason the value is invalid, set max unavailable to the
-		// number of desired replicas. This is the same behavior as the
-		// `MaxUnavailable` function in deploymentutil
-		maxUnavailable = int(ds.Status.DesiredNumberScheduled)
+
+	// pods updated on deletion are replaced by the operator, so all of them are expected to be ready
+	maxUnavailable := 0
+	if ds.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType {
+		var err error
+		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable, int(ds.Status.DesiredNumberScheduled), true)
+		if err != nil {
+			// If for some reason the value is invalid, set max unavailable to the
+			// number of desired replicas. This is the same behavior as the
+			// `MaxUnavailable` function in deploymentutil
+			maxUnavailable = int(ds.Status.DesiredNumberScheduled)
+		}
 	}
 
 	expectedReady := int(ds.Status.DesiredNumberScheduled) - maxUnavailable
@@ -19,9 +19,11 @@ package resource
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	"github.com/openshift/lvm-operator/v4/internal/cluster"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +45,7 @@ type vgManager struct {
 }
 
 var _ Manager = vgManager{}
+var _ DriftDetector = vgManager{}
 
 const (
 	VGManagerUnit = "vg-manager"
@@ -55,17 +58,16 @@ func (v vgManager) GetName() string {
 func (v vgManager) EnsureCreated(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", v.GetName())
 
-	// get desired daemonset spec
-	dsTemplate := templateVGManagerDaemonset(
-		lvmCluster,
-		v.clusterType,
-		r.GetNamespace(),
-		r.GetImageName(),
-		r.GetVGManagerCommand(),
-		r.GetLogPassthroughOptions().VGManager.AsArgs(),
-	)
-	if err := ctrl.SetControllerReference(lvmCluster, &dsTemplate, r.Scheme()); err != nil {
-		return fmt.Errorf("failed to set controller reference on vgManager daemonset %q. %v", dsTemplate.Name, err)
+	// the daemonset is shared by all LVMClusters and owned by the primary one
+	lvmClusters, err := sharedClusters(ctx, r, lvmCluster)
+	if err != nil {
+		return err
+	}
+	primary := &lvmClusters[0]
+
+	dsTemplate, err := v.desiredDaemonSet(r, ctx, lvmClusters)
+	if err != nil {
+		return err
 	}
 
 	// create desired daemonset or update mutable fields on existing one
@@ -75,73 +77,255 @@ func (v vgManager) EnsureCreated(r Reconciler, ctx context.Context, lvmCluster *
 			Namespace: dsTemplate.Namespace,
 		},
 	}
+
+	if policy := driftPoliciesOf(primary).DaemonSet; policy == lvmv1alpha1.DriftPolicyReport {
+		fields, err := v.drift(r, ctx, primary, dsTemplate, ds, policy)
+		if err != nil {
+			return err
+		}
+		if len(fields) > 0 {
+			logger.V(2).Info("DaemonSet drifted from its desired state and is not overwritten", "name", ds.Name, "fields", fields)
+			if err := v.updateTemplateHash(ctx, r, ds); err != nil {
+				return err
+			}
+			if err := v.rollout(ctx, r, ds, primary.Spec.VGManager); err != nil {
+				return fmt.Errorf("DaemonSet is not considered ready: %w", err)
+			}
+			return nil
+		}
+	}
+
 	// the anonymous mutate function modifies the daemonset object after fetching it.
 	// if the daemonset does not already exist, it creates it, otherwise, it updates it
 	result, err := ctrl.CreateOrUpdate(ctx, r, ds, func() error {
-		// at creation, deep copy the whole daemonset
-		if ds.CreationTimestamp.IsZero() {
-			dsTemplate.DeepCopyInto(ds)
-			return nil
-		}
+		return v.mutate(r, primary, dsTemplate, ds)
+	})
 
-		if err := ctrl.SetControllerReference(lvmCluster, ds, r.Scheme()); err != nil {
-			return fmt.Errorf("failed to set controller reference on vgManager daemonset %q. %v", dsTemplate.Name, err)
-		}
+	if err != nil {
+		return fmt.Errorf("%s failed to reconcile: %w", v.GetName(), err)
+	}
 
-		// if update, update only mutable fields
-		initMapIfNil(&ds.Labels)
-		initMapIfNil(&ds.Spec.Template.Labels)
-		for key, value := range dsTemplate.Labels {
-			ds.Labels[key] = value
-			ds.Spec.Template.Labels[key] = value
-		}
+	if result != cutil.OperationResultNone {
+		logger.V(2).Info("DaemonSet applied to cluster", "operation", result, "name", ds.Name)
+	}
+	if err := v.recordAppliedTemplateHash(ctx, r, ds); err != nil {
+		return err
+	}
 
-		initMapIfNil(&ds.Annotations)
-		for key, value := range dsTemplate.Annotations {
-			ds.Annotations[key] = value
-		}
+	if err := v.rollout(ctx, r, ds, primary.Spec.VGManager); err != nil {
+		return fmt.Errorf("DaemonSet is not considered ready: %w", err)
+	}
 
-		initMapIfNil(&ds.Spec.Template.Annotations)
-		for key, value := range dsTemplate.Spec.Template.Annotations {
-			ds.Spec.Template.Annotations[key] = value
-		}
+	return nil
+}
 
-		ds.Spec.Template.Spec.Containers = dsTemplate.Spec.Template.Spec.Containers
-		ds.Spec.Template.Spec.Volumes = dsTemplate.Spec.Template.Spec.Volumes
-		ds.Spec.Template.Spec.ServiceAccountName = dsTemplate.Spec.Template.Spec.ServiceAccountName
-		ds.Spec.Template.Spec.PriorityClassName = dsTemplate.Spec.Template.Spec.PriorityClassName
-		ds.Spec.Template.Spec.Tolerations = dsTemplate.Spec.Template.Spec.Tolerations
+func (v vgManager) DetectDrift(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) ([]Drift, error) {
+	lvmClusters, err := sharedClusters(ctx, r, lvmCluster)
+	if err != nil {
+		return nil, err
+	}
+	primary := &lvmClusters[0]
 
-		if dsTemplate.Spec.Template.Spec.Affinity != nil {
-			setDaemonsetNodeSelector(dsTemplate.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, ds)
-		}
+	dsTemplate, err := v.desiredDaemonSet(r, ctx, lvmClusters)
+	if err != nil {
+		return nil, err
+	}
+	policy := driftPoliciesOf(primary).DaemonSet
+	fields, err := v.drift(r, ctx, primary, dsTemplate, &appsv1.DaemonSet{}, policy)
+	if err != nil {
+		return nil, err
+	}
+	return driftOf("DaemonSet", dsTemplate, fields, policy), nil
+}
+
+// desiredDaemonSet returns the desired vg-manager daemonset of the given LVMClusters, the first of which is the primary one.
+func (v vgManager) desiredDaemonSet(r Reconciler, ctx context.Context, lvmClusters []lvmv1alpha1.LVMCluster) (*appsv1.DaemonSet, error) {
+	// get desired daemonset spec
+	dsTemplate := templateVGManagerDaemonset(
+		lvmClusters,
+		v.clusterType,
+		r.GetNamespace(),
+		r.GetImageName(),
+		r.GetVGManagerCommand(),
+		r.GetLogPassthroughOptions().VGManager.AsArgs(),
+	)
+	if err := setSharedControllerReference(&lvmClusters[0], &dsTemplate, r.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference on vgManager daemonset %q. %v", dsTemplate.Name, err)
+	}
+
+	// the hash of the desired pod template tells outdated pods apart during the rollout. It is taken before the
+	// retained nodes are added to the affinity, as they change whenever a node reports its first volume group or a
+	// node status is removed, which would restart vg-manager on every node.
+	templateHash, err := hashOf(&dsTemplate.Spec.Template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the pod template of vgManager daemonset %q: %w", dsTemplate.Name, err)
+	}
+	dsTemplate.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation] = templateHash
+
+	// nodes that no longer match the node selector keep running vg-manager until their volume groups are removed
+	retained, err := retainedNodes(ctx, r, lvmClusters)
+	if err != nil {
+		return nil, err
+	}
+	if affinity := dsTemplate.Spec.Template.Spec.Affinity; affinity != nil && len(retained) > 0 {
+		nodeSelector := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		nodeSelector.NodeSelectorTerms = append(nodeSelector.NodeSelectorTerms, v1.NodeSelectorTerm{
+			MatchFields: []v1.NodeSelectorRequirement{{
+				Key:      "metadata.name",
+				Operator: v1.NodeSelectorOpIn,
+				Values:   retained,
+			}},
+		})
+	}
+
+	if err := setDesiredStateHash(&dsTemplate); err != nil {
+		return nil, err
+	}
+	return &dsTemplate, nil
+}
 
+// mutate applies the desired daemonset to the given one, which is copied as a whole at creation.
+func (v vgManager) mutate(r Reconciler, primary *lvmv1alpha1.LVMCluster, dsTemplate, ds *appsv1.DaemonSet) error {
+	// at creation, deep copy the whole daemonset
+	if ds.CreationTimestamp.IsZero() {
+		dsTemplate.DeepCopyInto(ds)
 		return nil
-	})
+	}
+
+	if err := setSharedControllerReference(primary, ds, r.Scheme()); err != nil {
+		return fmt.Errorf("failed to set controller reference on vgManager daemonset %q. %v", dsTemplate.Name, err)
+	}
+
+	// if update, update only mutable fields
+	initMapIfNil(&ds.Labels)
+	initMapIfNil(&ds.Spec.Template.Labels)
+	for key, value := range dsTemplate.Labels {
+		ds.Labels[key] = value
+		ds.Spec.Template.Labels[key] = value
+	}
 
+	initMapIfNil(&ds.Annotations)
+	for key, value := range dsTemplate.Annotations {
+		ds.Annotations[key] = value
+	}
+
+	initMapIfNil(&ds.Spec.Template.Annotations)
+	for key, value := range dsTemplate.Spec.Template.Annotations {
+		ds.Spec.Template.Annotations[key] = value
+	}
+
+	ds.Spec.Template.Spec.Containers = dsTemplate.Spec.Template.Spec.Containers
+	ds.Spec.Template.Spec.Volumes = dsTemplate.Spec.Template.Spec.Volumes
+	ds.Spec.Template.Spec.ServiceAccountName = dsTemplate.Spec.Template.Spec.ServiceAccountName
+	ds.Spec.Template.Spec.PriorityClassName = dsTemplate.Spec.Template.Spec.PriorityClassName
+	ds.Spec.Template.Spec.Tolerations = dsTemplate.Spec.Template.Spec.Tolerations
+	ds.Spec.Template.Spec.NodeSelector = dsTemplate.Spec.Template.Spec.NodeSelector
+	ds.Spec.UpdateStrategy = dsTemplate.Spec.UpdateStrategy
+
+	// the node selector is the union of all LVMClusters, so it can also be removed when an LVMCluster without one is added
+	var nodeSelector *v1.NodeSelector
+	if dsTemplate.Spec.Template.Spec.Affinity != nil {
+		nodeSelector = dsTemplate.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	}
+	setDaemonsetNodeSelector(nodeSelector, ds)
+
+	return nil
+}
+
+// drift gets the daemonset into ds and returns the fields in which it drifted from the desired daemonset.
+func (v vgManager) drift(r Reconciler, ctx context.Context, primary *lvmv1alpha1.LVMCluster, dsTemplate, ds *appsv1.DaemonSet, policy lvmv1alpha1.DriftPolicy) ([]string, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(dsTemplate), ds); errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get vgManager daemonset %q to detect drift: %w", dsTemplate.Name, err)
+	}
+	expected := ds.DeepCopy()
+	if err := v.mutate(r, primary, dsTemplate, expected); err != nil {
+		return nil, err
+	}
+	if policy == lvmv1alpha1.DriftPolicyReport && ds.Spec.Template.Annotations != nil {
+		// the template hash of a template changed by others is updated by the operator, see updateTemplateHash
+		expected.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation] =
+			ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+	}
+	return driftedFields(expected, ds, policy)
+}
+
+// updateTemplateHash sets the template hash of the daemonset to the hash of its pod template, if the template was
+// changed by others since the operator last applied it. With the OnDelete update strategy, the pods are only replaced
+// by the operator once their template hash is outdated, so without it the changes to a template that is left in place
+// under the Report policy would never reach the pods. Pending changes of the operator are not applied, so they do not
+// cause a rollout either.
+func (v vgManager) updateTemplateHash(ctx context.Context, r Reconciler, ds *appsv1.DaemonSet) error {
+	liveHash, err := hashOf(&ds.Spec.Template)
 	if err != nil {
-		return fmt.Errorf("%s failed to reconcile: %w", v.GetName(), err)
+		return fmt.Errorf("failed to hash the pod template of vgManager daemonset %q: %w", ds.Name, err)
+	}
+	if applied := ds.Annotations[constants.VGManagerAppliedTemplateHashAnnotation]; applied == "" || applied == liveHash {
+		return nil
 	}
 
-	if result != cutil.OperationResultNone {
-		logger.V(2).Info("DaemonSet applied to cluster", "operation", result, "name", ds.Name)
+	template := ds.Spec.Template.DeepCopy()
+	delete(template.Annotations, constants.VGManagerTemplateHashAnnotation)
+	templateHash, err := hashOf(template)
+	if err != nil {
+		return fmt.Errorf("failed to hash the pod template of vgManager daemonset %q: %w", ds.Name, err)
 	}
+	patch := client.MergeFrom(ds.DeepCopy())
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = make(map[string]string)
+	}
+	ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation] = templateHash
+	if err := setAppliedTemplateHash(ds); err != nil {
+		return err
+	}
+	if err := r.Patch(ctx, ds, patch); err != nil {
+		return fmt.Errorf("failed to update the template hash of vgManager daemonset %q: %w", ds.Name, err)
+	}
+	return nil
+}
 
-	if err := VerifyDaemonSetReadiness(ds); err != nil {
-		return fmt.Errorf("DaemonSet is not considered ready: %w", err)
+// recordAppliedTemplateHash records the hash of the pod template of the daemonset after it was applied.
+func (v vgManager) recordAppliedTemplateHash(ctx context.Context, r Reconciler, ds *appsv1.DaemonSet) error {
+	patch := client.MergeFrom(ds.DeepCopy())
+	if err := setAppliedTemplateHash(ds); err != nil {
+		return err
+	}
+	if data, err := patch.Data(ds); err != nil || string(data) == "{}" {
+		return err
 	}
+	if err := r.Patch(ctx, ds, patch); err != nil {
+		return fmt.Errorf("failed to record the applied template hash of vgManager daemonset %q: %w", ds.Name, err)
+	}
+	return nil
+}
 
+// setAppliedTemplateHash sets the applied template hash of the daemonset to the hash of its pod template.
+func setAppliedTemplateHash(ds *appsv1.DaemonSet) error {
+	hash, err := hashOf(&ds.Spec.Template)
+	if err != nil {
+		return fmt.Errorf("failed to hash the pod template of vgManager daemonset %q: %w", ds.Name, err)
+	}
+	if ds.Annotations == nil {
+		ds.Annotations = make(map[string]string)
+	}
+	ds.Annotations[constants.VGManagerAppliedTemplateHashAnnotation] = hash
 	return nil
 }
 
 // EnsureDeleted makes sure that the driver is removed from the cluster and the daemonset is gone.
-// Deletion will be triggered again even though we also have an owner reference
+// Deletion will be triggered again even though we also have an owner reference.
+// If other LVMClusters remain, the daemonset is handed over to them instead.
 func (v vgManager) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *lvmv1alpha1.LVMCluster) error {
 	logger := log.FromContext(ctx).WithValues("resourceManager", v.GetName())
 
+	if shared, err := handOver(ctx, r, v, lvmCluster); shared || err != nil {
+		return err
+	}
+
 	// delete the daemonset
 	ds := templateVGManagerDaemonset(
-		lvmCluster,
+		[]lvmv1alpha1.LVMCluster{*lvmCluster},
 		v.clusterType,
 		r.GetNamespace(),
 		r.GetImageName(),
@@ -178,6 +362,29 @@ func (v vgManager) EnsureDeleted(r Reconciler, ctx context.Context, lvmCluster *
 	return nil
 }
 
+// retainedNodes returns the sorted names of the nodes that report a volume group of any of the device classes.
+// This includes nodes that no longer match the node selector of the device class but still hold its volume group.
+func retainedNodes(ctx context.Context, r Reconciler, lvmClusters []lvmv1alpha1.LVMCluster) ([]string, error) {
+	nodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{}
+	if err := r.List(ctx, nodeStatusList, client.InNamespace(r.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("failed to list LVMVolumeGroupNodeStatus: %w", err)
+	}
+
+	deviceClasses := deviceClassesOf(lvmClusters)
+	var nodes []string
+	for _, nodeStatus := range nodeStatusList.Items {
+		if slices.ContainsFunc(nodeStatus.Spec.LVMVGStatus, func(status lvmv1alpha1.VGStatus) bool {
+			return slices.ContainsFunc(deviceClasses, func(deviceClass lvmv1alpha1.DeviceClass) bool {
+				return deviceClass.Name == status.Name
+			})
+		}) {
+			nodes = append(nodes, nodeStatus.Name)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes, nil
+}
+
 func initMapIfNil(m *map[string]string) {
 	if len(*m) > 1 {
 		return
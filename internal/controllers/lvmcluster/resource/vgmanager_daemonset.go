@@ -19,6 +19,8 @@ package resource
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
@@ -202,6 +204,26 @@ var (
 	}
 )
 
+var (
+	MetadataBackupVolName = "metadata-backup"
+	// MetadataBackupVol is the corev1.Volume definition for the directory on the host in which
+	// the LVM metadata backups are written by vgcfgbackup and read by vgcfgrestore.
+	MetadataBackupVol = corev1.Volume{
+		Name: MetadataBackupVolName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: constants.MetadataBackupDir,
+				Type: &HostPathDirectoryOrCreate,
+			},
+		},
+	}
+	// MetadataBackupVolMount is the corresponding mount for MetadataBackupVol
+	MetadataBackupVolMount = corev1.VolumeMount{
+		Name:      MetadataBackupVolName,
+		MountPath: constants.MetadataBackupDir,
+	}
+)
+
 var (
 	MetricsCertsVolName = "metrics-cert"
 	// MetricsCertsDirVol is the corev1.Volume definition for the
@@ -223,9 +245,9 @@ var (
 	}
 )
 
-// templateVGManagerDaemonset returns the desired vgmanager daemonset for a given LVMCluster
+// templateVGManagerDaemonset returns the desired vgmanager daemonset shared by the given LVMClusters
 func templateVGManagerDaemonset(
-	lvmCluster *lvmv1alpha1.LVMCluster,
+	lvmClusters []lvmv1alpha1.LVMCluster,
 	clusterType cluster.Type,
 	namespace, vgImage string,
 	command, args []string,
@@ -236,7 +258,13 @@ func templateVGManagerDaemonset(
 		confMapVolume.HostPath.Path = filepath.Dir(lvmd.MicroShiftFileConfigPath)
 	}
 
-	nodeSelector, tolerations := selector.ExtractNodeSelectorAndTolerations(lvmCluster)
+	nodeSelector, tolerations := selector.ExtractNodeSelectorAndTolerationsOfClusters(lvmClusters)
+
+	// the daemonset is configured by the primary LVMCluster only
+	config := &lvmv1alpha1.VGManagerConfig{}
+	if len(lvmClusters) > 0 && lvmClusters[0].Spec.VGManager != nil {
+		config = lvmClusters[0].Spec.VGManager
+	}
 	volumes := []corev1.Volume{
 		RegistrationVol,
 		NodePluginVol,
@@ -247,6 +275,7 @@ func templateVGManagerDaemonset(
 		DevHostDirVol,
 		UDevHostDirVol,
 		SysHostDirVol,
+		MetadataBackupVol,
 		MetricsCertsDirVol,
 	}
 	volumeMounts := []corev1.VolumeMount{
@@ -259,6 +288,7 @@ func templateVGManagerDaemonset(
 		DevHostDirVolMount,
 		UDevHostDirVolMount,
 		SysHostDirVolMount,
+		MetadataBackupVolMount,
 		MetricsCertsDirVolMount,
 	}
 
@@ -267,12 +297,23 @@ func templateVGManagerDaemonset(
 	}
 
 	command = append(command, args...)
+	// the log level of the LVMCluster takes precedence over the one passed down by the operator, as later flags win
+	if config.LogLevel != nil {
+		command = append(command, logLevelArgs(*config.LogLevel)...)
+	}
 
-	resourceRequirements := corev1.ResourceRequirements{
-		Requests: corev1.ResourceList{
-			corev1.ResourceCPU:    resource.MustParse(constants.VgManagerCPURequest),
-			corev1.ResourceMemory: resource.MustParse(constants.VgManagerMemRequest),
-		},
+	resourceRequirements := vgManagerResources(config.Resources)
+	// GOMEMLIMIT only limits the memory of the Go runtime, while the memory limit of the container also covers
+	// stacks, cgo and the lvm processes run by vg-manager. It is kept at 90% of the memory limit, so that garbage
+	// is collected before the container is OOM-killed. Without a limit, the memory request is used.
+	goMemLimit := resourceRequirements.Requests.Memory().Value()
+	if limit, ok := resourceRequirements.Limits[corev1.ResourceMemory]; ok {
+		goMemLimit = limit.Value() / 10 * 9
+	}
+
+	priorityClassName := constants.PriorityClassNameUserCritical
+	if config.PriorityClassName != "" {
+		priorityClassName = config.PriorityClassName
 	}
 	containers := []corev1.Container{
 		{
@@ -314,10 +355,10 @@ func templateVGManagerDaemonset(
 				PeriodSeconds:       60},
 			VolumeMounts: volumeMounts,
 			Resources:    resourceRequirements,
-			Env: []corev1.EnvVar{
+			Env: mergeEnv([]corev1.EnvVar{
 				{
 					Name:  "GOMEMLIMIT",
-					Value: fmt.Sprintf("%sB", constants.VgManagerMemRequest),
+					Value: strconv.FormatInt(goMemLimit, 10),
 				},
 				{
 					Name:  "GOGC",
@@ -351,7 +392,7 @@ func templateVGManagerDaemonset(
 						},
 					},
 				},
-			},
+			}, config.Env),
 			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		},
 	}
@@ -372,6 +413,10 @@ func templateVGManagerDaemonset(
 		},
 		Spec: appsv1.DaemonSetSpec{
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			// the pods are replaced by the staged rollout of the operator
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.OnDeleteDaemonSetStrategyType,
+			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: annotations,
@@ -380,12 +425,13 @@ func templateVGManagerDaemonset(
 
 				Spec: corev1.PodSpec{
 					TerminationGracePeriodSeconds: ptr.To(int64(30)),
-					PriorityClassName:             constants.PriorityClassNameUserCritical,
+					PriorityClassName:             priorityClassName,
 					Volumes:                       volumes,
 					Containers:                    containers,
 					HostPID:                       true,
 					Tolerations:                   tolerations,
 					ServiceAccountName:            constants.VGManagerServiceAccount,
+					NodeSelector:                  config.NodeSelector,
 				},
 			},
 		},
@@ -396,6 +442,52 @@ func templateVGManagerDaemonset(
 	return ds
 }
 
+// vgManagerResources returns the resources of the vg-manager container. The configured requests and limits
+// override the default requests of the same resource.
+func vgManagerResources(configured *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(constants.VgManagerCPURequest),
+			corev1.ResourceMemory: resource.MustParse(constants.VgManagerMemRequest),
+		},
+	}
+	if configured == nil {
+		return resources
+	}
+	for name, quantity := range configured.Requests {
+		resources.Requests[name] = quantity.DeepCopy()
+	}
+	resources.Limits = configured.Limits.DeepCopy()
+	resources.Claims = slices.Clone(configured.Claims)
+	return resources
+}
+
+// logLevelArgs returns the arguments that set the verbosity of both loggers of vg-manager to the given level.
+// zap only accepts levels above 0, which are debug levels, so level 0 is passed as info.
+func logLevelArgs(level int32) []string {
+	zapLevel := "info"
+	if level > 0 {
+		zapLevel = strconv.Itoa(int(level))
+	}
+	return []string{"--zap-log-level=" + zapLevel, fmt.Sprintf("--v=%d", level)}
+}
+
+// mergeEnv adds the additional environment variables to the given ones, replacing the variables of the same name.
+// The variables identifying the pod are never replaced.
+func mergeEnv(env, additional []corev1.EnvVar) []corev1.EnvVar {
+	for _, variable := range additional {
+		if _, reserved := constants.ReservedVGManagerEnvVars[variable.Name]; reserved {
+			continue
+		}
+		if i := slices.IndexFunc(env, func(e corev1.EnvVar) bool { return e.Name == variable.Name }); i >= 0 {
+			env[i] = variable
+		} else {
+			env = append(env, variable)
+		}
+	}
+	return env
+}
+
 func GetAbsoluteKubeletPath(name string) string {
 	if strings.HasSuffix(name, "/") {
 		return name
@@ -0,0 +1,207 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// The vg-manager daemonset uses the OnDelete update strategy, so that the operator can gate its rollout on the health
+// of the nodes. Outdated pods are deleted in batches, and the next batch is only deleted once every updated pod
+// converged: it is ready, the volume groups of its node are ready and the CSI driver is registered on its node.
+// If an updated pod does not converge within the progress deadline, the rollout is halted.
+// Only pods replaced by the current rollout are gated on, so that a node with a failed volume group does not hold up
+// a rollout it is not part of, and vg-manager is never reported as rolling out while no pod is outdated.
+
+// ErrVGManagerRolloutInProgress is returned while vg-manager is rolled out to the nodes.
+var ErrVGManagerRolloutInProgress = errors.New("vg-manager rollout is in progress")
+
+// VGManagerRolloutHaltedError is returned if the rollout of vg-manager is halted
+// because vg-manager did not converge on the given nodes within the progress deadline.
+type VGManagerRolloutHaltedError struct {
+	Nodes            []string
+	ProgressDeadline time.Duration
+}
+
+func (e *VGManagerRolloutHaltedError) Error() string {
+	return fmt.Sprintf("vg-manager rollout is halted, as vg-manager did not become healthy within %v on nodes: %s",
+		e.ProgressDeadline, strings.Join(e.Nodes, ", "))
+}
+
+// rollout deletes the next batch of outdated vg-manager pods once all updated pods converged.
+func (v vgManager) rollout(ctx context.Context, r Reconciler, ds *appsv1.DaemonSet, config *lvmv1alpha1.VGManagerConfig) error {
+	logger := log.FromContext(ctx).WithValues("resourceManager", v.GetName())
+
+	maxUnavailable, progressDeadline := intstr.FromInt32(1), lvmv1alpha1.VGManagerProgressDeadlineDefault.Duration
+	if config != nil && config.UpdateStrategy != nil {
+		if config.UpdateStrategy.MaxUnavailable != nil {
+			maxUnavailable = *config.UpdateStrategy.MaxUnavailable
+		}
+		if config.UpdateStrategy.ProgressDeadline != nil {
+			progressDeadline = config.UpdateStrategy.ProgressDeadline.Duration
+		}
+	}
+
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return fmt.Errorf("%w: the DaemonSet controller did not observe the latest DaemonSet yet", ErrVGManagerRolloutInProgress)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(ds.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels)); err != nil {
+		return fmt.Errorf("failed to list pods of DaemonSet %q: %w", ds.Name, err)
+	}
+
+	templateHash := ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+	// with the OnDelete update strategy, outdated pods were all created before the template changed,
+	// so the current rollout started after the newest of them was created
+	var rolloutStarted *metav1.Time
+	for _, pod := range pods.Items {
+		if pod.Annotations[constants.VGManagerTemplateHashAnnotation] != templateHash &&
+			(rolloutStarted == nil || rolloutStarted.Before(&pod.CreationTimestamp)) {
+			rolloutStarted = &pod.CreationTimestamp
+		}
+	}
+	if rolloutStarted == nil {
+		return nil
+	}
+
+	var outdated []corev1.Pod
+	var progressing, failed []string
+	running := 0
+	for _, pod := range pods.Items {
+		if !pod.DeletionTimestamp.IsZero() {
+			progressing = append(progressing, pod.Spec.NodeName)
+			continue
+		}
+		running++
+		if pod.Annotations[constants.VGManagerTemplateHashAnnotation] != templateHash {
+			outdated = append(outdated, pod)
+			continue
+		}
+		if pod.CreationTimestamp.Before(rolloutStarted) {
+			// the pod was not replaced by the current rollout
+			continue
+		}
+		converged, err := podConverged(ctx, r, &pod)
+		if err != nil {
+			return err
+		}
+		if converged {
+			continue
+		}
+		if time.Since(pod.CreationTimestamp.Time) > progressDeadline {
+			failed = append(failed, pod.Spec.NodeName)
+		} else {
+			progressing = append(progressing, pod.Spec.NodeName)
+		}
+	}
+
+	if len(failed) > 0 {
+		slices.Sort(failed)
+		return &VGManagerRolloutHaltedError{Nodes: failed, ProgressDeadline: progressDeadline}
+	}
+	if len(progressing) > 0 {
+		slices.Sort(progressing)
+		return fmt.Errorf("%w: waiting for vg-manager to become healthy on nodes: %s",
+			ErrVGManagerRolloutInProgress, strings.Join(progressing, ", "))
+	}
+	if running < int(ds.Status.DesiredNumberScheduled) {
+		return fmt.Errorf("%w: %d out of %d pods are scheduled",
+			ErrVGManagerRolloutInProgress, running, ds.Status.DesiredNumberScheduled)
+	}
+	if len(outdated) == 0 {
+		return nil
+	}
+
+	batchSize, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, running, true)
+	if err != nil || batchSize < 1 {
+		batchSize = 1
+	}
+	// pods that are not ready are replaced first, as they cannot get any less available
+	slices.SortFunc(outdated, func(a, b corev1.Pod) int {
+		if aReady, bReady := podReady(&a), podReady(&b); aReady != bReady {
+			if bReady {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Spec.NodeName, b.Spec.NodeName)
+	})
+
+	var nodes []string
+	for _, pod := range outdated[:min(batchSize, len(outdated))] {
+		if err := r.Delete(ctx, &pod, client.Preconditions{UID: &pod.UID}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete outdated vg-manager pod %q: %w", pod.Name, err)
+		}
+		nodes = append(nodes, pod.Spec.NodeName)
+	}
+	logger.Info("updating vg-manager", "nodes", nodes, "outdated", len(outdated))
+
+	return fmt.Errorf("%w: updating vg-manager on nodes: %s", ErrVGManagerRolloutInProgress, strings.Join(nodes, ", "))
+}
+
+// podConverged returns true if the vg-manager pod is ready, the volume groups on its node are ready
+// and the CSI driver is registered on its node.
+func podConverged(ctx context.Context, r Reconciler, pod *corev1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" || !podReady(pod) {
+		return false, nil
+	}
+
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName, Namespace: pod.Namespace}, nodeStatus); err == nil {
+		for _, vgStatus := range nodeStatus.Spec.LVMVGStatus {
+			// orphaned volume groups are retained, but no longer managed
+			if vgStatus.Status != lvmv1alpha1.VGStatusReady && vgStatus.Status != lvmv1alpha1.VGStatusOrphaned {
+				return false, nil
+			}
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get LVMVolumeGroupNodeStatus of node %q: %w", pod.Spec.NodeName, err)
+	}
+
+	csiNode := &storagev1.CSINode{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, csiNode); k8serrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get CSINode of node %q: %w", pod.Spec.NodeName, err)
+	}
+	return slices.ContainsFunc(csiNode.Spec.Drivers, func(driver storagev1.CSINodeDriver) bool {
+		return driver.Name == constants.TopolvmCSIDriverName
+	}), nil
+}
+
+func podReady(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Status.Conditions, func(condition corev1.PodCondition) bool {
+		return condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue
+	})
+}
@@ -0,0 +1,238 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const testTemplateHash = "updated"
+
+func testRolloutDaemonSet(desired int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.VGManagerLabelVal, Namespace: "default", Generation: 2},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": constants.VGManagerLabelVal}},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{constants.VGManagerTemplateHashAnnotation: testTemplateHash},
+			}},
+		},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: desired},
+	}
+}
+
+func testRolloutPod(node, hash string, ready bool, created time.Time) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "vg-manager-" + node,
+			Namespace:         "default",
+			UID:               types.UID("vg-manager-" + node),
+			Labels:            map[string]string{"app": constants.VGManagerLabelVal},
+			Annotations:       map[string]string{constants.VGManagerTemplateHashAnnotation: hash},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type:   corev1.PodReady,
+			Status: status,
+		}}},
+	}
+}
+
+func testCSINode(node string) *storagev1.CSINode {
+	return &storagev1.CSINode{
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Spec: storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{
+			Name:   constants.TopolvmCSIDriverName,
+			NodeID: node,
+		}}},
+	}
+}
+
+func remainingPods(t *testing.T, ctx context.Context, r client.Client) map[string]bool {
+	t.Helper()
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		t.Fatalf("listing pods: %v", err)
+	}
+	nodes := map[string]bool{}
+	for _, pod := range pods.Items {
+		nodes[pod.Spec.NodeName] = true
+	}
+	return nodes
+}
+
+func TestVGManagerRollout(t *testing.T) {
+	now := time.Now()
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	t.Run("outdated pods are replaced in batches, unready pods first", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", "", true, now),
+			testRolloutPod("node-b", "", true, now),
+			testRolloutPod("node-c", "outdated", false, now),
+		)
+		err := vgManager{}.rollout(ctx, r, testRolloutDaemonSet(3), nil)
+		if !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 2 || nodes["node-c"] {
+			t.Fatalf("expected only the unready pod on node-c to be deleted, got %v", nodes)
+		}
+	})
+
+	t.Run("maxUnavailable sizes the batch", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", "", true, now),
+			testRolloutPod("node-b", "", true, now),
+			testRolloutPod("node-c", "", true, now),
+			testRolloutPod("node-d", "", true, now),
+		)
+		config := &lvmv1alpha1.VGManagerConfig{UpdateStrategy: &lvmv1alpha1.VGManagerUpdateStrategy{
+			MaxUnavailable: ptr.To(intstr.FromString("50%")),
+		}}
+		err := vgManager{}.rollout(ctx, r, testRolloutDaemonSet(4), config)
+		if !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 2 || !nodes["node-c"] || !nodes["node-d"] {
+			t.Fatalf("expected the pods on node-a and node-b to be deleted, got %v", nodes)
+		}
+	})
+
+	t.Run("the next batch waits for updated pods to converge", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", testTemplateHash, true, now),
+			testRolloutPod("node-b", "", true, now),
+			&lvmv1alpha1.LVMVolumeGroupNodeStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: "default"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{{
+					Name:   "vg1",
+					Status: lvmv1alpha1.VGStatusProgressing,
+				}}},
+			},
+			testCSINode("node-a"),
+		)
+		err := vgManager{}.rollout(ctx, r, testRolloutDaemonSet(2), nil)
+		if !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 2 {
+			t.Fatalf("expected no pod to be deleted while node-a did not converge, got %v", nodes)
+		}
+
+		nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+		if err := r.Get(ctx, client.ObjectKey{Name: "node-a", Namespace: "default"}, nodeStatus); err != nil {
+			t.Fatalf("getting node status: %v", err)
+		}
+		nodeStatus.Spec.LVMVGStatus[0].Status = lvmv1alpha1.VGStatusReady
+		if err := r.Update(ctx, nodeStatus); err != nil {
+			t.Fatalf("updating node status: %v", err)
+		}
+		err = vgManager{}.rollout(ctx, r, testRolloutDaemonSet(2), nil)
+		if !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 1 || !nodes["node-a"] {
+			t.Fatalf("expected the outdated pod on node-b to be deleted, got %v", nodes)
+		}
+	})
+
+	t.Run("the rollout is halted after the progress deadline", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", testTemplateHash, false, now.Add(-time.Hour)),
+			testRolloutPod("node-b", "", true, now.Add(-2*time.Hour)),
+		)
+		config := &lvmv1alpha1.VGManagerConfig{UpdateStrategy: &lvmv1alpha1.VGManagerUpdateStrategy{
+			ProgressDeadline: &metav1.Duration{Duration: 10 * time.Minute},
+		}}
+		err := vgManager{}.rollout(ctx, r, testRolloutDaemonSet(2), config)
+		var halted *VGManagerRolloutHaltedError
+		if !errors.As(err, &halted) {
+			t.Fatalf("expected the rollout to be halted, got %v", err)
+		}
+		if len(halted.Nodes) != 1 || halted.Nodes[0] != "node-a" {
+			t.Fatalf("expected node-a to be reported, got %v", halted.Nodes)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 2 {
+			t.Fatalf("expected no pod to be deleted while the rollout is halted, got %v", nodes)
+		}
+	})
+
+	t.Run("the rollout is complete once all pods are updated and converged", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", testTemplateHash, true, now),
+			testCSINode("node-a"),
+		)
+		if err := (vgManager{}).rollout(ctx, r, testRolloutDaemonSet(1), nil); err != nil {
+			t.Fatalf("expected the rollout to be complete, got %v", err)
+		}
+	})
+
+	t.Run("a failed volume group does not affect the steady state", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", testTemplateHash, true, now.Add(-time.Hour)),
+			testRolloutPod("node-b", testTemplateHash, true, now.Add(-time.Hour)),
+			&lvmv1alpha1.LVMVolumeGroupNodeStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: "default"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{{
+					Name:   "vg1",
+					Status: lvmv1alpha1.VGStatusFailed,
+				}}},
+			},
+			testCSINode("node-a"),
+			testCSINode("node-b"),
+		)
+		if err := (vgManager{}).rollout(ctx, r, testRolloutDaemonSet(2), nil); err != nil {
+			t.Fatalf("expected no rollout while no pod is outdated, got %v", err)
+		}
+	})
+
+	t.Run("only pods replaced by the current rollout are gated on", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t),
+			testRolloutPod("node-a", testTemplateHash, true, now.Add(-2*time.Hour)),
+			testRolloutPod("node-b", "", true, now.Add(-time.Hour)),
+			&lvmv1alpha1.LVMVolumeGroupNodeStatus{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", Namespace: "default"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{{
+					Name:   "vg1",
+					Status: lvmv1alpha1.VGStatusFailed,
+				}}},
+			},
+		)
+		err := vgManager{}.rollout(ctx, r, testRolloutDaemonSet(2), nil)
+		if !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+		if nodes := remainingPods(t, ctx, r); len(nodes) != 1 || !nodes["node-a"] {
+			t.Fatalf("expected the outdated pod on node-b to be deleted despite the failed volume group on node-a, got %v", nodes)
+		}
+	})
+
+	t.Run("the rollout waits for the DaemonSet controller", func(t *testing.T) {
+		r := newFakeStorageClassReconciler(t, newTestScheme(t))
+		ds := testRolloutDaemonSet(1)
+		ds.Status.ObservedGeneration = 1
+		if err := (vgManager{}).rollout(ctx, r, ds, nil); !errors.Is(err, ErrVGManagerRolloutInProgress) {
+			t.Fatalf("expected the rollout to be in progress, got %v", err)
+		}
+	})
+}
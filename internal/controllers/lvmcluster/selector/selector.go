@@ -3,6 +3,7 @@ package selector
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -64,6 +65,57 @@ func ValidNodes(lvmCluster *lvmv1alpha1.LVMCluster, nodes *corev1.NodeList) ([]c
 	return validNodes, errors.Join(errs...)
 }
 
+// ExtractNodeSelectorAndTolerationsOfClusters combines the scheduling parameters of multiple lvmClusters,
+// so that a single daemonset can serve the device classes of all of them.
+func ExtractNodeSelectorAndTolerationsOfClusters(lvmClusters []lvmv1alpha1.LVMCluster) (*corev1.NodeSelector, []corev1.Toleration) {
+	var terms []corev1.NodeSelectorTerm
+	var tolerations []corev1.Toleration
+	unrestricted := false
+
+	for i := range lvmClusters {
+		nodeSelector, clusterTolerations := ExtractNodeSelectorAndTolerations(&lvmClusters[i])
+		if nodeSelector == nil {
+			unrestricted = true
+		} else {
+			terms = append(terms, nodeSelector.NodeSelectorTerms...)
+		}
+		for _, toleration := range clusterTolerations {
+			if !slices.ContainsFunc(tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+				tolerations = append(tolerations, toleration)
+			}
+		}
+	}
+
+	// if at least one lvmCluster has no nodeselector, all nodes have to be considered
+	if unrestricted || len(terms) == 0 {
+		return nil, tolerations
+	}
+	return &corev1.NodeSelector{NodeSelectorTerms: terms}, tolerations
+}
+
+// ValidNodesOfClusters returns the nodes that are valid for at least one of the lvmClusters.
+func ValidNodesOfClusters(lvmClusters []lvmv1alpha1.LVMCluster, nodes *corev1.NodeList) ([]corev1.Node, error) {
+	var validNodes []corev1.Node
+	seen := make(map[string]struct{})
+
+	var errs []error
+	for i := range lvmClusters {
+		clusterNodes, err := ValidNodes(&lvmClusters[i], nodes)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, node := range clusterNodes {
+			if _, ok := seen[node.Name]; ok {
+				continue
+			}
+			seen[node.Name] = struct{}{}
+			validNodes = append(validNodes, node)
+		}
+	}
+
+	return validNodes, errors.Join(errs...)
+}
+
 // TolerateAllTaints returns true if all taints are tolerated by the provided tolerations
 func ToleratesAllTaints(taints []corev1.Taint, tolerations []corev1.Toleration) (bool, error) {
 	for _, taint := range taints {
@@ -6,6 +6,7 @@ import (
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestExtractNodeSelectorAndTolerations(t *testing.T) {
@@ -62,3 +63,70 @@ func TestExtractNodeSelectorAndTolerations(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractNodeSelectorAndTolerationsOfClusters(t *testing.T) {
+	clusterWithSelector := func(key string, tolerations ...corev1.Toleration) lvmv1alpha1.LVMCluster {
+		return lvmv1alpha1.LVMCluster{
+			Spec: lvmv1alpha1.LVMClusterSpec{
+				Storage: lvmv1alpha1.Storage{
+					DeviceClasses: []lvmv1alpha1.DeviceClass{{
+						NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: corev1.NodeSelectorOpExists}},
+						}}},
+					}},
+				},
+				Tolerations: tolerations,
+			},
+		}
+	}
+	toleration := corev1.Toleration{Key: "key1", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+
+	nodeSelector, tolerations := ExtractNodeSelectorAndTolerationsOfClusters([]lvmv1alpha1.LVMCluster{
+		clusterWithSelector("disk-a", toleration),
+		clusterWithSelector("disk-b", toleration),
+	})
+	assert.Len(t, nodeSelector.NodeSelectorTerms, 2, "node selector should be the union of both clusters")
+	assert.Equal(t, "disk-a", nodeSelector.NodeSelectorTerms[0].MatchExpressions[0].Key)
+	assert.Equal(t, "disk-b", nodeSelector.NodeSelectorTerms[1].MatchExpressions[0].Key)
+	assert.Equal(t, []corev1.Toleration{toleration}, tolerations, "tolerations should not be duplicated")
+
+	unrestricted := clusterWithSelector("disk-c")
+	unrestricted.Spec.Storage.DeviceClasses[0].NodeSelector = nil
+	nodeSelector, _ = ExtractNodeSelectorAndTolerationsOfClusters([]lvmv1alpha1.LVMCluster{
+		clusterWithSelector("disk-a"),
+		unrestricted,
+	})
+	assert.Nil(t, nodeSelector, "a cluster without node selector should select all nodes")
+}
+
+func TestValidNodesOfClusters(t *testing.T) {
+	clusterWithSelector := func(key string) lvmv1alpha1.LVMCluster {
+		return lvmv1alpha1.LVMCluster{
+			Spec: lvmv1alpha1.LVMClusterSpec{
+				Storage: lvmv1alpha1.Storage{
+					DeviceClasses: []lvmv1alpha1.DeviceClass{{
+						NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: corev1.NodeSelectorOpExists}},
+						}}},
+					}},
+				},
+			},
+		}
+	}
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"disk-a": "", "disk-b": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"disk-b": ""}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+	}}
+
+	validNodes, err := ValidNodesOfClusters([]lvmv1alpha1.LVMCluster{
+		clusterWithSelector("disk-a"),
+		clusterWithSelector("disk-b"),
+	}, nodes)
+	assert.NoError(t, err)
+	var names []string
+	for _, node := range validNodes {
+		names = append(names, node.Name)
+	}
+	assert.Equal(t, []string{"node-a", "node-b"}, names)
+}
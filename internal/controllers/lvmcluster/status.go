@@ -3,13 +3,21 @@ package lvmcluster
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/nodestatus"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/selector"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helper "k8s.io/component-helpers/scheduling/corev1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -36,8 +44,29 @@ const (
 	ReasonVGsReady  = "VGsReady"
 	MessageVGsReady = "All the VGs are ready"
 
+	ReasonVGsStale  = "VGsStale"
+	MessageVGsStale = "vg-manager did not report the VGs for more than %v on nodes: %v"
+
+	MessageVGStatusStale = "vg-manager did not report the VG for more than %v"
+
 	ReasonVGsUnmanaged  = "VGsUnmanaged"
 	MessageVGsUnmanaged = "VGs are unmanaged and not part of the LVMCluster, but the manager is running"
+
+	ReasonVGManagerRolloutComplete  = "VGManagerRolloutComplete"
+	MessageVGManagerRolloutComplete = "vg-manager is up to date and healthy on all nodes"
+
+	ReasonVGManagerRolloutInProgress = "VGManagerRolloutInProgress"
+
+	ReasonVGManagerRolloutDegraded = "VGManagerRolloutDegraded"
+
+	ReasonResourcesInSync  = "ResourcesInSync"
+	MessageResourcesInSync = "Resources match their desired state"
+
+	ReasonResourceDriftCorrected  = "ResourceDriftCorrected"
+	MessageResourceDriftCorrected = "Resources drifted from their desired state and were overwritten: %s"
+
+	ReasonResourceDriftDetected  = "ResourceDriftDetected"
+	MessageResourceDriftDetected = "Resources drifted from their desired state and are left in place: %s"
 )
 
 func setResourcesAvailableConditionTrue(instance *lvmv1alpha1.LVMCluster) {
@@ -67,6 +96,60 @@ func setResourcesAvailableConditionInProgress(instance *lvmv1alpha1.LVMCluster)
 	})
 }
 
+func setVGManagerRolloutConditionComplete(instance *lvmv1alpha1.LVMCluster) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.VGManagerRollout,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonVGManagerRolloutComplete,
+		Message: MessageVGManagerRolloutComplete,
+	})
+}
+
+func setVGManagerRolloutConditionInProgress(instance *lvmv1alpha1.LVMCluster, rolloutErr error) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.VGManagerRollout,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonVGManagerRolloutInProgress,
+		Message: rolloutErr.Error(),
+	})
+}
+
+func setVGManagerRolloutConditionDegraded(instance *lvmv1alpha1.LVMCluster, rolloutErr error) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.VGManagerRollout,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonVGManagerRolloutDegraded,
+		Message: rolloutErr.Error(),
+	})
+}
+
+func setResourcesInSyncConditionTrue(instance *lvmv1alpha1.LVMCluster) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.ResourcesInSync,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonResourcesInSync,
+		Message: MessageResourcesInSync,
+	})
+}
+
+func setResourcesInSyncConditionDriftCorrected(instance *lvmv1alpha1.LVMCluster, drifts []string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.ResourcesInSync,
+		Status:  metav1.ConditionTrue,
+		Reason:  ReasonResourceDriftCorrected,
+		Message: fmt.Sprintf(MessageResourceDriftCorrected, strings.Join(drifts, "; ")),
+	})
+}
+
+func setResourcesInSyncConditionDriftDetected(instance *lvmv1alpha1.LVMCluster, drifts []string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.ResourcesInSync,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonResourceDriftDetected,
+		Message: fmt.Sprintf(MessageResourceDriftDetected, strings.Join(drifts, "; ")),
+	})
+}
+
 func setVolumeGroupsReadyConditionTrue(instance *lvmv1alpha1.LVMCluster) {
 	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 		Type:    lvmv1alpha1.VolumeGroupsReady,
@@ -94,6 +177,15 @@ func setVolumeGroupsReadyConditionDegraded(instance *lvmv1alpha1.LVMCluster) {
 	})
 }
 
+func setVolumeGroupsReadyConditionStale(instance *lvmv1alpha1.LVMCluster, staleAfter time.Duration, staleNodes []string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    lvmv1alpha1.VolumeGroupsReady,
+		Status:  metav1.ConditionUnknown,
+		Reason:  ReasonVGsStale,
+		Message: fmt.Sprintf(MessageVGsStale, staleAfter, strings.Join(staleNodes, ", ")),
+	})
+}
+
 func setVolumeGroupsReadyConditionInProgress(instance *lvmv1alpha1.LVMCluster) {
 	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 		Type:    lvmv1alpha1.VolumeGroupsReady,
@@ -112,31 +204,68 @@ func setVolumeGroupsReadyConditionUnmanaged(instance *lvmv1alpha1.LVMCluster) {
 	})
 }
 
-func computeDeviceClassStatuses(vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList) []lvmv1alpha1.DeviceClassStatus {
+// computeDeviceClassStatuses collects the status of the device classes from the LVMVolumeGroupNodeStatus of all nodes.
+// The status of the VGs on stale nodes is reported as Unknown, as it cannot be trusted anymore.
+func computeDeviceClassStatuses(
+	vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+	staleAfter time.Duration,
+	staleNodes []string,
+) []lvmv1alpha1.DeviceClassStatus {
+	stale := sets.New(staleNodes...)
 	vgNodeMap := make(map[string][]lvmv1alpha1.NodeStatus)
 	for _, nodeItem := range vgNodeStatusList.Items {
 		for _, item := range nodeItem.Spec.LVMVGStatus {
+			vgStatus := *item.DeepCopy()
+			if stale.Has(nodeItem.Name) {
+				vgStatus.Status = lvmv1alpha1.VGStatusUnknown
+				vgStatus.Reason = fmt.Sprintf(MessageVGStatusStale, staleAfter)
+			}
 			vgNodeMap[item.Name] = append(vgNodeMap[item.Name],
 				lvmv1alpha1.NodeStatus{
 					Node:     nodeItem.Name,
-					VGStatus: *item.DeepCopy(),
+					VGStatus: vgStatus,
 				},
 			)
 		}
 	}
 	var allVgStatuses []lvmv1alpha1.DeviceClassStatus
 	for key, val := range vgNodeMap {
-		allVgStatuses = append(allVgStatuses,
-			lvmv1alpha1.DeviceClassStatus{
-				Name:       key,
-				NodeStatus: val,
-			},
-		)
+		deviceClassStatus := lvmv1alpha1.DeviceClassStatus{
+			Name:       key,
+			NodeStatus: val,
+		}
+		aggregateCapacity(&deviceClassStatus)
+		allVgStatuses = append(allVgStatuses, deviceClassStatus)
 	}
 
 	return allVgStatuses
 }
 
+// aggregateCapacity sums up the capacity reported by the nodes of the deviceclass.
+// Totals stay unset if none of the nodes reported the corresponding value.
+func aggregateCapacity(status *lvmv1alpha1.DeviceClassStatus) {
+	for _, node := range status.NodeStatus {
+		nodestatus.AddQuantity(&status.Size, node.Size)
+		nodestatus.AddQuantity(&status.Free, node.Free)
+		if node.ThinPool != nil {
+			nodestatus.AddQuantity(&status.ThinPoolSize, node.ThinPool.Size)
+			nodestatus.AddQuantity(&status.ThinPoolUsed, thinPoolUsed(node.ThinPool))
+		}
+	}
+}
+
+// thinPoolUsed calculates the data space in use in the thin pool from its size and data percentage.
+func thinPoolUsed(thinPool *lvmv1alpha1.ThinPoolStatus) *resource.Quantity {
+	if thinPool.Size == nil {
+		return nil
+	}
+	dataPercent, err := strconv.ParseFloat(thinPool.DataPercent, 64)
+	if err != nil {
+		return nil
+	}
+	return resource.NewQuantity(int64(float64(thinPool.Size.Value())*dataPercent/100), thinPool.Size.Format)
+}
+
 func computeLVMClusterReadiness(conditions []metav1.Condition) (lvmv1alpha1.LVMStateType, bool) {
 	state := lvmv1alpha1.LVMStatusUnknown
 	for _, c := range conditions {
@@ -152,15 +281,17 @@ func translateReasonToState(reason string, currentState lvmv1alpha1.LVMStateType
 	switch reason {
 	case ReasonVGsFailed:
 		return lvmv1alpha1.LVMStatusFailed
-	case ReasonVGsDegraded:
+	case ReasonVGsDegraded, ReasonVGsStale, ReasonVGManagerRolloutDegraded:
 		if currentState != lvmv1alpha1.LVMStatusFailed {
 			return lvmv1alpha1.LVMStatusDegraded
 		}
-	case ReasonReconciliationInProgress, ReasonVGReadinessInProgress, ReasonResourcesIncomplete:
+	case ReasonReconciliationInProgress, ReasonVGReadinessInProgress, ReasonResourcesIncomplete, ReasonVGManagerRolloutInProgress:
 		if currentState != lvmv1alpha1.LVMStatusFailed && currentState != lvmv1alpha1.LVMStatusDegraded {
 			return lvmv1alpha1.LVMStatusProgressing
 		}
-	case ReasonResourcesAvailable, ReasonVGsReady, ReasonVGsUnmanaged:
+	// drift that is only reported is left in place on purpose and does not affect the readiness
+	case ReasonResourcesAvailable, ReasonVGsReady, ReasonVGsUnmanaged, ReasonVGManagerRolloutComplete,
+		ReasonResourcesInSync, ReasonResourceDriftCorrected, ReasonResourceDriftDetected:
 		transitionToReadyAcceptable := true
 		// if at least one other state was signalling Failed, Degraded or Progressing State,
 		// we should not transition to Ready State. only if all other states are acceptable
@@ -184,20 +315,40 @@ func translateReasonToState(reason string, currentState lvmv1alpha1.LVMStateType
 	return currentState
 }
 
-func setVolumeGroupsReadyCondition(ctx context.Context, instance *lvmv1alpha1.LVMCluster, nodes *corev1.NodeList, vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList) {
+// setVolumeGroupsReadyCondition rolls up the status of the volume groups on all nodes into the VolumeGroupsReady condition.
+// The status of a volume group on a node is only taken into account once vgmanager reported conditions
+// for the current generation of the LVMVolumeGroup, until then the volume group is considered in progress.
+// The status reported by stale nodes is not taken into account, instead the condition is set to Unknown.
+func setVolumeGroupsReadyCondition(
+	ctx context.Context,
+	instance *lvmv1alpha1.LVMCluster,
+	nodes *corev1.NodeList,
+	volumeGroups *lvmv1alpha1.LVMVolumeGroupList,
+	vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+	staleAfter time.Duration,
+	staleNodes []string,
+) {
 	logger := log.FromContext(ctx)
 
-	err := validateDeviceClassSetup(instance, nodes, vgNodeStatusList)
-	if err == nil {
+	generations := volumeGroupGenerations(volumeGroups)
+	err := validateDeviceClassSetup(instance, nodes, generations, vgNodeStatusList)
+	if err == nil && len(staleNodes) == 0 {
 		setVolumeGroupsReadyConditionTrue(instance)
 		return
-	} else {
+	} else if err != nil {
 		logger.Error(err, "failed to validate device class setup")
 	}
 
+	stale := sets.New(staleNodes...)
 	degraded := false
 	for _, nodeItem := range vgNodeStatusList.Items {
+		if stale.Has(nodeItem.Name) {
+			continue
+		}
 		for _, vgStatus := range nodeItem.Spec.LVMVGStatus {
+			if nodestatus.ConditionsOutdated(nodestatus.VolumeGroupConditions(&nodeItem, vgStatus.Name), generations[vgStatus.Name]) {
+				continue
+			}
 			switch vgStatus.Status {
 			case lvmv1alpha1.VGStatusFailed:
 				setVolumeGroupsReadyConditionFailed(instance)
@@ -208,12 +359,110 @@ func setVolumeGroupsReadyCondition(ctx context.Context, instance *lvmv1alpha1.LV
 		}
 	}
 
-	if degraded {
+	if len(staleNodes) > 0 {
+		setVolumeGroupsReadyConditionStale(instance, staleAfter, staleNodes)
+	} else if degraded {
 		setVolumeGroupsReadyConditionDegraded(instance)
 	}
 }
 
-func validateDeviceClassSetup(cluster *lvmv1alpha1.LVMCluster, nodes *corev1.NodeList, nodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList) error {
+// volumeGroupsBecameStale checks if the VolumeGroupsReady condition transitioned to stale, or if other nodes became
+// stale, compared to the condition that was reported before the reconciliation.
+func volumeGroupsBecameStale(reported, current *metav1.Condition) bool {
+	if current == nil || current.Reason != ReasonVGsStale {
+		return false
+	}
+	return reported == nil || reported.Reason != ReasonVGsStale || reported.Message != current.Message
+}
+
+// resourcesInSyncChanged returns true if the current ResourcesInSync condition differs from the reported one,
+// so that drift that is left in place is only reported once.
+func resourcesInSyncChanged(reported, current *metav1.Condition) bool {
+	if current == nil {
+		return false
+	}
+	return reported == nil || reported.Reason != current.Reason || reported.Message != current.Message
+}
+
+// getStaleNodes returns the sorted names of the nodes on which vgmanager did not refresh the heartbeat
+// of any of its volume groups for longer than staleAfter. Volume groups without a heartbeat were reported by
+// vgmanager versions that do not support it and are never considered stale. A staleAfter of zero disables the check.
+func getStaleNodes(vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList, now time.Time, staleAfter time.Duration) []string {
+	if staleAfter <= 0 {
+		return nil
+	}
+	var staleNodes []string
+	for _, nodeItem := range vgNodeStatusList.Items {
+		for _, vg := range nodeItem.Status.VolumeGroups {
+			if vg.LastReconcileTime != nil && now.Sub(vg.LastReconcileTime.Time) > staleAfter {
+				staleNodes = append(staleNodes, nodeItem.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(staleNodes)
+	return staleNodes
+}
+
+// nodeStatusesOfCluster returns the node statuses limited to the volume groups of the device classes of the LVMCluster,
+// as vgmanager reports the volume groups of all LVMClusters in the same node status.
+func nodeStatusesOfCluster(
+	vgNodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+	instance *lvmv1alpha1.LVMCluster,
+) *lvmv1alpha1.LVMVolumeGroupNodeStatusList {
+	deviceClasses := deviceClassNames(instance)
+	filtered := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{}
+	for _, nodeItem := range vgNodeStatusList.Items {
+		nodeItem := *nodeItem.DeepCopy()
+		nodeItem.Spec.LVMVGStatus = slices.DeleteFunc(nodeItem.Spec.LVMVGStatus, func(vg lvmv1alpha1.VGStatus) bool {
+			return !deviceClasses.Has(vg.Name)
+		})
+		nodeItem.Status.VolumeGroups = slices.DeleteFunc(nodeItem.Status.VolumeGroups, func(vg lvmv1alpha1.VolumeGroupConditions) bool {
+			return !deviceClasses.Has(vg.Name)
+		})
+		filtered.Items = append(filtered.Items, nodeItem)
+	}
+	return filtered
+}
+
+// volumeGroupsOfCluster returns the LVMVolumeGroups of the device classes of the LVMCluster.
+func volumeGroupsOfCluster(volumeGroups *lvmv1alpha1.LVMVolumeGroupList, instance *lvmv1alpha1.LVMCluster) *lvmv1alpha1.LVMVolumeGroupList {
+	deviceClasses := deviceClassNames(instance)
+	filtered := &lvmv1alpha1.LVMVolumeGroupList{}
+	for _, vg := range volumeGroups.Items {
+		if deviceClasses.Has(vg.Name) {
+			filtered.Items = append(filtered.Items, vg)
+		}
+	}
+	return filtered
+}
+
+func deviceClassNames(instance *lvmv1alpha1.LVMCluster) sets.Set[string] {
+	names := sets.New[string]()
+	for _, deviceClass := range instance.Spec.Storage.DeviceClasses {
+		names.Insert(deviceClass.Name)
+	}
+	return names
+}
+
+// volumeGroupGenerations returns the generations of the LVMVolumeGroups by name.
+func volumeGroupGenerations(volumeGroups *lvmv1alpha1.LVMVolumeGroupList) map[string]int64 {
+	generations := make(map[string]int64)
+	if volumeGroups == nil {
+		return generations
+	}
+	for _, vg := range volumeGroups.Items {
+		generations[vg.Name] = vg.Generation
+	}
+	return generations
+}
+
+func validateDeviceClassSetup(
+	cluster *lvmv1alpha1.LVMCluster,
+	nodes *corev1.NodeList,
+	generations map[string]int64,
+	nodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+) error {
 	for _, deviceClass := range cluster.Spec.Storage.DeviceClasses {
 		validNodeExists := false
 		for _, node := range nodes.Items {
@@ -265,6 +514,19 @@ func validateDeviceClassSetup(cluster *lvmv1alpha1.LVMCluster, nodes *corev1.Nod
 					"that is part of the expected nodes for device class %s",
 					deviceClass.Name, relatedVGStatus.Status, node.Name, deviceClass.Name)
 			}
+
+			// Older vgmanager versions do not report conditions, in which case the VGStatus is used on its own.
+			conditions := nodestatus.VolumeGroupConditions(relatedNodeStatus, deviceClass.Name)
+			if nodestatus.ConditionsOutdated(conditions, generations[deviceClass.Name]) {
+				return fmt.Errorf("VG %s on node %s was not yet reconciled for generation %d",
+					deviceClass.Name, node.Name, generations[deviceClass.Name])
+			}
+			for _, condition := range conditions {
+				if condition.Status != metav1.ConditionTrue {
+					return fmt.Errorf("VG %s on node %s has condition %s in state %s: %s",
+						deviceClass.Name, node.Name, condition.Type, condition.Status, condition.Message)
+				}
+			}
 		}
 		if !validNodeExists {
 			return fmt.Errorf("no valid node found for device class %s",
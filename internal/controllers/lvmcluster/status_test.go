@@ -2,13 +2,19 @@ package lvmcluster
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var (
@@ -203,7 +209,9 @@ func TestSetVolumeGroupsReadyCondition(t *testing.T) {
 		desc              string
 		deviceClasses     []lvmv1alpha1.DeviceClass
 		nodes             *corev1.NodeList
+		volumeGroups      *lvmv1alpha1.LVMVolumeGroupList
 		vgNodeStatusList  *lvmv1alpha1.LVMVolumeGroupNodeStatusList
+		staleNodes        []string
 		expectedCondition metav1.Condition
 	}{
 		{
@@ -551,6 +559,95 @@ func TestSetVolumeGroupsReadyCondition(t *testing.T) {
 			vgNodeStatusList:  &lvmv1alpha1.LVMVolumeGroupNodeStatusList{},
 			expectedCondition: vgProgressingCondition,
 		},
+		{
+			desc:          "ready vg with conditions for the current generation should return ready condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:      volumeGroupList("vg1", 2),
+			vgNodeStatusList:  nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusReady, 2, metav1.ConditionTrue),
+			expectedCondition: vgReadyCondition,
+		},
+		{
+			desc:          "ready vg with conditions for an older generation should return progressing condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:      volumeGroupList("vg1", 3),
+			vgNodeStatusList:  nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusReady, 2, metav1.ConditionTrue),
+			expectedCondition: vgProgressingCondition,
+		},
+		{
+			desc:          "failed vg with conditions for the current generation should return failed condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:      volumeGroupList("vg1", 2),
+			vgNodeStatusList:  nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusFailed, 2, metav1.ConditionFalse),
+			expectedCondition: vgFailedCondition,
+		},
+		{
+			desc:          "failed vg with conditions for an older generation should return progressing condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:      volumeGroupList("vg1", 3),
+			vgNodeStatusList:  nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusFailed, 2, metav1.ConditionFalse),
+			expectedCondition: vgProgressingCondition,
+		},
+		{
+			desc:          "failed vg with later steps of an older generation should return failed condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups: volumeGroupList("vg1", 2),
+			vgNodeStatusList: func() *lvmv1alpha1.LVMVolumeGroupNodeStatusList {
+				list := nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusFailed, 2, metav1.ConditionFalse)
+				vg := &list.Items[0].Status.VolumeGroups[0]
+				vg.Conditions = append(vg.Conditions, metav1.Condition{
+					Type: lvmv1alpha1.LVMDConfigApplied, Status: metav1.ConditionTrue, ObservedGeneration: 1,
+				})
+				return list
+			}(),
+			expectedCondition: vgFailedCondition,
+		},
+		{
+			desc:          "ready vg on a stale node should return stale condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:     volumeGroupList("vg1", 2),
+			vgNodeStatusList: nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusReady, 2, metav1.ConditionTrue),
+			staleNodes:       []string{"node1"},
+			expectedCondition: metav1.Condition{
+				Type:    lvmv1alpha1.VolumeGroupsReady,
+				Status:  metav1.ConditionUnknown,
+				Reason:  ReasonVGsStale,
+				Message: fmt.Sprintf(MessageVGsStale, 15*time.Minute, "node1"),
+			},
+		},
+		{
+			desc:          "failed vg on a stale node should return stale condition",
+			deviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}},
+			nodes: &corev1.NodeList{
+				Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			},
+			volumeGroups:     volumeGroupList("vg1", 2),
+			vgNodeStatusList: nodeStatusListWithConditions("vg1", lvmv1alpha1.VGStatusFailed, 2, metav1.ConditionFalse),
+			staleNodes:       []string{"node1"},
+			expectedCondition: metav1.Condition{
+				Type:    lvmv1alpha1.VolumeGroupsReady,
+				Status:  metav1.ConditionUnknown,
+				Reason:  ReasonVGsStale,
+				Message: fmt.Sprintf(MessageVGsStale, 15*time.Minute, "node1"),
+			},
+		},
 	}
 	for _, testCase := range testTable {
 		t.Run(testCase.desc, func(t *testing.T) {
@@ -563,7 +660,8 @@ func TestSetVolumeGroupsReadyCondition(t *testing.T) {
 			}
 
 			setVolumeGroupsReadyConditionInProgress(cluster)
-			setVolumeGroupsReadyCondition(context.TODO(), cluster, testCase.nodes, testCase.vgNodeStatusList)
+			setVolumeGroupsReadyCondition(context.TODO(), cluster, testCase.nodes, testCase.volumeGroups, testCase.vgNodeStatusList,
+				15*time.Minute, testCase.staleNodes)
 			exists := false
 			for _, cond := range cluster.Status.Conditions {
 				if cond.Type == testCase.expectedCondition.Type {
@@ -578,6 +676,38 @@ func TestSetVolumeGroupsReadyCondition(t *testing.T) {
 	}
 }
 
+func volumeGroupList(name string, generation int64) *lvmv1alpha1.LVMVolumeGroupList {
+	return &lvmv1alpha1.LVMVolumeGroupList{
+		Items: []lvmv1alpha1.LVMVolumeGroup{
+			{ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation}},
+		},
+	}
+}
+
+func nodeStatusListWithConditions(name string, status lvmv1alpha1.VGStatusType, generation int64, conditionStatus metav1.ConditionStatus) *lvmv1alpha1.LVMVolumeGroupNodeStatusList {
+	return &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{
+					LVMVGStatus: []lvmv1alpha1.VGStatus{{Name: name, Status: status}},
+				},
+				Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+					VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{
+						{
+							Name: name,
+							Conditions: []metav1.Condition{
+								{Type: lvmv1alpha1.DevicesDiscovered, Status: metav1.ConditionTrue, ObservedGeneration: generation},
+								{Type: lvmv1alpha1.VolumeGroupCreated, Status: conditionStatus, ObservedGeneration: generation},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func TestComputeDeviceClassStatuses(t *testing.T) {
 	testTable := []struct {
 		desc                        string
@@ -831,12 +961,206 @@ func TestComputeDeviceClassStatuses(t *testing.T) {
 
 	for _, testCase := range testTable {
 		t.Run(testCase.desc, func(t *testing.T) {
-			deviceClassStatuses := computeDeviceClassStatuses(testCase.vgNodeStatusList)
+			deviceClassStatuses := computeDeviceClassStatuses(testCase.vgNodeStatusList, 0, nil)
 			assert.ElementsMatch(t, testCase.expectedDeviceClassStatuses, deviceClassStatuses)
 		})
 	}
 }
 
+func TestComputeDeviceClassCapacity(t *testing.T) {
+	gib := resource.MustParse("1Gi")
+	vgNodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{
+					{
+						Name:   "vg1",
+						Status: lvmv1alpha1.VGStatusReady,
+						Size:   ptr.To(resource.MustParse("100Gi")),
+						Free:   ptr.To(resource.MustParse("10Gi")),
+						ThinPool: &lvmv1alpha1.ThinPoolStatus{
+							Name:        "thin-pool-1",
+							Size:        ptr.To(resource.MustParse("90Gi")),
+							DataPercent: "50.00",
+						},
+					},
+					{
+						Name:   "vg2",
+						Status: lvmv1alpha1.VGStatusProgressing,
+					},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{
+					{
+						Name:   "vg1",
+						Status: lvmv1alpha1.VGStatusReady,
+						Size:   ptr.To(resource.MustParse("50Gi")),
+						Free:   ptr.To(resource.MustParse("5Gi")),
+						ThinPool: &lvmv1alpha1.ThinPoolStatus{
+							Name:        "thin-pool-1",
+							Size:        ptr.To(resource.MustParse("45Gi")),
+							DataPercent: "",
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	statuses := make(map[string]lvmv1alpha1.DeviceClassStatus)
+	for _, status := range computeDeviceClassStatuses(vgNodeStatusList, 0, nil) {
+		statuses[status.Name] = status
+	}
+
+	vg1 := statuses["vg1"]
+	assert.Equal(t, int64(150)*gib.Value(), vg1.Size.Value())
+	assert.Equal(t, int64(15)*gib.Value(), vg1.Free.Value())
+	assert.Equal(t, int64(135)*gib.Value(), vg1.ThinPoolSize.Value())
+	assert.Equal(t, int64(45)*gib.Value(), vg1.ThinPoolUsed.Value(), "thin pools without data usage are not counted")
+
+	vg2 := statuses["vg2"]
+	assert.Nil(t, vg2.Size)
+	assert.Nil(t, vg2.Free)
+	assert.Nil(t, vg2.ThinPoolSize)
+	assert.Nil(t, vg2.ThinPoolUsed)
+}
+
+func TestGetStaleNodes(t *testing.T) {
+	now := time.Now()
+	nodeStatus := func(name string, heartbeats ...*metav1.Time) lvmv1alpha1.LVMVolumeGroupNodeStatus {
+		nodeStatus := lvmv1alpha1.LVMVolumeGroupNodeStatus{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for i, heartbeat := range heartbeats {
+			nodeStatus.Status.VolumeGroups = append(nodeStatus.Status.VolumeGroups, lvmv1alpha1.VolumeGroupConditions{
+				Name:              fmt.Sprintf("vg%d", i),
+				LastReconcileTime: heartbeat,
+			})
+		}
+		return nodeStatus
+	}
+	vgNodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			nodeStatus("node3", &metav1.Time{Time: now.Add(-time.Hour)}),
+			nodeStatus("node2", &metav1.Time{Time: now.Add(-time.Minute)}),
+			nodeStatus("node1", &metav1.Time{Time: now.Add(-time.Minute)}, &metav1.Time{Time: now.Add(-time.Hour)}),
+			nodeStatus("node4", nil),
+		},
+	}
+
+	assert.Equal(t, []string{"node1", "node3"}, getStaleNodes(vgNodeStatusList, now, 15*time.Minute))
+	assert.Empty(t, getStaleNodes(vgNodeStatusList, now, 2*time.Hour))
+	assert.Empty(t, getStaleNodes(vgNodeStatusList, now, 0), "a zero duration must disable the detection")
+}
+
+func TestVolumeGroupsBecameStale(t *testing.T) {
+	stale := func(nodes string) *metav1.Condition {
+		return &metav1.Condition{
+			Type:    lvmv1alpha1.VolumeGroupsReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonVGsStale,
+			Message: fmt.Sprintf(MessageVGsStale, 15*time.Minute, nodes),
+		}
+	}
+	ready := &metav1.Condition{Type: lvmv1alpha1.VolumeGroupsReady, Status: metav1.ConditionTrue, Reason: ReasonVGsReady}
+
+	assert.True(t, volumeGroupsBecameStale(nil, stale("node1")))
+	assert.True(t, volumeGroupsBecameStale(ready, stale("node1")))
+	assert.True(t, volumeGroupsBecameStale(stale("node1"), stale("node1, node2")), "newly stale nodes must be reported")
+	assert.False(t, volumeGroupsBecameStale(stale("node1"), stale("node1")), "unchanged stale nodes must not be reported again")
+	assert.False(t, volumeGroupsBecameStale(stale("node1"), ready))
+	assert.False(t, volumeGroupsBecameStale(nil, nil))
+}
+
+func TestResourcesInSyncChanged(t *testing.T) {
+	detected := func(drifts ...string) *metav1.Condition {
+		return &metav1.Condition{
+			Type:    lvmv1alpha1.ResourcesInSync,
+			Status:  metav1.ConditionFalse,
+			Reason:  ReasonResourceDriftDetected,
+			Message: fmt.Sprintf(MessageResourceDriftDetected, strings.Join(drifts, "; ")),
+		}
+	}
+	inSync := &metav1.Condition{Type: lvmv1alpha1.ResourcesInSync, Status: metav1.ConditionTrue, Reason: ReasonResourcesInSync, Message: MessageResourcesInSync}
+
+	assert.True(t, resourcesInSyncChanged(nil, detected("StorageClass/a")))
+	assert.True(t, resourcesInSyncChanged(inSync, detected("StorageClass/a")))
+	assert.True(t, resourcesInSyncChanged(detected("StorageClass/a"), detected("StorageClass/a", "StorageClass/b")), "new drift must be reported")
+	assert.False(t, resourcesInSyncChanged(detected("StorageClass/a"), detected("StorageClass/a")), "drift left in place must not be reported again")
+	assert.False(t, resourcesInSyncChanged(inSync, inSync))
+	assert.False(t, resourcesInSyncChanged(nil, nil))
+}
+
+func TestNodeStatusesOfCluster(t *testing.T) {
+	vgNodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{
+					LVMVGStatus: []lvmv1alpha1.VGStatus{
+						{Name: "vg1", Status: lvmv1alpha1.VGStatusReady},
+						{Name: "vg2", Status: lvmv1alpha1.VGStatusFailed},
+					},
+				},
+				Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+					VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{{Name: "vg1"}, {Name: "vg2"}},
+				},
+			},
+		},
+	}
+	instance := &lvmv1alpha1.LVMCluster{Spec: lvmv1alpha1.LVMClusterSpec{
+		Storage: lvmv1alpha1.Storage{DeviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}}},
+	}}
+
+	filtered := nodeStatusesOfCluster(vgNodeStatusList, instance)
+	require.Len(t, filtered.Items, 1)
+	assert.Equal(t, []lvmv1alpha1.VGStatus{{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}}, filtered.Items[0].Spec.LVMVGStatus)
+	assert.Equal(t, []lvmv1alpha1.VolumeGroupConditions{{Name: "vg1"}}, filtered.Items[0].Status.VolumeGroups)
+	assert.Len(t, vgNodeStatusList.Items[0].Spec.LVMVGStatus, 2, "the shared node status must not be modified")
+
+	volumeGroups := volumeGroupsOfCluster(&lvmv1alpha1.LVMVolumeGroupList{Items: []lvmv1alpha1.LVMVolumeGroup{
+		{ObjectMeta: metav1.ObjectMeta{Name: "vg1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "vg2"}},
+	}}, instance)
+	require.Len(t, volumeGroups.Items, 1)
+	assert.Equal(t, "vg1", volumeGroups.Items[0].Name)
+
+	statuses := computeDeviceClassStatuses(filtered, 0, nil)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "vg1", statuses[0].Name)
+}
+
+func TestComputeDeviceClassStatusesStaleNodes(t *testing.T) {
+	vgNodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{
+					LVMVGStatus: []lvmv1alpha1.VGStatus{{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+				Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{
+					LVMVGStatus: []lvmv1alpha1.VGStatus{{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}},
+				},
+			},
+		},
+	}
+
+	statuses := computeDeviceClassStatuses(vgNodeStatusList, 15*time.Minute, []string{"node2"})
+	require.Len(t, statuses, 1)
+	assert.ElementsMatch(t, []lvmv1alpha1.NodeStatus{
+		{Node: "node1", VGStatus: lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}},
+		{Node: "node2", VGStatus: lvmv1alpha1.VGStatus{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusUnknown,
+			Reason: fmt.Sprintf(MessageVGStatusStale, 15*time.Minute),
+		}},
+	}, statuses[0].NodeStatus)
+}
+
 func TestComputeReadiness(t *testing.T) {
 	testTable := []struct {
 		desc          string
@@ -918,6 +1242,24 @@ func TestComputeReadiness(t *testing.T) {
 			expectedState: lvmv1alpha1.LVMStatusDegraded,
 			expectedReady: false,
 		},
+		{
+			desc: "resources available, vgs stale",
+			conditions: []metav1.Condition{
+				{
+					Type:    lvmv1alpha1.ResourcesAvailable,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonResourcesAvailable,
+					Message: MessageResourcesAvailable,
+				},
+				{
+					Type:   lvmv1alpha1.VolumeGroupsReady,
+					Status: metav1.ConditionUnknown,
+					Reason: ReasonVGsStale,
+				},
+			},
+			expectedState: lvmv1alpha1.LVMStatusDegraded,
+			expectedReady: false,
+		},
 		{
 			desc: "both failing",
 			conditions: []metav1.Condition{
@@ -1013,6 +1355,55 @@ func TestComputeReadiness(t *testing.T) {
 			expectedState: lvmv1alpha1.LVMStatusProgressing,
 			expectedReady: false,
 		},
+		{
+			desc: "vg-manager rollout halted",
+			conditions: []metav1.Condition{
+				{
+					Type:    lvmv1alpha1.ResourcesAvailable,
+					Status:  metav1.ConditionFalse,
+					Reason:  ReasonResourcesIncomplete,
+					Message: MessageReasonResourcesSyncIncomplete,
+				},
+				{
+					Type:    lvmv1alpha1.VolumeGroupsReady,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonVGsReady,
+					Message: MessageVGsReady,
+				},
+				{
+					Type:   lvmv1alpha1.VGManagerRollout,
+					Status: metav1.ConditionFalse,
+					Reason: ReasonVGManagerRolloutDegraded,
+				},
+			},
+			expectedState: lvmv1alpha1.LVMStatusDegraded,
+			expectedReady: false,
+		},
+		{
+			desc: "vg-manager rollout complete",
+			conditions: []metav1.Condition{
+				{
+					Type:    lvmv1alpha1.ResourcesAvailable,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonResourcesAvailable,
+					Message: MessageResourcesAvailable,
+				},
+				{
+					Type:    lvmv1alpha1.VolumeGroupsReady,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonVGsReady,
+					Message: MessageVGsReady,
+				},
+				{
+					Type:    lvmv1alpha1.VGManagerRollout,
+					Status:  metav1.ConditionTrue,
+					Reason:  ReasonVGManagerRolloutComplete,
+					Message: MessageVGManagerRolloutComplete,
+				},
+			},
+			expectedState: lvmv1alpha1.LVMStatusReady,
+			expectedReady: true,
+		},
 	}
 	for _, testCase := range testTable {
 		t.Run(testCase.desc, func(t *testing.T) {
@@ -19,8 +19,10 @@ package lvmcluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/openshift/lvm-operator/v4/internal/cluster"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/logpassthrough"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/resource"
 	"gotest.tools/v3/assert"
@@ -31,8 +33,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -107,3 +112,234 @@ func TestVGManagerEnsureCreated(t *testing.T) {
 		}
 	}
 }
+
+func TestVGManagerSharedByLVMClusters(t *testing.T) {
+	clusterWithSelector := func(name string, created time.Time, key string) *lvmv1alpha1.LVMCluster {
+		return &lvmv1alpha1.LVMCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         testNamespace,
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.NewTime(created),
+				Finalizers:        []string{lvmClusterFinalizer},
+			},
+			Spec: lvmv1alpha1.LVMClusterSpec{
+				Storage: lvmv1alpha1.Storage{
+					DeviceClasses: []lvmv1alpha1.DeviceClass{{
+						Name: name,
+						NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{Key: key, Operator: corev1.NodeSelectorOpExists}},
+						}}},
+					}},
+				},
+			},
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+	older := clusterWithSelector("older", now.Add(-time.Hour), "disk-a")
+	newer := clusterWithSelector("newer", now, "disk-b")
+	r := newFakeReconciler(t, older, newer)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	unit := resource.VGManager(cluster.TypeOCP)
+
+	// the daemonset covers the nodes of both clusters and is owned by the older one
+	assert.NilError(t, unit.EnsureCreated(r, ctx, newer), "running EnsureCreated")
+	ds := &appsv1.DaemonSet{}
+	key := types.NamespacedName{Name: resource.VGManagerUnit, Namespace: testNamespace}
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Equal(t, len(terms), 2)
+	assert.Equal(t, metav1.GetControllerOf(ds).Name, "older")
+
+	// deleting the older cluster hands the daemonset over instead of removing it
+	assert.NilError(t, r.Delete(ctx, older), "deleting LVMCluster")
+	assert.NilError(t, r.Get(ctx, client.ObjectKeyFromObject(older), older), "fetching LVMCluster")
+	assert.NilError(t, unit.EnsureDeleted(r, ctx, older), "running EnsureDeleted")
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	terms = ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Equal(t, len(terms), 1)
+	assert.Equal(t, terms[0].MatchExpressions[0].Key, "disk-b")
+	assert.Equal(t, len(ds.OwnerReferences), 1)
+	assert.Equal(t, metav1.GetControllerOf(ds).Name, "newer")
+}
+
+func TestVGManagerKeepsNodesWithRetainedVolumeGroups(t *testing.T) {
+	lvmCluster := &lvmv1alpha1.LVMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "lvmcluster", Namespace: testNamespace, UID: "lvmcluster"},
+		Spec: lvmv1alpha1.LVMClusterSpec{
+			Storage: lvmv1alpha1.Storage{
+				DeviceClasses: []lvmv1alpha1.DeviceClass{{
+					Name: "vg1",
+					NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "disk", Operator: corev1.NodeSelectorOpExists}},
+					}}},
+				}},
+			},
+		},
+	}
+	// the node no longer matches the node selector, but still reports the volume group
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "deselected-node", Namespace: testNamespace},
+		Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusOrphaned,
+		}}},
+	}
+	r := newFakeReconciler(t, lvmCluster, nodeStatus)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	assert.NilError(t, resource.VGManager(cluster.TypeOCP).EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	ds := &appsv1.DaemonSet{}
+	assert.NilError(t, r.Get(ctx, types.NamespacedName{Name: resource.VGManagerUnit, Namespace: testNamespace}, ds), "fetching daemonset")
+	terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.Equal(t, len(terms), 2)
+	assert.DeepEqual(t, terms[1].MatchFields, []corev1.NodeSelectorRequirement{{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"deselected-node"},
+	}})
+	templateHash := ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+
+	// a node that reports its first volume group does not make the vg-manager pods outdated
+	assert.NilError(t, r.Create(ctx, &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-node", Namespace: testNamespace},
+		Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{LVMVGStatus: []lvmv1alpha1.VGStatus{{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusReady,
+		}}},
+	}), "creating LVMVolumeGroupNodeStatus")
+	assert.NilError(t, resource.VGManager(cluster.TypeOCP).EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	assert.NilError(t, r.Get(ctx, types.NamespacedName{Name: resource.VGManagerUnit, Namespace: testNamespace}, ds), "fetching daemonset")
+	terms = ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	assert.DeepEqual(t, terms[1].MatchFields[0].Values, []string{"deselected-node", "new-node"})
+	assert.Equal(t, ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation], templateHash)
+}
+
+func TestVGManagerConfiguration(t *testing.T) {
+	lvmCluster := &lvmv1alpha1.LVMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "lvmcluster", Namespace: testNamespace, UID: "lvmcluster"},
+		Spec: lvmv1alpha1.LVMClusterSpec{
+			Storage: lvmv1alpha1.Storage{DeviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}}},
+		},
+	}
+	r := newFakeReconciler(t, lvmCluster)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	unit := resource.VGManager(cluster.TypeOCP)
+	key := types.NamespacedName{Name: resource.VGManagerUnit, Namespace: testNamespace}
+
+	// without a configuration, the defaults apply
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	ds := &appsv1.DaemonSet{}
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	container := ds.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, container.Resources.Requests.Memory().String(), "45Mi")
+	assert.Equal(t, len(container.Resources.Limits), 0)
+	assert.Equal(t, envValue(container.Env, "GOMEMLIMIT"), "47185920")
+	assert.Equal(t, ds.Spec.Template.Spec.PriorityClassName, "openshift-user-critical")
+	assert.Equal(t, ds.Spec.UpdateStrategy.Type, appsv1.OnDeleteDaemonSetStrategyType)
+
+	// the configuration is applied to the existing daemonset
+	lvmCluster.Spec.VGManager = &lvmv1alpha1.VGManagerConfig{
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("256Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: k8sresource.MustParse("1Gi")},
+		},
+		PriorityClassName: "system-node-critical",
+		UpdateStrategy:    &lvmv1alpha1.VGManagerUpdateStrategy{MaxUnavailable: ptr.To(intstr.FromString("25%"))},
+		NodeSelector:      map[string]string{"node-role.kubernetes.io/worker": ""},
+		LogLevel:          ptr.To[int32](4),
+		Env: []corev1.EnvVar{
+			{Name: "GOMAXPROCS", Value: "4"},
+			{Name: "NODE_NAME", Value: "ignored"},
+			{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+		},
+	}
+	assert.NilError(t, r.Update(ctx, lvmCluster), "updating LVMCluster")
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	container = ds.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, container.Resources.Requests.Cpu().String(), "5m")
+	assert.Equal(t, container.Resources.Requests.Memory().String(), "256Mi")
+	assert.Equal(t, container.Resources.Limits.Memory().String(), "1Gi")
+	assert.Equal(t, envValue(container.Env, "GOMEMLIMIT"), "966367638", "90% of the memory limit")
+	assert.Equal(t, envValue(container.Env, "GOMAXPROCS"), "4")
+	assert.Equal(t, envValue(container.Env, "HTTP_PROXY"), "http://proxy:3128")
+	assert.Assert(t, envValue(container.Env, "NODE_NAME") == "", "NODE_NAME must be taken from the pod")
+	assert.DeepEqual(t, container.Command[len(container.Command)-2:], []string{"--zap-log-level=4", "--v=4"})
+	assert.Equal(t, ds.Spec.Template.Spec.PriorityClassName, "system-node-critical")
+	assert.DeepEqual(t, ds.Spec.Template.Spec.NodeSelector, map[string]string{"node-role.kubernetes.io/worker": ""})
+}
+
+func TestVGManagerDrift(t *testing.T) {
+	lvmCluster := &lvmv1alpha1.LVMCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "lvmcluster", Namespace: testNamespace, UID: "lvmcluster"},
+		Spec: lvmv1alpha1.LVMClusterSpec{
+			Storage:       lvmv1alpha1.Storage{DeviceClasses: []lvmv1alpha1.DeviceClass{{Name: "vg1"}}},
+			DriftPolicies: &lvmv1alpha1.DriftPolicies{DaemonSet: lvmv1alpha1.DriftPolicyReport},
+		},
+	}
+	r := newFakeReconciler(t, lvmCluster)
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	unit := resource.VGManager(cluster.TypeOCP)
+	detector := unit.(resource.DriftDetector)
+	key := types.NamespacedName{Name: resource.VGManagerUnit, Namespace: testNamespace}
+
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	drifts, err := detector.DetectDrift(r, ctx, lvmCluster)
+	assert.NilError(t, err, "detecting drift")
+	assert.Equal(t, len(drifts), 0, "the daemonset did not drift after it was applied")
+
+	// changes made by others are reported and left in place
+	ds := &appsv1.DaemonSet{}
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	appliedTemplateHash := ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+	ds.Spec.Template.Spec.PriorityClassName = "changed"
+	assert.NilError(t, r.Update(ctx, ds), "updating daemonset")
+	drifts, err = detector.DetectDrift(r, ctx, lvmCluster)
+	assert.NilError(t, err, "detecting drift")
+	assert.Equal(t, len(drifts), 1)
+	assert.DeepEqual(t, drifts[0].Fields, []string{"spec.template.spec.priorityClassName"})
+	assert.Equal(t, drifts[0].Policy, lvmv1alpha1.DriftPolicyReport)
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	assert.Equal(t, ds.Spec.Template.Spec.PriorityClassName, "changed")
+	// the changed template is rolled out to the pods
+	changedTemplateHash := ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+	assert.Assert(t, changedTemplateHash != appliedTemplateHash)
+	drifts, err = detector.DetectDrift(r, ctx, lvmCluster)
+	assert.NilError(t, err, "detecting drift")
+	assert.Equal(t, len(drifts), 1)
+	assert.DeepEqual(t, drifts[0].Fields, []string{"spec.template.spec.priorityClassName"})
+
+	// changes of the LVMCluster cannot be told apart from the drift, so they are reported as well
+	lvmCluster.Spec.VGManager = &lvmv1alpha1.VGManagerConfig{PriorityClassName: "system-node-critical"}
+	assert.NilError(t, r.Update(ctx, lvmCluster), "updating LVMCluster")
+	drifts, err = detector.DetectDrift(r, ctx, lvmCluster)
+	assert.NilError(t, err, "detecting drift")
+	assert.Equal(t, len(drifts), 1)
+	assert.DeepEqual(t, drifts[0].Fields, []string{"spec.template.spec.priorityClassName"})
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	assert.Equal(t, ds.Spec.Template.Spec.PriorityClassName, "changed")
+
+	// once the drift is reverted, the changes of the LVMCluster are applied
+	ds.Spec.Template.Spec.PriorityClassName = "system-node-critical"
+	assert.NilError(t, r.Update(ctx, ds), "updating daemonset")
+	drifts, err = detector.DetectDrift(r, ctx, lvmCluster)
+	assert.NilError(t, err, "detecting drift")
+	assert.Equal(t, len(drifts), 0)
+	assert.NilError(t, unit.EnsureCreated(r, ctx, lvmCluster), "running EnsureCreated")
+	assert.NilError(t, r.Get(ctx, key, ds), "fetching daemonset")
+	templateHash := ds.Spec.Template.Annotations[constants.VGManagerTemplateHashAnnotation]
+	assert.Assert(t, templateHash != appliedTemplateHash && templateHash != changedTemplateHash,
+		"the hash of the desired template is applied")
+}
+
+func envValue(env []corev1.EnvVar, name string) string {
+	for _, variable := range env {
+		if variable.Name == name {
+			return variable.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lvmvolumegroup
+
+import (
+	"context"
+	"fmt"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/selector"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	client.Client
+	Namespace string
+}
+
+func NewReconciler(client client.Client, namespace string) *Reconciler {
+	return &Reconciler{
+		Client:    client,
+		Namespace: namespace,
+	}
+}
+
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lvm.topolvm.io,resources=lvmclusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lvm.topolvm.io,resources=lvmvolumegroups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lvm.topolvm.io,resources=lvmvolumegroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=lvm.topolvm.io,resources=lvmvolumegroupnodestatuses,verbs=get;list;watch
+
+// Reconcile aggregates the status of a LVMVolumeGroup from the LVMVolumeGroupNodeStatus of the nodes it targets.
+// It does not depend on a LVMCluster, so that the status is also available for volume groups that are not
+// managed through a LVMCluster.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	volumeGroup := &lvmv1alpha1.LVMVolumeGroup{}
+	if err := r.Get(ctx, req.NamespacedName, volumeGroup); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	nodes, err := r.targetNodes(ctx, volumeGroup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	nodeStatusList := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{}
+	if err := r.List(ctx, nodeStatusList, client.InNamespace(volumeGroup.GetNamespace())); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list LVMVolumeGroupNodeStatus: %w", err)
+	}
+
+	original := volumeGroup.DeepCopy()
+	volumeGroup.Status = computeStatus(volumeGroup, nodes, nodeStatusList)
+	if equality.Semantic.DeepEqual(original.Status, volumeGroup.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Status().Patch(ctx, volumeGroup, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update LVMVolumeGroup status: %w", err)
+	}
+	logger.V(2).Info("successfully updated the LVMVolumeGroup status",
+		"ready", volumeGroup.Status.ReadyNodes, "nodes", len(volumeGroup.Status.Nodes))
+
+	return ctrl.Result{}, nil
+}
+
+// targetNodes returns the nodes the volume group is set up on. These are the nodes that match the node selector
+// of the volume group and whose taints are tolerated by the LVMCluster owning the volume group, if there is one.
+func (r *Reconciler) targetNodes(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup) ([]corev1.Node, error) {
+	var tolerations []corev1.Toleration
+	if owner := metav1.GetControllerOf(volumeGroup); owner != nil && owner.Kind == "LVMCluster" {
+		cluster := &lvmv1alpha1.LVMCluster{}
+		err := r.Get(ctx, types.NamespacedName{Name: owner.Name, Namespace: volumeGroup.GetNamespace()}, cluster)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to get LVMCluster owning the LVMVolumeGroup: %w", err)
+		}
+		tolerations = cluster.Spec.Tolerations
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list Nodes: %w", err)
+	}
+
+	var nodes []corev1.Node
+	for _, node := range nodeList.Items {
+		if ok, err := selector.ToleratesAllTaints(node.Spec.Taints, tolerations); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		if volumeGroup.Spec.NodeSelector != nil {
+			matches, err := corev1helper.MatchNodeSelectorTerms(&node, volumeGroup.Spec.NodeSelector)
+			if err != nil {
+				return nil, fmt.Errorf("error matching node selector terms: %w", err)
+			}
+			if !matches {
+				continue
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("lvmvolumegroup-status").
+		For(&lvmv1alpha1.LVMVolumeGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}, handler.EnqueueRequestsFromMapFunc(r.getVolumeGroups)).
+		Watches(&lvmv1alpha1.LVMCluster{}, handler.EnqueueRequestsFromMapFunc(r.getVolumeGroups)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.getVolumeGroups),
+			builder.WithPredicates(nodeSchedulingChangedPredicate)).
+		WithOptions(controller.Options{SkipNameValidation: ptr.To(true)}).
+		Complete(r)
+}
+
+// getVolumeGroups returns a reconcile.Request for every LVMVolumeGroup, as any of them can be affected
+// by a change of a node or its LVMVolumeGroupNodeStatus.
+func (r *Reconciler) getVolumeGroups(ctx context.Context, _ client.Object) []reconcile.Request {
+	volumeGroups := &lvmv1alpha1.LVMVolumeGroupList{}
+	if err := r.List(ctx, volumeGroups, client.InNamespace(r.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list LVMVolumeGroups")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(volumeGroups.Items))
+	for _, volumeGroup := range volumeGroups.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&volumeGroup)})
+	}
+	return requests
+}
+
+// nodeSchedulingChangedPredicate filters out node updates that cannot change whether a volume group targets the node,
+// such as the frequent updates of the node status.
+var nodeSchedulingChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldNode.GetLabels(), newNode.GetLabels()) ||
+			!equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+	},
+}
@@ -0,0 +1,191 @@
+package lvmvolumegroup
+
+import (
+	"context"
+	"testing"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	sch := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(sch))
+	require.NoError(t, lvmv1alpha1.AddToScheme(sch))
+	return sch
+}
+
+func nodeStatus(node string, vgStatus lvmv1alpha1.VGStatus, observedGeneration int64) *lvmv1alpha1.LVMVolumeGroupNodeStatus {
+	return &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: node, Namespace: "test"},
+		Spec: lvmv1alpha1.LVMVolumeGroupNodeStatusSpec{
+			LVMVGStatus: []lvmv1alpha1.VGStatus{vgStatus},
+		},
+		Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+			VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{{
+				Name: vgStatus.Name,
+				Conditions: []metav1.Condition{{
+					Type:               lvmv1alpha1.VolumeGroupCreated,
+					Status:             metav1.ConditionTrue,
+					Reason:             lvmv1alpha1.VolumeGroupCreated,
+					ObservedGeneration: observedGeneration,
+				}},
+			}},
+		},
+	}
+}
+
+func TestLVMVolumeGroupController_SetupWithManager(t *testing.T) {
+	sch := newScheme(t)
+	mgr, err := controllerruntime.NewManager(&rest.Config{}, controllerruntime.Options{Scheme: sch})
+	require.NoError(t, err)
+	r := NewReconciler(fake.NewClientBuilder().WithScheme(sch).Build(), "test")
+	assert.NoError(t, r.SetupWithManager(mgr))
+}
+
+func TestLVMVolumeGroupController_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	vg := &lvmv1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "test", Generation: 2},
+		Spec: lvmv1alpha1.LVMVolumeGroupSpec{
+			NodeSelector: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "storage",
+					Operator: corev1.NodeSelectorOpExists,
+				}},
+			}}},
+		},
+	}
+	labels := map[string]string{"storage": "true"}
+
+	objs := []client.Object{
+		vg,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-ready", Labels: labels}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-failed", Labels: labels}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-unreported", Labels: labels}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-outdated", Labels: labels}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-unselected"}},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-tainted", Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}},
+		},
+		nodeStatus("node-ready", lvmv1alpha1.VGStatus{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusReady,
+			Size:   ptr.To(resource.MustParse("10Gi")),
+			Free:   ptr.To(resource.MustParse("4Gi")),
+		}, 2),
+		nodeStatus("node-failed", lvmv1alpha1.VGStatus{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusFailed,
+			Reason: "no available devices",
+		}, 2),
+		nodeStatus("node-outdated", lvmv1alpha1.VGStatus{
+			Name:   "vg1",
+			Status: lvmv1alpha1.VGStatusFailed,
+			Size:   ptr.To(resource.MustParse("5Gi")),
+		}, 1),
+	}
+
+	r := NewReconciler(fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(objs...).
+		WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroup{}).Build(), "test")
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(vg)})
+	require.NoError(t, err)
+
+	updated := &lvmv1alpha1.LVMVolumeGroup{}
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(vg), updated))
+	status := updated.Status
+
+	assert.Equal(t, int64(2), status.ObservedGeneration)
+	assert.Equal(t, []string{"node-failed", "node-outdated", "node-ready", "node-unreported"}, status.Nodes,
+		"unselected and tainted nodes must not be targeted")
+	assert.Equal(t, int32(1), status.ReadyNodes)
+	assert.Equal(t, int32(2), status.ProgressingNodes, "unreported and outdated nodes must be progressing")
+	assert.Equal(t, int32(0), status.DegradedNodes)
+	assert.Equal(t, int32(1), status.FailedNodes)
+	assert.Equal(t, "15Gi", status.Size.String())
+	assert.Equal(t, "4Gi", status.Free.String())
+
+	require.Len(t, status.NodeStatus, 4)
+	assert.Equal(t, lvmv1alpha1.VolumeGroupNodeSummary{
+		Node:   "node-failed",
+		Status: lvmv1alpha1.VGStatusFailed,
+		Reason: "no available devices",
+	}, status.NodeStatus[0])
+	assert.Equal(t, lvmv1alpha1.VGStatusProgressing, status.NodeStatus[1].Status)
+
+	ready := meta.FindStatusCondition(status.Conditions, lvmv1alpha1.VolumeGroupReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, ReasonVolumeGroupFailed, ready.Reason)
+	assert.Contains(t, ready.Message, "node-failed")
+}
+
+func TestComputeStatus_Ready(t *testing.T) {
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Generation: 1}}
+	nodes := []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}}
+	nodeStatuses := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		*nodeStatus("node1", lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}, 1),
+	}}
+
+	status := computeStatus(vg, nodes, nodeStatuses)
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, lvmv1alpha1.VolumeGroupReady))
+	assert.Equal(t, int32(1), status.ReadyNodes)
+
+	status = computeStatus(vg, nil, nodeStatuses)
+	ready := meta.FindStatusCondition(status.Conditions, lvmv1alpha1.VolumeGroupReady)
+	assert.Equal(t, ReasonNoTargetNodes, ready.Reason)
+	assert.Empty(t, status.NodeStatus)
+}
+
+func TestComputeStatus_Orphaned(t *testing.T) {
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Generation: 1}}
+	nodes := []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}}
+	nodeStatuses := &lvmv1alpha1.LVMVolumeGroupNodeStatusList{Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		*nodeStatus("node1", lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusReady}, 1),
+		*nodeStatus("node2", lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusOrphaned}, 1),
+		*nodeStatus("node3", lvmv1alpha1.VGStatus{Name: "vg2", Status: lvmv1alpha1.VGStatusOrphaned}, 1),
+	}}
+
+	status := computeStatus(vg, nodes, nodeStatuses)
+	assert.Equal(t, []string{"node2"}, status.OrphanedNodes)
+	assert.Equal(t, []string{"node1"}, status.Nodes, "orphaned nodes must not be targeted")
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, lvmv1alpha1.VolumeGroupReady),
+		"orphaned nodes must not affect the readiness of the volume group")
+}
+
+func TestComputeStatus_FailedWithOutdatedLaterSteps(t *testing.T) {
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Generation: 2}}
+	nodes := []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}}
+	failed := nodeStatus("node1", lvmv1alpha1.VGStatus{Name: "vg1", Status: lvmv1alpha1.VGStatusFailed, Reason: "failed"}, 2)
+	conditions := &failed.Status.VolumeGroups[0].Conditions
+	(*conditions)[0].Status = metav1.ConditionFalse
+	*conditions = append(*conditions, metav1.Condition{
+		Type:               lvmv1alpha1.LVMDConfigApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             lvmv1alpha1.LVMDConfigApplied,
+		ObservedGeneration: 1,
+	})
+
+	status := computeStatus(vg, nodes, &lvmv1alpha1.LVMVolumeGroupNodeStatusList{
+		Items: []lvmv1alpha1.LVMVolumeGroupNodeStatus{*failed},
+	})
+	assert.Equal(t, int32(1), status.FailedNodes,
+		"steps after the failed one keep their generation and must not mask the failure")
+	ready := meta.FindStatusCondition(status.Conditions, lvmv1alpha1.VolumeGroupReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, ReasonVolumeGroupFailed, ready.Reason)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lvmvolumegroup
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/lvmcluster/nodestatus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReasonVolumeGroupReady       = "VolumeGroupReady"
+	ReasonVolumeGroupProgressing = "VolumeGroupProgressing"
+	ReasonVolumeGroupDegraded    = "VolumeGroupDegraded"
+	ReasonVolumeGroupFailed      = "VolumeGroupFailed"
+	ReasonNoTargetNodes          = "NoTargetNodes"
+
+	MessageVolumeGroupReady = "The volume group is ready on all targeted nodes"
+	MessageNoTargetNodes    = "No node matches the node selector of the volume group"
+
+	MessageNotReported   = "vg-manager did not report the volume group on the node yet"
+	MessageNotReconciled = "vg-manager did not reconcile generation %d of the volume group on the node yet"
+)
+
+// computeStatus aggregates the status of the volume group from the LVMVolumeGroupNodeStatus of the targeted nodes.
+// Statuses of nodes that are not targeted anymore are ignored. The conditions of the current status are carried over
+// so that their transition times are preserved.
+func computeStatus(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	nodes []corev1.Node,
+	nodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+) lvmv1alpha1.LVMVolumeGroupStatus {
+	status := lvmv1alpha1.LVMVolumeGroupStatus{
+		ObservedGeneration: volumeGroup.GetGeneration(),
+		Conditions:         volumeGroup.Status.DeepCopy().Conditions,
+	}
+
+	nodeStatuses := make(map[string]*lvmv1alpha1.LVMVolumeGroupNodeStatus, len(nodeStatusList.Items))
+	for i := range nodeStatusList.Items {
+		nodeStatuses[nodeStatusList.Items[i].GetName()] = &nodeStatusList.Items[i]
+	}
+
+	nodesByStatus := make(map[lvmv1alpha1.VGStatusType][]string)
+	for _, node := range nodes {
+		summary := summarizeNode(volumeGroup, node.GetName(), nodeStatuses[node.GetName()])
+		status.Nodes = append(status.Nodes, summary.Node)
+		status.NodeStatus = append(status.NodeStatus, summary)
+		nodesByStatus[summary.Status] = append(nodesByStatus[summary.Status], summary.Node)
+		nodestatus.AddQuantity(&status.Size, summary.Size)
+		nodestatus.AddQuantity(&status.Free, summary.Free)
+	}
+	sort.Strings(status.Nodes)
+	sort.Slice(status.NodeStatus, func(i, j int) bool {
+		return status.NodeStatus[i].Node < status.NodeStatus[j].Node
+	})
+
+	status.OrphanedNodes = orphanedNodes(volumeGroup, nodes, nodeStatusList)
+
+	status.ReadyNodes = int32(len(nodesByStatus[lvmv1alpha1.VGStatusReady]))
+	status.ProgressingNodes = int32(len(nodesByStatus[lvmv1alpha1.VGStatusProgressing]))
+	status.DegradedNodes = int32(len(nodesByStatus[lvmv1alpha1.VGStatusDegraded]))
+	status.FailedNodes = int32(len(nodesByStatus[lvmv1alpha1.VGStatusFailed]))
+
+	meta.SetStatusCondition(&status.Conditions, readyCondition(volumeGroup, len(nodes), nodesByStatus))
+
+	return status
+}
+
+// orphanedNodes returns the sorted names of the nodes that are not targeted anymore,
+// but on which vg-manager reports the volume group as orphaned.
+func orphanedNodes(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	nodes []corev1.Node,
+	nodeStatusList *lvmv1alpha1.LVMVolumeGroupNodeStatusList,
+) []string {
+	var orphaned []string
+	for _, nodeStatus := range nodeStatusList.Items {
+		if slices.ContainsFunc(nodes, func(node corev1.Node) bool { return node.GetName() == nodeStatus.GetName() }) {
+			continue
+		}
+		if slices.ContainsFunc(nodeStatus.Spec.LVMVGStatus, func(vgStatus lvmv1alpha1.VGStatus) bool {
+			return vgStatus.Name == volumeGroup.GetName() && vgStatus.Status == lvmv1alpha1.VGStatusOrphaned
+		}) {
+			orphaned = append(orphaned, nodeStatus.GetName())
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned
+}
+
+// summarizeNode summarizes the status of the volume group on the node. The volume group is considered progressing
+// as long as vg-manager did not report conditions for the current generation of the volume group, see
+// nodestatus.ConditionsOutdated for the conditions that are taken into account.
+func summarizeNode(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	node string,
+	nodeStatus *lvmv1alpha1.LVMVolumeGroupNodeStatus,
+) lvmv1alpha1.VolumeGroupNodeSummary {
+	summary := lvmv1alpha1.VolumeGroupNodeSummary{
+		Node:   node,
+		Status: lvmv1alpha1.VGStatusProgressing,
+		Reason: MessageNotReported,
+	}
+	if nodeStatus == nil {
+		return summary
+	}
+
+	var vgStatus *lvmv1alpha1.VGStatus
+	for i := range nodeStatus.Spec.LVMVGStatus {
+		if nodeStatus.Spec.LVMVGStatus[i].Name == volumeGroup.GetName() {
+			vgStatus = &nodeStatus.Spec.LVMVGStatus[i]
+			break
+		}
+	}
+	if vgStatus == nil {
+		return summary
+	}
+
+	summary.Size = vgStatus.Size
+	summary.Free = vgStatus.Free
+
+	conditions := nodestatus.VolumeGroupConditions(nodeStatus, volumeGroup.GetName())
+	if nodestatus.ConditionsOutdated(conditions, volumeGroup.GetGeneration()) {
+		summary.Reason = fmt.Sprintf(MessageNotReconciled, volumeGroup.GetGeneration())
+		return summary
+	}
+
+	if vgStatus.Status != "" {
+		summary.Status = vgStatus.Status
+	}
+	summary.Reason = vgStatus.Reason
+	return summary
+}
+
+// readyCondition returns the Ready condition of the volume group. The most severe status across the nodes wins.
+func readyCondition(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	nodeCount int,
+	nodesByStatus map[lvmv1alpha1.VGStatusType][]string,
+) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               lvmv1alpha1.VolumeGroupReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: volumeGroup.GetGeneration(),
+	}
+
+	if nodeCount == 0 {
+		condition.Reason = ReasonNoTargetNodes
+		condition.Message = MessageNoTargetNodes
+		return condition
+	}
+
+	for _, state := range []struct {
+		status lvmv1alpha1.VGStatusType
+		reason string
+	}{
+		{lvmv1alpha1.VGStatusFailed, ReasonVolumeGroupFailed},
+		{lvmv1alpha1.VGStatusDegraded, ReasonVolumeGroupDegraded},
+		{lvmv1alpha1.VGStatusProgressing, ReasonVolumeGroupProgressing},
+	} {
+		if nodes := nodesByStatus[state.status]; len(nodes) > 0 {
+			sort.Strings(nodes)
+			condition.Reason = state.reason
+			condition.Message = fmt.Sprintf("The volume group is %s on %d of %d nodes: %s",
+				strings.ToLower(string(state.status)), len(nodes), nodeCount, strings.Join(nodes, ", "))
+			return condition
+		}
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = ReasonVolumeGroupReady
+	condition.Message = MessageVolumeGroupReady
+	return condition
+}
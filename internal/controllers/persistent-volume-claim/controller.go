@@ -4,8 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math"
-	"sort"
-	"strings"
 	"time"
 
 	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
@@ -13,7 +11,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,23 +23,32 @@ import (
 
 const (
 	CapacityAnnotation = "capacity.topolvm.io/"
+
+	// ProvisioningBlockedAnnotation is set on pending PersistentVolumeClaims that cannot be provisioned
+	// on any node, with the reason and a message explaining why.
+	ProvisioningBlockedAnnotation = "lvms.openshift.io/provisioning-blocked"
 )
 
 // Reconciler reconciles a PersistentVolumeClaim object
 type Reconciler struct {
 	Client   client.Client
 	Recorder events.EventRecorder
+	// Namespace is the namespace of the LVMVolumeGroups of the device classes
+	Namespace string
 }
 
 // NewReconciler returns Reconciler.
-func NewReconciler(client client.Client, eventRecorder events.EventRecorder) *Reconciler {
+func NewReconciler(client client.Client, eventRecorder events.EventRecorder, namespace string) *Reconciler {
 	return &Reconciler{
-		Client:   client,
-		Recorder: eventRecorder,
+		Client:    client,
+		Recorder:  eventRecorder,
+		Namespace: namespace,
 	}
 }
 
-//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=lvm.topolvm.io,resources=lvmvolumegroups,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=node,verbs=get;list;watch
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
 
@@ -88,7 +94,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	// Skip if the PVC is not in Pending state.
 	if pvc.Status.Phase != corev1.ClaimPending {
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.setProvisioningBlocked(ctx, pvc, "")
 	}
 
 	// List the nodes
@@ -98,41 +104,50 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
-	// Check if there is any node that has enough storage capacity for the PVC
-	found := false
-	requestedStorage := pvc.Spec.Resources.Requests.Storage()
-	var nodeMessage []string
-	for _, node := range nodeList.Items {
-		capacity, ok := node.Annotations[CapacityAnnotation+deviceClass]
-		if !ok {
-			continue
-		}
-
-		capacityQuantity, err := resource.ParseQuantity(capacity)
-		if err != nil {
-			logger.Error(fmt.Errorf("failed to parse capacity for node %s: %w", node.Name, err), "failed to parse capacity")
-			return ctrl.Result{}, nil
-		}
-		if requestedStorage.Cmp(capacityQuantity) < 0 {
-			found = true
-			break
-		}
-		nodeMessage = append(nodeMessage, fmt.Sprintf("node %s has %s free storage", node.Name, prettyByteSize(capacityQuantity.Value())))
+	// Check if there is any node that can host the PVC
+	result, err := r.checkFeasibility(ctx, pvc, &sc, deviceClass, nodeList.Items)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	// sort the message alphabetically so we always publish the same event
-	sort.Strings(nodeMessage)
-
-	// Publish an event if the requested storage is greater than the available capacity
-	if !found {
-		msg := fmt.Sprintf("Requested storage (%s) is greater than available capacity on any node (%s).", requestedStorage.String(), strings.Join(nodeMessage, ","))
-		r.Recorder.Eventf(pvc, nil, "Warning", "NotEnoughCapacity", "CheckCapacity", msg)
+	var blocked string
+	if !result.feasible {
+		msg := result.Message(pvc.Spec.Resources.Requests.Storage())
+		blocked = fmt.Sprintf("%s: %s", result.Reason(), msg)
+		// Only publish the event if the reason changed since the last check, the annotation keeps the current one
+		if pvc.Annotations[ProvisioningBlockedAnnotation] != blocked {
+			r.Recorder.Eventf(pvc, nil, "Warning", result.Reason(), "CheckCapacity", msg)
+		}
 		logger.V(7).Info(msg)
 	}
+	if err := r.setProvisioningBlocked(ctx, pvc, blocked); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
 }
 
+// setProvisioningBlocked sets the ProvisioningBlockedAnnotation to the given value, or removes it if the value is empty.
+func (r *Reconciler) setProvisioningBlocked(ctx context.Context, pvc *corev1.PersistentVolumeClaim, value string) error {
+	current, ok := pvc.Annotations[ProvisioningBlockedAnnotation]
+	if current == value && (ok || value == "") {
+		return nil
+	}
+	original := pvc.DeepCopy()
+	if value == "" {
+		delete(pvc.Annotations, ProvisioningBlockedAnnotation)
+	} else {
+		if pvc.Annotations == nil {
+			pvc.Annotations = make(map[string]string)
+		}
+		pvc.Annotations[ProvisioningBlockedAnnotation] = value
+	}
+	if err := r.Client.Patch(ctx, pvc, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update the %s annotation: %w", ProvisioningBlockedAnnotation, err)
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
 	persistentvolumeclaim "github.com/openshift/lvm-operator/v4/internal/controllers/persistent-volume-claim"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -18,7 +20,9 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	controllerruntime "sigs.k8s.io/controller-runtime"
@@ -30,7 +34,7 @@ func TestPersistentVolumeClaimReconciler_SetupWithManager(t *testing.T) {
 	mgr, err := controllerruntime.NewManager(&rest.Config{}, controllerruntime.Options{})
 	assert.NoError(t, err)
 	fakeclient := fake.NewClientBuilder().Build()
-	r := persistentvolumeclaim.NewReconciler(fakeclient, events.NewFakeRecorder(1))
+	r := persistentvolumeclaim.NewReconciler(fakeclient, events.NewFakeRecorder(1), "openshift-lvm-storage")
 	assert.NoError(t, r.SetupWithManager(mgr))
 
 	predicates := r.Predicates()
@@ -305,7 +309,7 @@ func TestPersistentVolumeClaimReconciler_Reconcile(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			recorder := events.NewFakeRecorder(1)
 			r := persistentvolumeclaim.NewReconciler(
-				fake.NewClientBuilder().WithObjects(tt.objs...).
+				fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tt.objs...).
 					WithInterceptorFuncs(interceptor.Funcs{
 						Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 							if tt.clientGetErr != nil {
@@ -319,6 +323,7 @@ func TestPersistentVolumeClaimReconciler_Reconcile(t *testing.T) {
 							return client.List(ctx, list, opts...)
 						}}).Build(),
 				recorder,
+				defaultNamespace,
 			)
 			got, err := r.Reconcile(context.Background(), tt.req)
 			if (err != nil) != tt.wantErr {
@@ -353,3 +358,191 @@ func TestPersistentVolumeClaimReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestPersistentVolumeClaimReconciler_Feasibility(t *testing.T) {
+	namespace := "openshift-lvm-storage"
+	deviceClass := "vg1"
+
+	newPVC := func(size string, mutate ...func(pvc *v1.PersistentVolumeClaim)) *v1.PersistentVolumeClaim {
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "data"},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(constants.StorageClassPrefix + deviceClass),
+				Resources: v1.VolumeResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+			Status: v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		}
+		for _, m := range mutate {
+			m(pvc)
+		}
+		return pvc
+	}
+	newStorageClass := func(mutate ...func(sc *storagev1.StorageClass)) *storagev1.StorageClass {
+		sc := &storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: constants.StorageClassPrefix + deviceClass},
+			Provisioner: constants.TopolvmCSIDriverName,
+			Parameters: map[string]string{
+				constants.DeviceClassKey: deviceClass,
+				constants.FsTypeKey:      string(lvmv1alpha1.FilesystemTypeXFS),
+			},
+		}
+		for _, m := range mutate {
+			m(sc)
+		}
+		return sc
+	}
+	newNode := func(name, zone, capacity string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{"topology.kubernetes.io/zone": zone},
+			Annotations: map[string]string{persistentvolumeclaim.CapacityAnnotation + deviceClass: capacity},
+		}}
+	}
+	newVolumeGroup := func(nodeSelector *v1.NodeSelector, thinPoolConfig *lvmv1alpha1.ThinPoolConfig) *lvmv1alpha1.LVMVolumeGroup {
+		return &lvmv1alpha1.LVMVolumeGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: deviceClass},
+			Spec:       lvmv1alpha1.LVMVolumeGroupSpec{NodeSelector: nodeSelector, ThinPoolConfig: thinPoolConfig},
+		}
+	}
+	zoneSelector := func(zone string) *v1.NodeSelector {
+		return &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{
+			{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{zone}},
+		}}}}
+	}
+
+	tests := []struct {
+		name          string
+		objs          []client.Object
+		expectReason  string
+		expectMessage string
+	}{
+		{
+			name: "node with enough capacity",
+			objs: []client.Object{newPVC("1Gi"), newStorageClass(), newNode("a", "zone-a", "2Gi")},
+		},
+		{
+			name:         "request is rounded up to the minimum allocation size",
+			objs:         []client.Object{newPVC("10Mi"), newStorageClass(), newNode("a", "zone-a", "100Mi")},
+			expectReason: persistentvolumeclaim.ReasonNotEnoughCapacity,
+			expectMessage: "Requested storage (10Mi, rounded up to the minimum allocation size of 300Mi) is greater than " +
+				"available capacity on any node (node a has 100.0MiB free storage).",
+		},
+		{
+			name: "block volumes have a smaller minimum allocation size",
+			objs: []client.Object{newPVC("1Mi", func(pvc *v1.PersistentVolumeClaim) {
+				pvc.Spec.VolumeMode = ptr.To(v1.PersistentVolumeBlock)
+			}), newStorageClass(), newNode("a", "zone-a", "100Mi")},
+		},
+		{
+			name: "node with enough capacity excluded by the allowed topologies",
+			objs: []client.Object{
+				newPVC("1Gi"),
+				newStorageClass(func(sc *storagev1.StorageClass) {
+					sc.AllowedTopologies = []v1.TopologySelectorTerm{{MatchLabelExpressions: []v1.TopologySelectorLabelRequirement{
+						{Key: "topology.kubernetes.io/zone", Values: []string{"zone-b"}},
+					}}}
+				}),
+				newNode("a", "zone-a", "2Gi"),
+				newNode("b", "zone-b", "512Mi"),
+			},
+			expectReason: persistentvolumeclaim.ReasonNotEnoughCapacity,
+			expectMessage: "Requested storage (1Gi) is greater than available capacity on any node (node b has 512.0MiB free storage). " +
+				"1 node(s) did not match the allowed topologies of the StorageClass.",
+		},
+		{
+			name: "node with enough capacity not selected by the scheduler",
+			objs: []client.Object{
+				newPVC("1Gi", func(pvc *v1.PersistentVolumeClaim) {
+					pvc.Annotations = map[string]string{"volume.kubernetes.io/selected-node": "b"}
+				}),
+				newStorageClass(),
+				newNode("a", "zone-a", "2Gi"),
+				newNode("b", "zone-b", "512Mi"),
+			},
+			expectReason: persistentvolumeclaim.ReasonNotEnoughCapacity,
+			expectMessage: "Requested storage (1Gi) is greater than available capacity on any node (node b has 512.0MiB free storage). " +
+				"1 node(s) were not selected by the scheduler.",
+		},
+		{
+			name: "node with enough capacity excluded by the affinity of the pod",
+			objs: []client.Object{
+				newPVC("1Gi"),
+				newStorageClass(),
+				newNode("a", "zone-a", "2Gi"),
+				&v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "app"},
+					Spec: v1.PodSpec{
+						NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+						Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+						}}},
+					},
+				},
+			},
+			expectReason:  persistentvolumeclaim.ReasonNoEligibleNode,
+			expectMessage: "Requested storage (1Gi) cannot be placed on any node. 1 node(s) did not match the node selector or affinity of the pods using the claim.",
+		},
+		{
+			name: "node with enough capacity excluded by the node selector of the device class",
+			objs: []client.Object{
+				newPVC("1Gi"),
+				newStorageClass(),
+				newNode("a", "zone-a", "2Gi"),
+				newNode("b", "zone-b", "512Mi"),
+				newVolumeGroup(zoneSelector("zone-b"), &lvmv1alpha1.ThinPoolConfig{OverprovisionRatio: 10}),
+			},
+			expectReason: persistentvolumeclaim.ReasonNotEnoughCapacity,
+			expectMessage: "Requested storage (1Gi) is greater than available capacity on any node " +
+				"(node b has 512.0MiB free storage with an overprovision ratio of 10). " +
+				"1 node(s) did not match the node selector of the device class.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tt.objs...).Build()
+			recorder := events.NewFakeRecorder(10)
+			r := persistentvolumeclaim.NewReconciler(c, recorder, namespace)
+			req := controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: "data"}}
+
+			// the second reconcile must not publish the same event again
+			for range 2 {
+				_, err := r.Reconcile(ctx, req)
+				require.NoError(t, err)
+			}
+
+			pvc := &v1.PersistentVolumeClaim{}
+			require.NoError(t, c.Get(ctx, req.NamespacedName, pvc))
+			close(recorder.Events)
+			var recorded []string
+			for e := range recorder.Events {
+				recorded = append(recorded, e)
+			}
+
+			if tt.expectReason == "" {
+				assert.NotContains(t, pvc.Annotations, persistentvolumeclaim.ProvisioningBlockedAnnotation)
+				assert.Empty(t, recorded)
+				return
+			}
+			assert.Equal(t, tt.expectReason+": "+tt.expectMessage, pvc.Annotations[persistentvolumeclaim.ProvisioningBlockedAnnotation])
+			assert.Equal(t, []string{"Warning " + tt.expectReason + " " + tt.expectMessage}, recorded)
+
+			// the annotation is removed once the claim is bound
+			pvc.Status.Phase = v1.ClaimBound
+			require.NoError(t, c.Status().Update(ctx, pvc))
+			_, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+			require.NoError(t, c.Get(ctx, req.NamespacedName, pvc))
+			assert.NotContains(t, pvc.Annotations, persistentvolumeclaim.ProvisioningBlockedAnnotation)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistent_volume_claim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	corev1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	volumehelpers "k8s.io/component-helpers/storage/volume"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ReasonNotEnoughCapacity is used when none of the nodes the volume can be placed on has enough free capacity.
+	ReasonNotEnoughCapacity = "NotEnoughCapacity"
+	// ReasonNoEligibleNode is used when the volume cannot be placed on any node, regardless of the free capacity.
+	ReasonNoEligibleNode = "NoEligibleNode"
+)
+
+// feasibility is the result of checking on which nodes a PersistentVolumeClaim can be provisioned.
+type feasibility struct {
+	// required is the size of the logical volume that will be created for the claim
+	required resource.Quantity
+	// feasible tells if at least one node can host the logical volume
+	feasible bool
+
+	// the number of nodes excluded by each placement constraint, in the order they are checked
+	excludedByTopology     int
+	excludedBySelectedNode int
+	excludedByConsumer     int
+	excludedByDeviceClass  int
+	// capacity describes the free capacity of every eligible node without enough capacity
+	capacity []string
+	// overprovisionRatio is the overprovision ratio of the thin pool of the device class, 0 for thick device classes
+	overprovisionRatio int
+}
+
+// Reason returns the reason of the event explaining why the claim cannot be provisioned.
+func (f *feasibility) Reason() string {
+	if len(f.capacity) == 0 && f.excluded() > 0 {
+		return ReasonNoEligibleNode
+	}
+	return ReasonNotEnoughCapacity
+}
+
+func (f *feasibility) excluded() int {
+	return f.excludedByTopology + f.excludedBySelectedNode + f.excludedByConsumer + f.excludedByDeviceClass
+}
+
+// Message returns a message explaining why the claim cannot be provisioned.
+// It does not depend on the order of the nodes, so that it can be compared with earlier results.
+func (f *feasibility) Message(requested *resource.Quantity) string {
+	var msg string
+	if f.Reason() == ReasonNotEnoughCapacity {
+		sort.Strings(f.capacity)
+		msg = fmt.Sprintf("Requested storage (%s) is greater than available capacity on any node (%s).",
+			f.requestedString(requested), strings.Join(f.capacity, ","))
+	} else {
+		msg = fmt.Sprintf("Requested storage (%s) cannot be placed on any node.", f.requestedString(requested))
+	}
+	for _, excluded := range []struct {
+		count  int
+		reason string
+	}{
+		{f.excludedByTopology, "did not match the allowed topologies of the StorageClass"},
+		{f.excludedBySelectedNode, "were not selected by the scheduler"},
+		{f.excludedByConsumer, "did not match the node selector or affinity of the pods using the claim"},
+		{f.excludedByDeviceClass, "did not match the node selector of the device class"},
+	} {
+		if excluded.count > 0 {
+			msg += fmt.Sprintf(" %d node(s) %s.", excluded.count, excluded.reason)
+		}
+	}
+	return msg
+}
+
+func (f *feasibility) requestedString(requested *resource.Quantity) string {
+	if requested.Cmp(f.required) < 0 {
+		return fmt.Sprintf("%s, rounded up to the minimum allocation size of %s", requested.String(), f.required.String())
+	}
+	return requested.String()
+}
+
+// checkFeasibility checks on which of the nodes the claim can be provisioned.
+// A node is eligible if it matches the allowed topologies of the StorageClass, the node selected by the scheduler
+// or otherwise the node selectors and affinities of the pods using the claim, and the node selector of the device class.
+// An eligible node can host the claim if its free capacity in the device class is larger than the size of the
+// logical volume, which is at least the minimum allocation size of TopoLVM.
+func (r *Reconciler) checkFeasibility(
+	ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim,
+	sc *storagev1.StorageClass,
+	deviceClass string,
+	nodes []corev1.Node,
+) (*feasibility, error) {
+	logger := log.FromContext(ctx)
+
+	volumeGroup, err := r.volumeGroup(ctx, deviceClass)
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := r.consumers(ctx, pvc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &feasibility{required: requiredSize(pvc, sc)}
+	if volumeGroup != nil && volumeGroup.Spec.ThinPoolConfig != nil {
+		result.overprovisionRatio = volumeGroup.Spec.ThinPoolConfig.OverprovisionRatio
+	}
+	selectedNode := pvc.Annotations[volumehelpers.AnnSelectedNode]
+
+	for i := range nodes {
+		node := &nodes[i]
+		if !matchesAllowedTopologies(node, sc.AllowedTopologies) {
+			result.excludedByTopology++
+			continue
+		}
+		if selectedNode != "" && node.Name != selectedNode {
+			result.excludedBySelectedNode++
+			continue
+		}
+		if ok, err := matchesConsumers(node, consumers); err != nil {
+			return nil, err
+		} else if !ok {
+			result.excludedByConsumer++
+			continue
+		}
+		if volumeGroup != nil && volumeGroup.Spec.NodeSelector != nil {
+			if ok, err := corev1helper.MatchNodeSelectorTerms(node, volumeGroup.Spec.NodeSelector); err != nil {
+				return nil, fmt.Errorf("error matching node selector terms: %w", err)
+			} else if !ok {
+				result.excludedByDeviceClass++
+				continue
+			}
+		}
+
+		// TopoLVM reports the free capacity of thin device classes already multiplied with the overprovision ratio,
+		// so the annotation is the effective capacity for both thick and thin device classes.
+		capacity, ok := node.Annotations[CapacityAnnotation+deviceClass]
+		if !ok {
+			continue
+		}
+		capacityQuantity, err := resource.ParseQuantity(capacity)
+		if err != nil {
+			logger.Error(err, "failed to parse capacity", "node", node.Name)
+			continue
+		}
+		if result.required.Cmp(capacityQuantity) < 0 {
+			result.feasible = true
+			return result, nil
+		}
+		result.capacity = append(result.capacity, result.capacityMessage(node.Name, capacityQuantity))
+	}
+	return result, nil
+}
+
+func (f *feasibility) capacityMessage(node string, capacity resource.Quantity) string {
+	if f.overprovisionRatio > 1 {
+		return fmt.Sprintf("node %s has %s free storage with an overprovision ratio of %d", node, prettyByteSize(capacity.Value()), f.overprovisionRatio)
+	}
+	return fmt.Sprintf("node %s has %s free storage", node, prettyByteSize(capacity.Value()))
+}
+
+// volumeGroup returns the LVMVolumeGroup of the device class, or nil if it does not exist.
+func (r *Reconciler) volumeGroup(ctx context.Context, deviceClass string) (*lvmv1alpha1.LVMVolumeGroup, error) {
+	volumeGroup := &lvmv1alpha1.LVMVolumeGroup{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: deviceClass, Namespace: r.Namespace}, volumeGroup); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get LVMVolumeGroup %s: %w", deviceClass, err)
+	}
+	return volumeGroup, nil
+}
+
+// consumers returns the pods that are not yet scheduled and use the claim, either directly or as generic ephemeral volume.
+func (r *Reconciler) consumers(ctx context.Context, pvc *corev1.PersistentVolumeClaim) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, client.InNamespace(pvc.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool {
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				return volume.PersistentVolumeClaim.ClaimName == pvc.Name
+			case volume.Ephemeral != nil:
+				return pod.Name+"-"+volume.Name == pvc.Name
+			}
+			return false
+		}) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+func matchesConsumers(node *corev1.Node, pods []corev1.Pod) (bool, error) {
+	for i := range pods {
+		ok, err := nodeaffinity.GetRequiredNodeAffinity(&pods[i]).Match(node)
+		if err != nil {
+			return false, fmt.Errorf("failed to match the node affinity of pod %s: %w", pods[i].Name, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesAllowedTopologies tells if the node matches any of the topology terms.
+// The node matches a term if it has every label of the term with one of the allowed values.
+func matchesAllowedTopologies(node *corev1.Node, terms []corev1.TopologySelectorTerm) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(terms, func(term corev1.TopologySelectorTerm) bool {
+		for _, requirement := range term.MatchLabelExpressions {
+			value, ok := node.Labels[requirement.Key]
+			if !ok || !slices.Contains(requirement.Values, value) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// requiredSize returns the size of the logical volume TopoLVM creates for the claim,
+// which is the requested size rounded up to the minimum allocation size of the volume mode and filesystem.
+func requiredSize(pvc *corev1.PersistentVolumeClaim, sc *storagev1.StorageClass) resource.Quantity {
+	required := pvc.Spec.Resources.Requests.Storage().DeepCopy()
+
+	var minimum string
+	if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock {
+		minimum = constants.DefaultMinimumAllocationSizeBlock
+	} else {
+		switch lvmv1alpha1.DeviceFilesystemType(sc.Parameters[constants.FsTypeKey]) {
+		case lvmv1alpha1.FilesystemTypeXFS:
+			minimum = constants.DefaultMinimumAllocationSizeXFS
+		case lvmv1alpha1.FilesystemTypeExt4:
+			minimum = constants.DefaultMinimumAllocationSizeExt4
+		}
+	}
+	if minimum != "" {
+		if minimumQuantity := resource.MustParse(minimum); required.Cmp(minimumQuantity) < 0 {
+			return minimumQuantity
+		}
+	}
+	return required
+}
@@ -33,7 +33,8 @@ func NewReconciler(client client.Client, eventRecorder events.EventRecorder) *Re
 	}
 }
 
-//+kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;delete
+//+kubebuilder:rbac:groups=topolvm.io,resources=logicalvolumes,verbs=get;delete
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
 
 // Reconcile PV
@@ -55,8 +56,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
-	// Publish an event if PV has no claimRef
-	if pv.Spec.ClaimRef == nil {
+	// Reclaim a retained PV only if explicitly requested
+	if action, ok := pv.Annotations[constants.ReclaimActionAnnotation]; ok {
+		return r.reclaim(ctx, pv, action)
+	}
+
+	// Publish an event if PV has no claimRef, unless it was made Available for rebinding
+	if pv.Spec.ClaimRef == nil && pv.Status.Phase != corev1.VolumeAvailable {
 		r.recorder.Eventf(pv, nil, "Warning", "ClaimReferenceRemoved", "CheckClaimReference", "Claim reference has been removed. This PV is no longer dynamically managed by LVM Storage and will need to be cleaned up manually.")
 		logger.Info("Event published for the PV", "PV", req.NamespacedName)
 	}
@@ -67,7 +73,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	for _, pvNodeSelectorTerms := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
 		for _, v := range pvNodeSelectorTerms.MatchExpressions {
-			if v.Key == "topology.topolvm.io/node" && v.Operator == corev1.NodeSelectorOpIn {
+			if v.Key == constants.TopologyNodeKey && v.Operator == corev1.NodeSelectorOpIn {
 				if pv.Labels == nil {
 					pv.Labels = make(map[string]string)
 				}
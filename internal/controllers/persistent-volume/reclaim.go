@@ -0,0 +1,159 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistent_volume
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	EventReasonReclaimRejected        = "ReclaimRejected"
+	EventReasonLogicalVolumeDeleting  = "LogicalVolumeDeleting"
+	EventReasonRetainedVolumeDeleted  = "RetainedVolumeDeleted"
+	EventReasonRetainedVolumeReleased = "RetainedVolumeReleased"
+
+	eventActionReclaim = "Reclaim"
+
+	// logicalVolumeDeletionCheckInterval is the interval in which the removal of the logical volume is checked,
+	// as TopoLVM removes it asynchronously on the node and LogicalVolumes are not watched.
+	logicalVolumeDeletionCheckInterval = 10 * time.Second
+)
+
+// reclaim executes the action requested with the constants.ReclaimActionAnnotation on a Released
+// PersistentVolume of LVMS. Every action, and every rejected request, is recorded as an event on the volume.
+// Rejected requests are removed from the volume, so that they are only reported once.
+func (r *Reconciler) reclaim(ctx context.Context, pv *corev1.PersistentVolume, action string) (ctrl.Result, error) {
+	if pv.Status.Phase != corev1.VolumeReleased {
+		return ctrl.Result{}, r.rejectReclaim(ctx, pv, action,
+			fmt.Sprintf("the PersistentVolume is %s, only Released volumes can be reclaimed", pv.Status.Phase))
+	}
+
+	switch action {
+	case constants.ReclaimActionDelete:
+		return r.deleteRetainedVolume(ctx, pv)
+	case constants.ReclaimActionRelease:
+		return ctrl.Result{}, r.releaseRetainedVolume(ctx, pv)
+	default:
+		return ctrl.Result{}, r.rejectReclaim(ctx, pv, action, fmt.Sprintf("the action must be %s or %s",
+			constants.ReclaimActionDelete, constants.ReclaimActionRelease))
+	}
+}
+
+// deleteRetainedVolume deletes the TopoLVM LogicalVolume of the PersistentVolume, which makes TopoLVM remove the
+// logical volume on its node, and deletes the PersistentVolume once the LogicalVolume is gone.
+func (r *Reconciler) deleteRetainedVolume(ctx context.Context, pv *corev1.PersistentVolume) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != constants.TopolvmCSIDriverName {
+		return ctrl.Result{}, r.rejectReclaim(ctx, pv, constants.ReclaimActionDelete, "the PersistentVolume was not provisioned by TopoLVM")
+	}
+
+	// TopoLVM names the LogicalVolume after the PersistentVolume it was provisioned for.
+	lv := &topolvmv1.LogicalVolume{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: pv.Name}, lv)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get the LogicalVolume of PersistentVolume %s: %w", pv.Name, err)
+	}
+
+	if err == nil {
+		if lv.Status.VolumeID != pv.Spec.CSI.VolumeHandle {
+			return ctrl.Result{}, r.rejectReclaim(ctx, pv, constants.ReclaimActionDelete, fmt.Sprintf(
+				"LogicalVolume %s refers to volume %s instead of %s", lv.Name, lv.Status.VolumeID, pv.Spec.CSI.VolumeHandle))
+		}
+		if lv.DeletionTimestamp == nil {
+			if err := r.client.Delete(ctx, lv); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to delete LogicalVolume %s: %w", lv.Name, err)
+			}
+			r.recorder.Eventf(pv, lv, corev1.EventTypeNormal, EventReasonLogicalVolumeDeleting, eventActionReclaim,
+				"Deleting logical volume %s on node %s as requested with %s=%s.",
+				lv.Status.VolumeID, lv.Spec.NodeName, constants.ReclaimActionAnnotation, constants.ReclaimActionDelete)
+			logger.Info("deleting the logical volume of the retained PersistentVolume", "LogicalVolume", lv.Name, "node", lv.Spec.NodeName)
+		}
+		return ctrl.Result{RequeueAfter: logicalVolumeDeletionCheckInterval}, nil
+	}
+
+	if err := r.client.Delete(ctx, pv); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete PersistentVolume %s: %w", pv.Name, err)
+	}
+	r.recorder.Eventf(pv, nil, corev1.EventTypeNormal, EventReasonRetainedVolumeDeleted, eventActionReclaim,
+		"Deleted the PersistentVolume, its logical volume %s was removed.", pv.Spec.CSI.VolumeHandle)
+	logger.Info("deleted the retained PersistentVolume")
+	return ctrl.Result{}, nil
+}
+
+// releaseRetainedVolume clears the claim of the PersistentVolume so that it becomes Available again.
+// If the constants.ReclaimClaimAnnotation is set, the volume is reserved for that claim instead.
+func (r *Reconciler) releaseRetainedVolume(ctx context.Context, pv *corev1.PersistentVolume) error {
+	logger := log.FromContext(ctx)
+
+	var claimRef *corev1.ObjectReference
+	if claim, ok := pv.Annotations[constants.ReclaimClaimAnnotation]; ok {
+		namespace, name, found := strings.Cut(claim, "/")
+		if !found || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return r.rejectReclaim(ctx, pv, constants.ReclaimActionRelease, fmt.Sprintf(
+				"%s must be the claim as <namespace>/<name>, not %q", constants.ReclaimClaimAnnotation, claim))
+		}
+		claimRef = &corev1.ObjectReference{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+			Namespace:  namespace,
+			Name:       name,
+		}
+	}
+
+	pv.Spec.ClaimRef = claimRef
+	// remove the request so that it is executed only once
+	delete(pv.Annotations, constants.ReclaimActionAnnotation)
+	delete(pv.Annotations, constants.ReclaimClaimAnnotation)
+	if err := r.client.Update(ctx, pv); err != nil {
+		return fmt.Errorf("failed to release PersistentVolume %s: %w", pv.Name, err)
+	}
+
+	if claimRef != nil {
+		r.recorder.Eventf(pv, nil, corev1.EventTypeNormal, EventReasonRetainedVolumeReleased, eventActionReclaim,
+			"Released the PersistentVolume for rebinding by PersistentVolumeClaim %s/%s.", claimRef.Namespace, claimRef.Name)
+	} else {
+		r.recorder.Eventf(pv, nil, corev1.EventTypeNormal, EventReasonRetainedVolumeReleased, eventActionReclaim,
+			"Released the PersistentVolume for rebinding.")
+	}
+	logger.Info("released the retained PersistentVolume", "claimRef", claimRef)
+	return nil
+}
+
+// rejectReclaim removes the rejected request from the PersistentVolume and records the reason as an event.
+func (r *Reconciler) rejectReclaim(ctx context.Context, pv *corev1.PersistentVolume, action, reason string) error {
+	delete(pv.Annotations, constants.ReclaimActionAnnotation)
+	delete(pv.Annotations, constants.ReclaimClaimAnnotation)
+	if err := r.client.Update(ctx, pv); err != nil {
+		return fmt.Errorf("failed to remove the rejected reclaim request from PersistentVolume %s: %w", pv.Name, err)
+	}
+	r.recorder.Eventf(pv, nil, corev1.EventTypeWarning, EventReasonReclaimRejected, eventActionReclaim,
+		"Ignoring %s=%s: %s.", constants.ReclaimActionAnnotation, action, reason)
+	log.FromContext(ctx).Info("rejected the reclaim request of the PersistentVolume", "action", action, "reason", reason)
+	return nil
+}
@@ -0,0 +1,176 @@
+package persistent_volume_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	persistentvolume "github.com/openshift/lvm-operator/v4/internal/controllers/persistent-volume"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func retainedPersistentVolume(phase v1.PersistentVolumePhase, annotations map[string]string) *v1.PersistentVolume {
+	return &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Annotations: annotations},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName:              constants.StorageClassPrefix + "vg1",
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+			ClaimRef:                      &v1.ObjectReference{Namespace: "app", Name: "data", UID: "uid"},
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{Driver: constants.TopolvmCSIDriverName, VolumeHandle: "volume-1"},
+			},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: phase},
+	}
+}
+
+func TestPersistentVolumeReconciler_Reclaim(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	logicalVolume := &topolvmv1.LogicalVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+		Spec:       topolvmv1.LogicalVolumeSpec{Name: "pvc-1", NodeName: "node1", DeviceClass: "vg1"},
+		Status:     topolvmv1.LogicalVolumeStatus{VolumeID: "volume-1"},
+	}
+
+	tests := []struct {
+		name          string
+		pv            *v1.PersistentVolume
+		objs          []client.Object
+		expectedEvent string
+		check         func(t *testing.T, c client.Client, result controllerruntime.Result)
+	}{
+		{
+			name: "volume that is not released is not reclaimed",
+			pv: retainedPersistentVolume(v1.VolumeBound, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionDelete,
+			}),
+			objs:          []client.Object{logicalVolume.DeepCopy()},
+			expectedEvent: persistentvolume.EventReasonReclaimRejected,
+			check: func(t *testing.T, c client.Client, _ controllerruntime.Result) {
+				assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(logicalVolume), &topolvmv1.LogicalVolume{}))
+			},
+		},
+		{
+			name: "unknown action is rejected",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: "Recycle",
+			}),
+			expectedEvent: persistentvolume.EventReasonReclaimRejected,
+		},
+		{
+			name: "release clears the claim",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionRelease,
+			}),
+			expectedEvent: persistentvolume.EventReasonRetainedVolumeReleased,
+			check: func(t *testing.T, c client.Client, _ controllerruntime.Result) {
+				pv := &v1.PersistentVolume{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "pvc-1"}, pv))
+				assert.Nil(t, pv.Spec.ClaimRef)
+				assert.NotContains(t, pv.Annotations, constants.ReclaimActionAnnotation, "the request must only be executed once")
+			},
+		},
+		{
+			name: "release reserves the volume for a new claim",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionRelease,
+				constants.ReclaimClaimAnnotation:  "app/restored",
+			}),
+			expectedEvent: persistentvolume.EventReasonRetainedVolumeReleased,
+			check: func(t *testing.T, c client.Client, _ controllerruntime.Result) {
+				pv := &v1.PersistentVolume{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "pvc-1"}, pv))
+				require.NotNil(t, pv.Spec.ClaimRef)
+				assert.Equal(t, "app", pv.Spec.ClaimRef.Namespace)
+				assert.Equal(t, "restored", pv.Spec.ClaimRef.Name)
+				assert.Empty(t, pv.Spec.ClaimRef.UID)
+				assert.NotContains(t, pv.Annotations, constants.ReclaimClaimAnnotation)
+			},
+		},
+		{
+			name: "release with an invalid claim is rejected",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionRelease,
+				constants.ReclaimClaimAnnotation:  "restored",
+			}),
+			expectedEvent: persistentvolume.EventReasonReclaimRejected,
+			check: func(t *testing.T, c client.Client, _ controllerruntime.Result) {
+				pv := &v1.PersistentVolume{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "pvc-1"}, pv))
+				assert.Equal(t, "data", pv.Spec.ClaimRef.Name)
+			},
+		},
+		{
+			name: "delete removes the logical volume first",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionDelete,
+			}),
+			objs:          []client.Object{logicalVolume.DeepCopy()},
+			expectedEvent: persistentvolume.EventReasonLogicalVolumeDeleting,
+			check: func(t *testing.T, c client.Client, result controllerruntime.Result) {
+				err := c.Get(context.Background(), client.ObjectKeyFromObject(logicalVolume), &topolvmv1.LogicalVolume{})
+				assert.True(t, apierrors.IsNotFound(err))
+				assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "pvc-1"}, &v1.PersistentVolume{}))
+				assert.NotZero(t, result.RequeueAfter, "the removal of the logical volume must be awaited")
+			},
+		},
+		{
+			name: "delete removes the volume once the logical volume is gone",
+			pv: retainedPersistentVolume(v1.VolumeReleased, map[string]string{
+				constants.ReclaimActionAnnotation: constants.ReclaimActionDelete,
+			}),
+			expectedEvent: persistentvolume.EventReasonRetainedVolumeDeleted,
+			check: func(t *testing.T, c client.Client, _ controllerruntime.Result) {
+				err := c.Get(context.Background(), client.ObjectKey{Name: "pvc-1"}, &v1.PersistentVolume{})
+				assert.True(t, apierrors.IsNotFound(err))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := events.NewFakeRecorder(1)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.objs, tt.pv)...).Build()
+			r := persistentvolume.NewReconciler(c, recorder)
+
+			result, err := r.Reconcile(context.Background(), controllerruntime.Request{
+				NamespacedName: client.ObjectKeyFromObject(tt.pv),
+			})
+			require.NoError(t, err)
+
+			require.Len(t, recorder.Events, 1)
+			event := <-recorder.Events
+			assert.Contains(t, event, tt.expectedEvent)
+			if tt.check != nil {
+				tt.check(t, c, result)
+			}
+
+			if tt.expectedEvent == persistentvolume.EventReasonReclaimRejected {
+				// the rejected request is removed, so that it is only reported once
+				pv := &v1.PersistentVolume{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tt.pv), pv))
+				assert.NotContains(t, pv.Annotations, constants.ReclaimActionAnnotation)
+				assert.NotContains(t, pv.Annotations, constants.ReclaimClaimAnnotation)
+				_, err := r.Reconcile(context.Background(), controllerruntime.Request{
+					NamespacedName: client.ObjectKeyFromObject(tt.pv),
+				})
+				require.NoError(t, err)
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistent_volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// nodeNameExtraKey and podNameExtraKey identify the node and the pod of a bound service account token
+	// in the user info of a request.
+	nodeNameExtraKey = "authentication.kubernetes.io/node-name"
+	podNameExtraKey  = "authentication.kubernetes.io/pod-name"
+)
+
+var ErrPersistentVolumeOfOtherNode = errors.New("vg-manager can only create TopoLVM PersistentVolumes on its own node")
+
+// vgManagerPersistentVolumeValidator restricts the PersistentVolumes created by vg-manager, when it imports or
+// recovers logical volumes, to TopoLVM volumes on the node of the vg-manager pod. The PersistentVolumes of everyone
+// else are not validated.
+type vgManagerPersistentVolumeValidator struct {
+	client    client.Reader
+	namespace string
+}
+
+var _ admission.Validator[*corev1.PersistentVolume] = &vgManagerPersistentVolumeValidator{}
+
+// The webhook ignores failures, so that the creation of PersistentVolumes by other provisioners does not depend on the
+// availability of the operator.
+//+kubebuilder:webhook:path=/validate--v1-persistentvolume,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=persistentvolumes,verbs=create,versions=v1,name=vpersistentvolume.lvms.openshift.io,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the validation of the PersistentVolumes created by the vg-manager service account
+// of the given namespace.
+func SetupWebhookWithManager(mgr ctrl.Manager, namespace string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1.PersistentVolume{}).
+		WithValidator(&vgManagerPersistentVolumeValidator{client: mgr.GetClient(), namespace: namespace}).
+		Complete()
+}
+
+func (v *vgManagerPersistentVolumeValidator) ValidateCreate(ctx context.Context, pv *corev1.PersistentVolume) (admission.Warnings, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserInfo.Username != fmt.Sprintf("system:serviceaccount:%s:%s", v.namespace, constants.VGManagerServiceAccount) {
+		return nil, nil
+	}
+
+	nodeName, err := v.requestingNode(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != constants.TopolvmCSIDriverName {
+		return nil, fmt.Errorf("PersistentVolume %s is not provisioned by %s: %w", pv.Name, constants.TopolvmCSIDriverName, ErrPersistentVolumeOfOtherNode)
+	}
+	if !restrictedToNode(pv.Spec.NodeAffinity, nodeName) {
+		return nil, fmt.Errorf("PersistentVolume %s is not restricted to node %s with %s: %w",
+			pv.Name, nodeName, constants.TopologyNodeKey, ErrPersistentVolumeOfOtherNode)
+	}
+	return nil, nil
+}
+
+func (v *vgManagerPersistentVolumeValidator) ValidateUpdate(_ context.Context, _, _ *corev1.PersistentVolume) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *vgManagerPersistentVolumeValidator) ValidateDelete(_ context.Context, _ *corev1.PersistentVolume) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// requestingNode returns the node of the vg-manager pod that the request was made by. The node is part of the user
+// info of bound service account tokens since Kubernetes 1.30, before that it is looked up from the pod.
+func (v *vgManagerPersistentVolumeValidator) requestingNode(ctx context.Context, req admission.Request) (string, error) {
+	if nodeName := req.UserInfo.Extra[nodeNameExtraKey]; len(nodeName) == 1 {
+		return nodeName[0], nil
+	}
+	podName := req.UserInfo.Extra[podNameExtraKey]
+	if len(podName) != 1 {
+		return "", fmt.Errorf("the node of the request cannot be determined without a bound service account token: %w", ErrPersistentVolumeOfOtherNode)
+	}
+	pod := &corev1.Pod{}
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: v.namespace, Name: podName[0]}, pod); err != nil {
+		return "", fmt.Errorf("failed to get the vg-manager pod %s of the request: %w", podName[0], err)
+	}
+	if pod.Spec.NodeName == "" {
+		return "", fmt.Errorf("the vg-manager pod %s of the request is not scheduled: %w", pod.Name, ErrPersistentVolumeOfOtherNode)
+	}
+	return pod.Spec.NodeName, nil
+}
+
+// restrictedToNode returns true if every term of the node affinity only selects the given node by the TopoLVM topology key.
+func restrictedToNode(affinity *corev1.VolumeNodeAffinity, nodeName string) bool {
+	if affinity == nil || affinity.Required == nil || len(affinity.Required.NodeSelectorTerms) == 0 {
+		return false
+	}
+	for _, term := range affinity.Required.NodeSelectorTerms {
+		if !slices.ContainsFunc(term.MatchExpressions, func(requirement corev1.NodeSelectorRequirement) bool {
+			return requirement.Key == constants.TopologyNodeKey &&
+				requirement.Operator == corev1.NodeSelectorOpIn &&
+				slices.Equal(requirement.Values, []string{nodeName})
+		}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package persistent_volume
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestVGManagerPersistentVolumeValidator(t *testing.T) {
+	vgManager := "system:serviceaccount:openshift-lvm-storage:vg-manager"
+	onNode := func(nodeName string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: constants.TopolvmCSIDriverName, VolumeHandle: "volume-1"},
+				},
+				NodeAffinity: nodeAffinityOf(nodeName),
+			},
+		}
+	}
+	hostPath := onNode("node1")
+	hostPath.Spec.CSI = nil
+	hostPath.Spec.HostPath = &corev1.HostPathVolumeSource{Path: "/"}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg-manager-abcde", Namespace: "openshift-lvm-storage"},
+		Spec:       corev1.PodSpec{NodeName: "node1"},
+	}
+
+	tests := []struct {
+		name      string
+		user      authenticationv1.UserInfo
+		pv        *corev1.PersistentVolume
+		expectErr bool
+	}{
+		{
+			name: "volumes of others are not validated",
+			user: authenticationv1.UserInfo{Username: "system:serviceaccount:kube-system:persistent-volume-binder"},
+			pv:   onNode("node2"),
+		},
+		{
+			name: "vg-manager creates a volume on its own node",
+			user: authenticationv1.UserInfo{Username: vgManager, Extra: map[string]authenticationv1.ExtraValue{nodeNameExtraKey: {"node1"}}},
+			pv:   onNode("node1"),
+		},
+		{
+			name:      "vg-manager creates a volume on another node",
+			user:      authenticationv1.UserInfo{Username: vgManager, Extra: map[string]authenticationv1.ExtraValue{nodeNameExtraKey: {"node1"}}},
+			pv:        onNode("node2"),
+			expectErr: true,
+		},
+		{
+			name:      "vg-manager creates a volume that is not provisioned by TopoLVM",
+			user:      authenticationv1.UserInfo{Username: vgManager, Extra: map[string]authenticationv1.ExtraValue{nodeNameExtraKey: {"node1"}}},
+			pv:        hostPath,
+			expectErr: true,
+		},
+		{
+			name: "the node is looked up from the pod of the request",
+			user: authenticationv1.UserInfo{Username: vgManager, Extra: map[string]authenticationv1.ExtraValue{podNameExtraKey: {pod.Name}}},
+			pv:   onNode("node1"),
+		},
+		{
+			name:      "the node of the pod of the request is enforced",
+			user:      authenticationv1.UserInfo{Username: vgManager, Extra: map[string]authenticationv1.ExtraValue{podNameExtraKey: {pod.Name}}},
+			pv:        onNode("node2"),
+			expectErr: true,
+		},
+		{
+			name:      "requests without a bound token are rejected",
+			user:      authenticationv1.UserInfo{Username: vgManager},
+			pv:        onNode("node1"),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &vgManagerPersistentVolumeValidator{
+				client:    fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(pod).Build(),
+				namespace: "openshift-lvm-storage",
+			}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create, UserInfo: tt.user},
+			})
+			_, err := validator.ValidateCreate(ctx, tt.pv)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrPersistentVolumeOfOtherNode)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func nodeAffinityOf(nodeName string) *corev1.VolumeNodeAffinity {
+	return &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      constants.TopologyNodeKey,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{nodeName},
+			}},
+		}},
+	}}
+}
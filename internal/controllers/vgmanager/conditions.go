@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"slices"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	ReasonDevicesWipeFailed     = "DevicesWipeFailed"
+	ReasonDeviceDiscoveryFailed = "DeviceDiscoveryFailed"
+	ReasonVolumeGroupFailed     = "VolumeGroupFailed"
+	ReasonThinPoolFailed        = "ThinPoolFailed"
+	ReasonLVMDConfigFailed      = "LVMDConfigFailed"
+
+	ReasonVolumeGroupCreating  = "Creating"
+	ReasonVolumeGroupExtending = "Extending"
+	ReasonNotReconciled        = "NotReconciled"
+)
+
+// conditionFailureReasons are the reasons reported for a condition whose step failed to reconcile.
+var conditionFailureReasons = map[string]string{
+	lvmv1alpha1.DevicesWiped:       ReasonDevicesWipeFailed,
+	lvmv1alpha1.DevicesDiscovered:  ReasonDeviceDiscoveryFailed,
+	lvmv1alpha1.VolumeGroupCreated: ReasonVolumeGroupFailed,
+	lvmv1alpha1.ThinPoolReady:      ReasonThinPoolFailed,
+	lvmv1alpha1.LVMDConfigApplied:  ReasonLVMDConfigFailed,
+}
+
+// volumeGroupConditionTypes returns the condition types that apply to the volume group
+// in the order in which their steps are reconciled.
+func volumeGroupConditionTypes(vg *lvmv1alpha1.LVMVolumeGroup) []string {
+	var types []string
+	if vg.Spec.DeviceSelector != nil && ptr.Deref(vg.Spec.DeviceSelector.ForceWipeDevicesAndDestroyAllData, false) {
+		types = append(types, lvmv1alpha1.DevicesWiped)
+	}
+	types = append(types, lvmv1alpha1.DevicesDiscovered, lvmv1alpha1.VolumeGroupCreated)
+	if vg.Spec.ThinPoolConfig != nil {
+		types = append(types, lvmv1alpha1.ThinPoolReady)
+	}
+	return append(types, lvmv1alpha1.LVMDConfigApplied)
+}
+
+// failedCondition returns the condition for a step of the volume group that failed with the error.
+func failedCondition(conditionType string, err error) *metav1.Condition {
+	return &metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  conditionFailureReasons[conditionType],
+		Message: err.Error(),
+	}
+}
+
+// progressingCondition returns the condition for a volume group that is created or extended with new devices.
+func progressingCondition(vgExists bool) *metav1.Condition {
+	if vgExists {
+		return &metav1.Condition{
+			Type:    lvmv1alpha1.VolumeGroupCreated,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonVolumeGroupExtending,
+			Message: "the volume group is extended with new available devices",
+		}
+	}
+	return &metav1.Condition{
+		Type:    lvmv1alpha1.VolumeGroupCreated,
+		Status:  metav1.ConditionFalse,
+		Reason:  ReasonVolumeGroupCreating,
+		Message: "the volume group is created from the available devices",
+	}
+}
+
+// setVolumeGroupConditions sets the conditions of the volume group for its current generation.
+// The steps before the given condition succeeded and are set to True. The steps after it were not reconciled,
+// so their conditions are kept as they were, or set to Unknown if they were never reported.
+// If no condition is given, all steps succeeded. Conditions that do not apply to the volume group are removed.
+func setVolumeGroupConditions(conditions *[]metav1.Condition, vg *lvmv1alpha1.LVMVolumeGroup, condition *metav1.Condition) {
+	types := volumeGroupConditionTypes(vg)
+	reached := false
+	for _, conditionType := range types {
+		switch {
+		case condition != nil && condition.Type == conditionType:
+			reached = true
+			c := *condition
+			c.ObservedGeneration = vg.GetGeneration()
+			meta.SetStatusCondition(conditions, c)
+		case !reached:
+			meta.SetStatusCondition(conditions, metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             conditionType,
+				ObservedGeneration: vg.GetGeneration(),
+			})
+		case meta.FindStatusCondition(*conditions, conditionType) == nil:
+			meta.SetStatusCondition(conditions, metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionUnknown,
+				Reason:             ReasonNotReconciled,
+				Message:            "a previous step of the volume group did not succeed yet",
+				ObservedGeneration: vg.GetGeneration(),
+			})
+		}
+	}
+
+	applicable := sets.New(types...)
+	for _, existing := range slices.Clone(*conditions) {
+		if !applicable.Has(existing.Type) {
+			meta.RemoveStatusCondition(conditions, existing.Type)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func conditionStatuses(conditions []metav1.Condition) map[string]metav1.ConditionStatus {
+	statuses := make(map[string]metav1.ConditionStatus, len(conditions))
+	for _, condition := range conditions {
+		statuses[condition.Type] = condition.Status
+	}
+	return statuses
+}
+
+func TestSetVolumeGroupConditions(t *testing.T) {
+	vg := &v1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1", Generation: 2},
+		Spec: v1alpha1.LVMVolumeGroupSpec{
+			DeviceSelector: &v1alpha1.DeviceSelector{ForceWipeDevicesAndDestroyAllData: ptr.To(true)},
+			ThinPoolConfig: &v1alpha1.ThinPoolConfig{Name: "pool"},
+		},
+	}
+
+	var conditions []metav1.Condition
+	setVolumeGroupConditions(&conditions, vg, failedCondition(v1alpha1.VolumeGroupCreated, errors.New("vgcreate failed")))
+	assert.Equal(t, map[string]metav1.ConditionStatus{
+		v1alpha1.DevicesWiped:       metav1.ConditionTrue,
+		v1alpha1.DevicesDiscovered:  metav1.ConditionTrue,
+		v1alpha1.VolumeGroupCreated: metav1.ConditionFalse,
+		v1alpha1.ThinPoolReady:      metav1.ConditionUnknown,
+		v1alpha1.LVMDConfigApplied:  metav1.ConditionUnknown,
+	}, conditionStatuses(conditions), "steps after the failed one must be unknown")
+
+	failed := meta.FindStatusCondition(conditions, v1alpha1.VolumeGroupCreated)
+	assert.Equal(t, ReasonVolumeGroupFailed, failed.Reason)
+	assert.Equal(t, "vgcreate failed", failed.Message)
+	assert.Equal(t, int64(2), failed.ObservedGeneration)
+
+	setVolumeGroupConditions(&conditions, vg, nil)
+	for _, condition := range conditions {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status, "%s must be true once all steps succeeded", condition.Type)
+	}
+
+	vg.Generation = 3
+	setVolumeGroupConditions(&conditions, vg, failedCondition(v1alpha1.DevicesDiscovered, errors.New("device missing")))
+	lvmdConfigApplied := meta.FindStatusCondition(conditions, v1alpha1.LVMDConfigApplied)
+	assert.Equal(t, metav1.ConditionTrue, lvmdConfigApplied.Status, "steps after the failed one must be kept")
+	assert.Equal(t, int64(2), lvmdConfigApplied.ObservedGeneration, "steps after the failed one were not observed again")
+
+	vg.Spec.DeviceSelector = nil
+	vg.Spec.ThinPoolConfig = nil
+	setVolumeGroupConditions(&conditions, vg, nil)
+	assert.Len(t, conditions, 3, "conditions that no longer apply must be removed")
+	assert.Nil(t, meta.FindStatusCondition(conditions, v1alpha1.DevicesWiped))
+	assert.Nil(t, meta.FindStatusCondition(conditions, v1alpha1.ThinPoolReady))
+}
+
+func TestSetVolumeGroupStatusConditions(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	vg := &v1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "test", UID: "uid", Generation: 1}}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&v1alpha1.LVMVolumeGroupNodeStatus{}).Build(),
+		Scheme:    scheme,
+		NodeName:  "test-node",
+		Namespace: "test",
+	}
+	vgs := []lvm.VolumeGroup{{Name: "vg1", PVs: []lvm.PhysicalVolume{{PvName: "/dev/sda"}}}}
+
+	_, err := r.setVolumeGroupFailedStatus(ctx, vg, nil, FilteredBlockDevices{}, v1alpha1.DevicesDiscovered, errors.New("no devices"))
+	require.NoError(t, err)
+
+	nodeStatus := r.getLVMVolumeGroupNodeStatus()
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+	require.Len(t, nodeStatus.Status.VolumeGroups, 1)
+	assert.Equal(t, "vg1", nodeStatus.Status.VolumeGroups[0].Name)
+	assert.True(t, meta.IsStatusConditionFalse(nodeStatus.Status.VolumeGroups[0].Conditions, v1alpha1.DevicesDiscovered))
+
+	_, err = r.setVolumeGroupReadyStatus(ctx, vg, vgs, FilteredBlockDevices{})
+	require.NoError(t, err)
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+	require.Len(t, nodeStatus.Status.VolumeGroups, 1)
+	assert.True(t, meta.IsStatusConditionTrue(nodeStatus.Status.VolumeGroups[0].Conditions, v1alpha1.LVMDConfigApplied))
+
+	require.NoError(t, r.removeVolumeGroupStatus(ctx, vg))
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+	assert.Empty(t, nodeStatus.Status.VolumeGroups, "conditions must be removed together with the volume group")
+}
+
+func TestStampHeartbeat(t *testing.T) {
+	now := time.Now()
+	vg := &v1alpha1.VolumeGroupConditions{Name: "vg1"}
+
+	stampHeartbeat(vg, now)
+	require.NotNil(t, vg.LastReconcileTime)
+	assert.Equal(t, now, vg.LastReconcileTime.Time)
+	assert.Equal(t, version.Get(), vg.VGManagerVersion)
+
+	stampHeartbeat(vg, now.Add(DefaultHeartbeatInterval/2))
+	assert.Equal(t, now, vg.LastReconcileTime.Time, "the heartbeat must not be refreshed within the interval")
+
+	stampHeartbeat(vg, now.Add(DefaultHeartbeatInterval))
+	assert.Equal(t, now.Add(DefaultHeartbeatInterval), vg.LastReconcileTime.Time, "the heartbeat must be refreshed after the interval")
+}
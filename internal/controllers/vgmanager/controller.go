@@ -32,12 +32,16 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvmd"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/partition"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/wipefs"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,6 +53,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -76,12 +81,14 @@ const (
 	EventReasonErrorThinPoolCreateOrExtendFailed EventReasonError = "ThinPoolCreateOrExtendFailed"
 	EventReasonErrorDevicePathCheckFailed        EventReasonError = "DevicePathCheckFailed"
 	EventReasonErrorDeviceRemovalFailed          EventReasonError = "DeviceRemovalFailed"
+	EventReasonErrorPartitionCreationFailed      EventReasonError = "PartitionCreationFailed"
 	EventReasonLVMDConfigMissing                 EventReasonInfo  = "LVMDConfigMissing"
 	EventReasonLVMDConfigUpdated                 EventReasonInfo  = "LVMDConfigUpdated"
 	EventReasonLVMDConfigDeleted                 EventReasonInfo  = "LVMDConfigDeleted"
 	EventReasonVolumeGroupReady                  EventReasonInfo  = "VolumeGroupReady"
 	EventReasonDeviceRemoved                     EventReasonInfo  = "DeviceRemoved"
 	EventReasonErrorManualCleanupRequired        EventReasonError = "ManualCleanupRequired"
+	EventReasonErrorVolumeGroupOrphaned          EventReasonError = "VolumeGroupOrphaned"
 )
 
 var reconcileAgain = ctrl.Result{Requeue: true, RequeueAfter: reconcileInterval}
@@ -89,12 +96,35 @@ var reconcileAgain = ctrl.Result{Requeue: true, RequeueAfter: reconcileInterval}
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&lvmv1alpha1.LVMVolumeGroup{}).
+		For(&lvmv1alpha1.LVMVolumeGroup{}, builder.WithPredicates(ignoreStatusUpdatesPredicate)).
 		Owns(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}, builder.MatchEveryOwner, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(controller.Options{SkipNameValidation: ptr.To(true)}).
 		Complete(r)
 }
 
+// ignoreStatusUpdatesPredicate filters out updates of the LVMVolumeGroup that only change its status.
+// The status is aggregated by the operator from the LVMVolumeGroupNodeStatus of all nodes, so reacting to it
+// would make every vg-manager reconcile whenever the volume group changes on any node.
+var ignoreStatusUpdatesPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldVG, ok := e.ObjectOld.(*lvmv1alpha1.LVMVolumeGroup)
+		if !ok {
+			return true
+		}
+		newVG, ok := e.ObjectNew.(*lvmv1alpha1.LVMVolumeGroup)
+		if !ok {
+			return true
+		}
+		oldVG, newVG = oldVG.DeepCopy(), newVG.DeepCopy()
+		for _, vg := range []*lvmv1alpha1.LVMVolumeGroup{oldVG, newVG} {
+			vg.Status = lvmv1alpha1.LVMVolumeGroupStatus{}
+			vg.SetResourceVersion("")
+			vg.SetManagedFields(nil)
+		}
+		return !equality.Semantic.DeepEqual(oldVG, newVG)
+	},
+}
+
 type Reconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
@@ -104,6 +134,9 @@ type Reconciler struct {
 	lsblk.LSBLK
 	wipefs.Wipefs
 	dmsetup.Dmsetup
+	partition.Partitioner
+	// SMART collects the health of the devices on the node. The health is not collected if it is not set.
+	SMART            smart.SMART
 	NodeName         string
 	Namespace        string
 	Filters          filter.FilterSetup
@@ -132,6 +165,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, fmt.Errorf("failed to match nodeSelector to node labels: %w", err)
 	}
 	if !nodeMatches {
+		// the volume group was set up on this node before its node selector changed
+		if controllerutil.ContainsFinalizer(volumeGroup, r.getFinalizer()) {
+			return r.processDeselectedNode(ctx, volumeGroup)
+		}
 		// Nothing to be done on this node for the VG.
 		logger.Info("node labels do not match the selector", "VGName", volumeGroup.Name)
 		return ctrl.Result{}, nil
@@ -142,7 +179,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, fmt.Errorf("could not get LVMVolumeGroupNodeStatus: %w", err)
 	}
 
-	return r.reconcile(ctx, volumeGroup, resolver)
+	result, err := r.reconcile(ctx, volumeGroup, resolver)
+	if volumeGroup.DeletionTimestamp.IsZero() || err != nil {
+		metrics.ObserveReconcile(volumeGroup.GetName(), err)
+	} else {
+		// the volume group was removed from the node
+		metrics.DeleteVolumeGroup(volumeGroup.GetName())
+	}
+	return result, err
 }
 
 func (r *Reconciler) reconcile(
@@ -174,11 +218,30 @@ func (r *Reconciler) reconcile(
 	logger.V(1).Info("block devices", "blockDevices", blockDevices)
 
 	if updated, err := r.wipeDevices(ctx, volumeGroup, blockDevices, resolver); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to wipe devices: %w", err)
+		err := fmt.Errorf("failed to wipe devices: %w", err)
+		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, lvmv1alpha1.DevicesWiped, err); err != nil {
+			logger.Error(err, "failed to set status to failed")
+		}
+		return ctrl.Result{}, err
 	} else if updated {
 		return ctrl.Result{}, r.Update(ctx, volumeGroup)
 	}
 
+	if created, err := r.carvePartitions(ctx, volumeGroup, blockDevices, resolver); err != nil {
+		err := fmt.Errorf("failed to partition free space of devices: %w", err)
+		r.WarningEvent(ctx, volumeGroup, EventReasonErrorPartitionCreationFailed, err)
+		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, lvmv1alpha1.DevicesDiscovered, err); err != nil {
+			logger.Error(err, "failed to set status to failed")
+		}
+		return ctrl.Result{}, err
+	} else if created {
+		// refresh the block devices so that the new partitions are considered
+		if blockDevices, err = r.ListBlockDevices(ctx); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to list block devices: %w", err)
+		}
+	}
+	carvedPartitions := carvedPartitionPaths(volumeGroup, blockDevices, resolver)
+
 	pvs, err := r.ListPVs(ctx, "")
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("physical volumes could not be fetched: %w", err)
@@ -190,16 +253,21 @@ func (r *Reconciler) reconcile(
 	}
 	logger.V(1).Info("block device infos", "bdi", bdi)
 
+	health := r.collectDeviceHealth(ctx, blockDevices)
+
 	devices := filterDevices(ctx, blockDevices, resolver, r.Filters(ctx, &filter.Options{
-		BDI: bdi,
-		PVs: pvs,
-		VG:  volumeGroup,
+		BDI:    bdi,
+		PVs:    pvs,
+		VG:     volumeGroup,
+		Health: health,
 	}))
+	devices.Health = health
 
 	if volumeGroup.Spec.DeviceSelector != nil {
-		if err := VerifyMandatoryDevicePaths(devices, resolver, volumeGroup.Spec.DeviceSelector.Paths); err != nil {
+		mandatoryPaths := withCarvedPartitions(volumeGroup.Spec.DeviceSelector.Paths, carvedPartitions, resolver)
+		if err := VerifyMandatoryDevicePaths(devices, resolver, mandatoryPaths); err != nil {
 			r.WarningEvent(ctx, volumeGroup, EventReasonErrorDevicePathCheckFailed, err)
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.DevicesDiscovered, err); err != nil {
 				logger.Error(err, "failed to set status to failed")
 			}
 			return ctrl.Result{}, err
@@ -232,6 +300,8 @@ func (r *Reconciler) reconcile(
 		devices.Available = nil
 	}
 
+	metrics.SetDevices(volumeGroup.Name, len(devices.Available), len(devices.Excluded), usedDeviceCount(vgs, volumeGroup.Name))
+
 	// If there are no available devices, that could mean either
 	// - There is no available devices to attach to the volume group
 	// - All the available devices are already attached
@@ -247,15 +317,15 @@ func (r *Reconciler) reconcile(
 			err := fmt.Errorf("the volume group %s does not exist (or was not tagged properly with %q), "+
 				"and there were no available devices to create it", volumeGroup.GetName(), lvm.DefaultTag)
 			r.WarningEvent(ctx, volumeGroup, EventReasonErrorNoAvailableDevicesForVG, err)
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.DevicesDiscovered, err); err != nil {
 				logger.Error(err, "failed to set status to failed")
 			}
 			return ctrl.Result{}, err
 		}
 
-		deleted, err := r.deleteRemovedDevices(ctx, lvmVG, volumeGroup, resolver)
+		deleted, err := r.deleteRemovedDevices(ctx, lvmVG, volumeGroup, resolver, carvedPartitions)
 		if err != nil {
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.VolumeGroupCreated, err); err != nil {
 				logger.Error(err, "failed to set status to failed")
 			}
 			return ctrl.Result{}, fmt.Errorf("failed to remove devices: %w", err)
@@ -277,7 +347,7 @@ func (r *Reconciler) reconcile(
 			if err := r.validateLVs(ctx, volumeGroup); err != nil {
 				err := fmt.Errorf("error while validating logical volumes in existing volume group: %w", err)
 				r.WarningEvent(ctx, volumeGroup, EventReasonErrorInconsistentLVs, err)
-				if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+				if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.ThinPoolReady, err); err != nil {
 					logger.Error(err, "failed to set status to failed")
 				}
 				return ctrl.Result{}, err
@@ -312,7 +382,7 @@ func (r *Reconciler) reconcile(
 	if err = r.addDevicesToVG(ctx, vgs, volumeGroup.Name, devices.Available, r.shouldWipeDevicesOnVolumeGroup(volumeGroup)); err != nil {
 		err = fmt.Errorf("failed to create/extend volume group %s: %w", volumeGroup.Name, err)
 		r.WarningEvent(ctx, volumeGroup, EventReasonErrorVGCreateOrExtendFailed, err)
-		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.VolumeGroupCreated, err); err != nil {
 			logger.Error(err, "failed to set status to failed")
 		}
 		return ctrl.Result{}, err
@@ -323,7 +393,7 @@ func (r *Reconciler) reconcile(
 		if err = r.addThinPoolToVG(ctx, volumeGroup.Name, volumeGroup.Spec.ThinPoolConfig); err != nil {
 			err := fmt.Errorf("failed to create thin pool %s for volume group %s: %w", volumeGroup.Spec.ThinPoolConfig.Name, volumeGroup.Name, err)
 			r.WarningEvent(ctx, volumeGroup, EventReasonErrorThinPoolCreateOrExtendFailed, err)
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.ThinPoolReady, err); err != nil {
 				logger.Error(err, "failed to set status to failed")
 			}
 			return ctrl.Result{}, err
@@ -332,7 +402,7 @@ func (r *Reconciler) reconcile(
 		if err := r.validateLVs(ctx, volumeGroup); err != nil {
 			err := fmt.Errorf("error while validating logical volumes in existing volume group: %w", err)
 			r.WarningEvent(ctx, volumeGroup, EventReasonErrorInconsistentLVs, err)
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.ThinPoolReady, err); err != nil {
 				logger.Error(err, "failed to set status to failed")
 			}
 			return ctrl.Result{}, err
@@ -364,7 +434,7 @@ func (r *Reconciler) reconcile(
 func (r *Reconciler) determineFinishedRequeue(volumeGroup *lvmv1alpha1.LVMVolumeGroup, effectivePolicy lvmv1alpha1.DeviceDiscoveryPolicySpec) ctrl.Result {
 	// With explicit paths, no periodic requeue is needed — the paths define
 	// the exact set of devices. Changes to paths trigger reconciliation via
-	// the LVMVolumeGroup watch.
+	// the LVMVolumeGroup watch. The heartbeat is refreshed by the Heartbeat runnable.
 	if hasExplicitDevicePaths(volumeGroup) {
 		return ctrl.Result{}
 	}
@@ -385,6 +455,16 @@ func hasExplicitDevicePaths(vg *lvmv1alpha1.LVMVolumeGroup) bool {
 		(len(vg.Spec.DeviceSelector.Paths) > 0 || len(vg.Spec.DeviceSelector.OptionalPaths) > 0)
 }
 
+// usedDeviceCount returns the number of physical volumes in the volume group with the given name.
+func usedDeviceCount(vgs []lvm.VolumeGroup, name string) int {
+	for _, vg := range vgs {
+		if vg.Name == name {
+			return len(vg.PVs)
+		}
+	}
+	return 0
+}
+
 func (r *Reconciler) applyLVMDConfig(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup, devices FilteredBlockDevices) error {
 	logger := log.FromContext(ctx).WithValues("VGName", volumeGroup.Name)
 
@@ -392,7 +472,7 @@ func (r *Reconciler) applyLVMDConfig(ctx context.Context, volumeGroup *lvmv1alph
 	lvmdConfig, err := r.LVMD.Load(ctx)
 	if err != nil {
 		err = fmt.Errorf("failed to read the lvmd config file: %w", err)
-		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.LVMDConfigApplied, err); err != nil {
 			logger.Error(err, "failed to set status to failed")
 		}
 		return err
@@ -440,7 +520,7 @@ func (r *Reconciler) applyLVMDConfig(ctx context.Context, volumeGroup *lvmv1alph
 	}
 
 	if err := r.updateLVMDConfigAfterReconcile(ctx, volumeGroup, oldConfig, lvmdConfig, lvmdConfigWasMissing); err != nil {
-		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, err); err != nil {
+		if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, devices, lvmv1alpha1.LVMDConfigApplied, err); err != nil {
 			logger.Error(err, "failed to set status to failed")
 		}
 		return err
@@ -581,7 +661,7 @@ func (r *Reconciler) processDelete(ctx context.Context, volumeGroup *lvmv1alpha1
 			if thinPoolExists {
 				if err := r.DeleteLV(ctx, thinPoolName, volumeGroup.Name); err != nil {
 					err := fmt.Errorf("failed to delete thin pool %s in volume group %s: %w", thinPoolName, volumeGroup.Name, err)
-					if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, err); err != nil {
+					if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, lvmv1alpha1.ThinPoolReady, err); err != nil {
 						logger.Error(err, "failed to set status to failed")
 					}
 					return err
@@ -594,7 +674,7 @@ func (r *Reconciler) processDelete(ctx context.Context, volumeGroup *lvmv1alpha1
 
 		if err = r.DeleteVG(ctx, existingVG); err != nil {
 			err := fmt.Errorf("failed to delete volume group %s: %w", volumeGroup.Name, err)
-			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, err); err != nil {
+			if _, err := r.setVolumeGroupFailedStatus(ctx, volumeGroup, vgs, FilteredBlockDevices{}, lvmv1alpha1.VolumeGroupCreated, err); err != nil {
 				logger.Error(err, "failed to set status to failed", "VGName", volumeGroup.GetName())
 			}
 			return err
@@ -755,6 +835,7 @@ func (r *Reconciler) deleteRemovedDevices(
 	currentVG *lvm.VolumeGroup,
 	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
 	resolver *symlinkResolver.Resolver,
+	carvedPartitions map[string]string,
 ) (bool, error) {
 	logger := log.FromContext(ctx).WithValues("VGName", volumeGroup.Name)
 
@@ -774,6 +855,10 @@ func (r *Reconciler) deleteRemovedDevices(
 	if err != nil {
 		return false, err
 	}
+	// partitions carved out of selected devices belong to the device selector as well
+	for _, partitionPath := range carvedPartitions {
+		userProvidedMappings = append(userProvidedMappings, partitionPath)
+	}
 
 	devicesToRemove := make([]string, 0)
 
@@ -813,7 +898,9 @@ func (r *Reconciler) deleteRemovedDevices(
 
 	// Remove devices directly from VG
 	for _, devicePath := range devicesToRemove {
-		if err = r.ReduceVG(ctx, volumeGroup.Name, devicePath); err != nil {
+		err = r.ReduceVG(ctx, volumeGroup.Name, devicePath)
+		metrics.ObserveDeviceRemoval(volumeGroup.Name, err)
+		if err != nil {
 			r.WarningEvent(ctx, volumeGroup, EventReasonErrorDeviceRemovalFailed, err)
 			return false, fmt.Errorf("failed to remove device %s from VG %s: %w", devicePath, volumeGroup.Name, err)
 		}
@@ -927,6 +1014,8 @@ func (r *Reconciler) matchesThisNode(ctx context.Context, selector *corev1.NodeS
 
 // WarningEvent sends an event to both the nodeStatus, and the affected processed volumeGroup as well as the owning LVMCluster if present
 func (r *Reconciler) WarningEvent(ctx context.Context, obj *lvmv1alpha1.LVMVolumeGroup, reason EventReasonError, errMsg error) {
+	metrics.ReconcileErrorsTotal.WithLabelValues(obj.GetName(), string(reason)).Inc()
+
 	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
 	nodeStatus.SetName(r.NodeName)
 	nodeStatus.SetNamespace(r.Namespace)
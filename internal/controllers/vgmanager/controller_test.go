@@ -145,7 +145,7 @@ var _ = Describe("vgmanager controller", func() {
 		})
 		It("should return true with error when Get fails", func(ctx SpecContext) {
 			errClient := interceptor.NewClient(
-				fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build(),
 				interceptor.Funcs{
 					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 						if _, ok := obj.(*storagev1.StorageClass); ok {
@@ -366,7 +366,7 @@ func setupInstances() testInstances {
 		hostnameLabelKey: hostname,
 	}}}
 
-	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
 		WithObjects(node, namespace).
 		Build()
 	fakeRecorder := events.NewFakeRecorder(100)
@@ -536,6 +536,7 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 				VgName:          vg.GetName(),
 				LvAttr:          "twi---tz--",
 				LvSize:          "1.0G",
+				DataPercent:     "0.00",
 				MetadataPercent: "10.0",
 				ChunkSize:       strconv.FormatInt(ptr.To(resource.MustParse("128Ki")).Value(), 10),
 				MetadataSize:    strconv.FormatInt(ptr.To(resource.MustParse("128Mi")).Value(), 10),
@@ -543,11 +544,13 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 			createdVG = lvm.VolumeGroup{
 				Name:   vg.GetName(),
 				VgSize: thinPool.LvSize,
+				VgFree: "0",
 				PVs:    []lvm.PhysicalVolume{lvmPV},
 			}
+			// validateLVs and the thin pool status
 			instances.LVM.EXPECT().ListLVs(ctx, vg.GetName()).Return(&lvm.LVReport{Report: []lvm.LVReportItem{{
 				Lv: []lvm.LogicalVolume{thinPool},
-			}}}, nil).Once()
+			}}}, nil).Twice()
 			instances.LVM.EXPECT().ActivateLV(ctx, thinPool.Name, vg.GetName()).Return(nil).Once()
 		})
 	} else {
@@ -555,11 +558,24 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 		createdVG = lvm.VolumeGroup{
 			Name:   vg.GetName(),
 			VgSize: "1.0G",
+			VgFree: "1.0G",
 			PVs:    []lvm.PhysicalVolume{lvmPV},
 		}
 	}
 	instances.LVM.EXPECT().ListVGs(ctx, true).Return([]lvm.VolumeGroup{createdVG}, nil).Once()
 
+	expectedPhysicalVolumes := []lvmv1alpha1.PhysicalVolumeStatus{{Name: device.Unresolved()}}
+	var expectedThinPool *lvmv1alpha1.ThinPoolStatus
+	if vg.Spec.ThinPoolConfig != nil {
+		expectedThinPool = &lvmv1alpha1.ThinPoolStatus{
+			Name:            thinPool.Name,
+			Size:            ptr.To(resource.MustParse(thinPool.LvSize)),
+			DataPercent:     thinPool.DataPercent,
+			MetadataPercent: thinPool.MetadataPercent,
+			ChunkSize:       ptr.To(resource.MustParse(thinPool.ChunkSize)),
+		}
+	}
+
 	By("triggering the next reconciliation after the creation of the thin pool", func() {
 		_, err := instances.Reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(vg)})
 		Expect(err).ToNot(HaveOccurred())
@@ -598,12 +614,16 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 		checkDistributedEvent(corev1.EventTypeNormal, "all the available devices are attached to the volume group")
 		Expect(instances.client.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus)).To(Succeed())
 		Expect(nodeStatus.Spec.LVMVGStatus).ToNot(BeEmpty())
-		Expect(nodeStatus.Spec.LVMVGStatus).To(ContainElement(lvmv1alpha1.VGStatus{
+		Expect(nodeStatus.Spec.LVMVGStatus).To(ContainElement(BeComparableTo(lvmv1alpha1.VGStatus{
 			Name:                  vg.GetName(),
 			Status:                lvmv1alpha1.VGStatusReady,
 			Devices:               []string{device.Unresolved()},
+			Size:                  ptr.To(resource.MustParse(createdVG.VgSize)),
+			Free:                  ptr.To(resource.MustParse(createdVG.VgFree)),
+			PhysicalVolumes:       expectedPhysicalVolumes,
+			ThinPool:              expectedThinPool,
 			DeviceDiscoveryPolicy: lvmv1alpha1.DeviceDiscoveryPolicyPreconfigured,
-		}))
+		})))
 		oldReadyGeneration = nodeStatus.GetGeneration()
 	})
 
@@ -649,7 +669,7 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 				Lv: []lvm.LogicalVolume{thinPool},
 			}}}
 			instances.LVM.EXPECT().ActivateLV(ctx, thinPool.Name, createdVG.Name).Return(nil).Once()
-			instances.LVM.EXPECT().ListLVs(ctx, vg.GetName()).Return(report, nil).Once()
+			instances.LVM.EXPECT().ListLVs(ctx, vg.GetName()).Return(report, nil).Twice()
 		})
 	}
 
@@ -688,13 +708,17 @@ func testVGWithLocalDevice(ctx context.Context, vgTemplate lvmv1alpha1.LVMVolume
 				},
 			}...)
 		}
-		Expect(nodeStatus.Spec.LVMVGStatus).To(ContainElement(lvmv1alpha1.VGStatus{
+		Expect(nodeStatus.Spec.LVMVGStatus).To(ContainElement(BeComparableTo(lvmv1alpha1.VGStatus{
 			Name:                  vg.GetName(),
 			Status:                lvmv1alpha1.VGStatusReady,
 			Devices:               []string{device.Unresolved()},
+			Size:                  ptr.To(resource.MustParse(createdVG.VgSize)),
+			Free:                  ptr.To(resource.MustParse(createdVG.VgFree)),
+			PhysicalVolumes:       expectedPhysicalVolumes,
+			ThinPool:              expectedThinPool,
 			Excluded:              excluded,
 			DeviceDiscoveryPolicy: lvmv1alpha1.DeviceDiscoveryPolicyPreconfigured,
-		}))
+		})))
 		Expect(oldReadyGeneration).To(Equal(nodeStatus.GetGeneration()))
 	})
 
@@ -782,7 +806,7 @@ func testNodeSelector(ctx context.Context) {
 	schema := scheme.Scheme
 	schema.AddKnownTypes(lvmv1alpha1.GroupVersion)
 
-	fakeClient := fake.NewClientBuilder().WithScheme(schema).
+	fakeClient := fake.NewClientBuilder().WithScheme(schema).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
 		WithObjects(matchingNode, notMatchingNode, volumeGroup, invalidVolumeGroup, nodeStatus).
 		Build()
 	r := &Reconciler{
@@ -808,7 +832,7 @@ func testNodeSelector(ctx context.Context) {
 		}
 		return client.Create(ctx, obj, opts...)
 	}}
-	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
 		WithObjects(matchingNode, notMatchingNode, volumeGroup, invalidVolumeGroup).
 		WithInterceptorFuncs(funcs).
 		Build()
@@ -822,7 +846,7 @@ func testNodeSelector(ctx context.Context) {
 	Expect(err).To(HaveOccurred(), "should error on valid node selector due to failure of nodestatus creation")
 	Expect(res).To(Equal(reconcile.Result{}))
 
-	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
 		WithObjects(matchingNode, notMatchingNode, volumeGroup, invalidVolumeGroup).
 		Build()
 	r = &Reconciler{
@@ -843,7 +867,7 @@ func testNodeSelector(ctx context.Context) {
 		}
 		return client.Get(ctx, key, obj, opts...)
 	}}
-	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+	fakeClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
 		WithObjects(matchingNode, notMatchingNode, volumeGroup, invalidVolumeGroup).
 		WithInterceptorFuncs(funcs).
 		Build()
@@ -883,7 +907,7 @@ func testEvents(ctx context.Context) {
 	gvk, _ := apiutil.GVKForObject(nodeStatus, scheme.Scheme)
 	nodeStatus.SetGroupVersionKind(gvk)
 
-	clnt := fake.NewClientBuilder().WithObjects(vg, nodeStatus).WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+	clnt := fake.NewClientBuilder().WithObjects(vg, nodeStatus).WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).WithInterceptorFuncs(interceptor.Funcs{
 		Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 			err := client.Get(ctx, key, obj, opts...)
 			if err == nil {
@@ -925,7 +949,7 @@ func testLVMD(ctx context.Context) {
 	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
 	devices := FilteredBlockDevices{}
 
-	r.Client = fake.NewClientBuilder().WithObjects(vg).WithScheme(scheme.Scheme).Build()
+	r.Client = fake.NewClientBuilder().WithObjects(vg).WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build()
 	mockLVMD := lvmdmocks.NewMockConfigurator(GinkgoT())
 	r.LVMD = mockLVMD
 	mockLVM := lvmmocks.NewMockLVM(GinkgoT())
@@ -1218,7 +1242,7 @@ func testLVMDConfigChange(ctx context.Context) {
 		},
 	}
 
-	r.Client = fake.NewClientBuilder().WithObjects(vg).WithScheme(scheme.Scheme).Build()
+	r.Client = fake.NewClientBuilder().WithObjects(vg).WithScheme(scheme.Scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build()
 
 	err := r.applyLVMDConfig(ctx, vg, nil, FilteredBlockDevices{})
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// collectDeviceHealth collects the health of the disks on the node and returns it by kernel name.
+// Partitions and other devices stacked on a disk share the health of the disk, except for multipath devices
+// which are backed by multiple disks. The health is informational unless a DeviceHealthPolicy is set,
+// so failures to collect it, e.g. for virtual disks without SMART support, are only logged.
+func (r *Reconciler) collectDeviceHealth(ctx context.Context, blockDevices []lsblk.BlockDevice) map[string]*smart.DeviceHealth {
+	if r.SMART == nil {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	health := make(map[string]*smart.DeviceHealth)
+	for _, device := range blockDevices {
+		if device.Type != lsblk.DeviceTypeDisk {
+			continue
+		}
+		deviceHealth, err := r.SMART.DeviceHealth(ctx, device.KName)
+		if err != nil {
+			logger.V(1).Info("could not collect the health of the device", "device", device.KName, "reason", err.Error())
+			continue
+		}
+		metrics.SetDeviceHealth(deviceHealthStatus(deviceHealth))
+		addDeviceHealth(health, device, deviceHealth)
+	}
+	return health
+}
+
+func addDeviceHealth(health map[string]*smart.DeviceHealth, device lsblk.BlockDevice, deviceHealth *smart.DeviceHealth) {
+	health[device.KName] = deviceHealth
+	for _, child := range device.Children {
+		if child.Type != lsblk.DeviceTypeMultipath {
+			addDeviceHealth(health, child, deviceHealth)
+		}
+	}
+}
+
+// deviceHealthStatus converts the health of a device to the health reported in the status.
+func deviceHealthStatus(health *smart.DeviceHealth) *lvmv1alpha1.DeviceHealth {
+	if health == nil {
+		return nil
+	}
+	return &lvmv1alpha1.DeviceHealth{
+		Device:             health.Device,
+		Healthy:            health.Passed,
+		ReallocatedSectors: health.ReallocatedSectors,
+		MediaErrors:        health.MediaErrors,
+		PercentageUsed:     health.PercentageUsed,
+		Temperature:        health.Temperature,
+	}
+}
@@ -0,0 +1,71 @@
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
+	smartmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCollectDeviceHealth(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	sdaHealth := &smart.DeviceHealth{Device: "/dev/sda", Passed: true, Temperature: ptr.To[int64](30)}
+	sdbHealth := &smart.DeviceHealth{Device: "/dev/sdb", Passed: false}
+	mockSMART := smartmocks.NewMockSMART(t)
+	mockSMART.EXPECT().DeviceHealth(mock.Anything, "/dev/sda").Return(sdaHealth, nil).Once()
+	mockSMART.EXPECT().DeviceHealth(mock.Anything, "/dev/sdb").Return(sdbHealth, nil).Once()
+	mockSMART.EXPECT().DeviceHealth(mock.Anything, "/dev/vda").Return(nil, errors.New("no SMART support")).Once()
+
+	r := &Reconciler{SMART: mockSMART}
+	health := r.collectDeviceHealth(ctx, []lsblk.BlockDevice{
+		{KName: "/dev/sda", Type: lsblk.DeviceTypeDisk, Children: []lsblk.BlockDevice{
+			{KName: "/dev/sda1", Type: lsblk.DeviceTypePart},
+			{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath},
+		}},
+		{KName: "/dev/sdb", Type: lsblk.DeviceTypeDisk, Children: []lsblk.BlockDevice{
+			{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath},
+		}},
+		{KName: "/dev/vda", Type: lsblk.DeviceTypeDisk},
+		{KName: "/dev/loop0", Type: lsblk.DeviceTypeLoop},
+	})
+
+	assert.Equal(t, map[string]*smart.DeviceHealth{
+		"/dev/sda":  sdaHealth,
+		"/dev/sda1": sdaHealth,
+		"/dev/sdb":  sdbHealth,
+	}, health, "partitions share the health of their disk, multipath devices and failed collections are skipped")
+
+	assert.Nil(t, (&Reconciler{}).collectDeviceHealth(ctx, []lsblk.BlockDevice{{KName: "/dev/sda", Type: lsblk.DeviceTypeDisk}}),
+		"the health must not be collected without SMART")
+}
+
+func TestSetDevicesHealth(t *testing.T) {
+	r := &Reconciler{}
+	status := &lvmv1alpha1.VGStatus{Name: "vg1"}
+	vgs := []lvm.VolumeGroup{{Name: "vg1", PVs: []lvm.PhysicalVolume{{PvName: "/dev/sda1"}, {PvName: "/dev/vda"}}}}
+	devices := FilteredBlockDevices{Health: map[string]*smart.DeviceHealth{
+		"/dev/sda1": {Device: "/dev/sda", Passed: true, ReallocatedSectors: ptr.To[int64](0)},
+	}}
+
+	_, err := r.setDevices(status, vgs, devices)
+	require.NoError(t, err)
+	require.Len(t, status.PhysicalVolumes, 2)
+	assert.Equal(t, &lvmv1alpha1.DeviceHealth{
+		Device:             "/dev/sda",
+		Healthy:            true,
+		ReallocatedSectors: ptr.To[int64](0),
+	}, status.PhysicalVolumes[0].Health)
+	assert.Nil(t, status.PhysicalVolumes[1].Health, "the health is only reported if it was collected")
+}
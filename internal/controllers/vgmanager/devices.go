@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/filter"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -76,6 +77,8 @@ type FilteredBlockDevice struct {
 type FilteredBlockDevices struct {
 	Available []lsblk.BlockDevice
 	Excluded  []FilteredBlockDevice
+	// Health is the health of the devices on the node by kernel name, see collectDeviceHealth
+	Health map[string]*smart.DeviceHealth
 }
 
 // VerifyMandatoryDevicePaths verifies if the provided device list is either available or already setup correctly.
@@ -26,7 +26,9 @@ import (
 	"io"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -56,10 +58,14 @@ func (e *CommandExecutor) RunCommandAsHost(ctx context.Context, command string,
 // CombinedOutputCommandAsHost executes a command as host and returns an error if the command fails.
 // it finishes the run and the output will be printed to the log.
 func (e *CommandExecutor) CombinedOutputCommandAsHost(ctx context.Context, command string, arg ...string) ([]byte, error) {
+	name := command
 	command, arg = e.WrapCommandWithNSenter(command, arg...)
 	cmd := exec.CommandContext(ctx, command, arg...)
 	log.FromContext(ctx).Info("executing", "command", cmd.String())
-	return cmd.CombinedOutput()
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	metrics.ObserveCommand(name, time.Since(start), err)
+	return output, err
 }
 
 // RunCommandAsHostInto executes a command as host and returns an error if the command fails.
@@ -90,10 +96,17 @@ func (e *CommandExecutor) RunCommandAsHostInto(ctx context.Context, into any, co
 // The caller is responsible for closing the ReadCloser.
 // Not calling close on this method will result in a resource leak.
 func (e *CommandExecutor) StartCommandWithOutputAsHost(ctx context.Context, command string, arg ...string) (io.ReadCloser, error) {
+	name := command
 	command, arg = e.WrapCommandWithNSenter(command, arg...)
 	cmd := exec.CommandContext(ctx, command, arg...)
 	log.FromContext(ctx).Info("executing", "command", cmd.String())
-	return runCommandWithOutput(cmd)
+	start := time.Now()
+	output, err := runCommandWithOutput(cmd)
+	if err != nil {
+		metrics.ObserveCommand(name, time.Since(start), err)
+		return nil, err
+	}
+	return observedReadCloser{ReadCloser: output, command: name, start: start}, nil
 }
 
 // WrapCommandWithNSenter wraps the command and arguments with nsenter arguments.
@@ -101,6 +114,20 @@ func (*CommandExecutor) WrapCommandWithNSenter(command string, arg ...string) (s
 	return nsEnterPath, append(append(nsEnterFlags, command), arg...)
 }
 
+// observedReadCloser records the duration of a command once its output is closed,
+// which is when the command has finished.
+type observedReadCloser struct {
+	io.ReadCloser
+	command string
+	start   time.Time
+}
+
+func (o observedReadCloser) Close() error {
+	err := o.ReadCloser.Close()
+	metrics.ObserveCommand(o.command, time.Since(o.start), err)
+	return err
+}
+
 type pipeClosingReadCloser struct {
 	pipeclose func() error
 	io.ReadCloser
@@ -26,6 +26,9 @@ import (
 	symlinkResolver "github.com/openshift/lvm-operator/v4/internal/controllers/symlink-resolver"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/partition"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -46,11 +49,15 @@ const (
 	noChildren                    = "noChildren"
 	usableDeviceType              = "usableDeviceType"
 	partOfDeviceSelector          = "partOfDeviceSelector"
+	notMultipathMember            = "notMultipathMember"
+	healthyDevice                 = "healthyDevice"
 )
 
 var (
 	ErrDeviceAlreadySetupCorrectly = errors.New("the device is already setup correctly and was filtered to avoid attempting recreation")
 	ErrLVMPartition                = errors.New("the device is a lvm partition and is excluded by default")
+	ErrMultipathMember             = errors.New("the device is a path of a multipath device and can only be used through the multipath device")
+	ErrUnhealthyDevice             = errors.New("the device is unhealthy and excluded by the device health policy")
 )
 
 var (
@@ -67,6 +74,9 @@ type Options struct {
 	VG  *lvmv1alpha1.LVMVolumeGroup
 	BDI lsblk.BlockDeviceInfos
 	PVs []lvm.PhysicalVolume
+	// Health is the health of the devices by kernel name. Partitions and other child devices
+	// share the health of the disk they are part of.
+	Health map[string]*smart.DeviceHealth
 }
 
 type FilterSetup func(context.Context, *Options) Filters
@@ -95,6 +105,9 @@ func DefaultFilters(ctx context.Context, opts *Options) Filters {
 				// used the non-resolved path, e.g. /dev/disk/by-id/xyz
 				if resolved, err := resolver.Resolve(path.Unresolved()); resolved == dev.KName {
 					return nil
+				} else if resolved != "" && resolved == dev.PKName && isCarvedPartition(dev, opts.VG) {
+					// partitions carved out of the free space of a selected device belong to the selector
+					return nil
 				} else if err != nil {
 					logger.Error(err, "the path was no kernel block device name and could not be resolved via symlink resolution", "path", path)
 					continue
@@ -153,7 +166,7 @@ func DefaultFilters(ctx context.Context, opts *Options) Filters {
 					return nil
 				}
 
-				if foundPV.VgName == opts.VG.GetName() {
+				if foundPV.VgName != "" && foundPV.VgName == opts.VG.GetName() {
 					return fmt.Errorf("%s is already a LVM2_Member of %s: %w", dev.Name, opts.VG.GetName(), ErrDeviceAlreadySetupCorrectly)
 				} else if foundPV.VgName != "" {
 					return fmt.Errorf("%s is already a LVM2_Member of another volume group (%s) and cannot be used for the volume group %s",
@@ -183,6 +196,25 @@ func DefaultFilters(ctx context.Context, opts *Options) Filters {
 			return nil
 		},
 
+		notMultipathMember: func(dev lsblk.BlockDevice, _ *symlinkResolver.Resolver) error {
+			if mpath, ok := dev.MultipathDevice(); ok {
+				return fmt.Errorf("%s is a path of %s: %w", dev.Name, mpath.Name, ErrMultipathMember)
+			}
+			return nil
+		},
+
+		healthyDevice: func(dev lsblk.BlockDevice, _ *symlinkResolver.Resolver) error {
+			health, ok := opts.Health[dev.KName]
+			if opts.VG.Spec.DeviceHealthPolicy == nil || !ok {
+				// devices are only excluded by an explicit policy, and only if their health is known
+				return nil
+			}
+			if problems := healthProblems(health, opts.VG.Spec.DeviceHealthPolicy); len(problems) > 0 {
+				return fmt.Errorf("%s %s: %w", dev.Name, strings.Join(problems, ", "), ErrUnhealthyDevice)
+			}
+			return nil
+		},
+
 		usableDeviceType: func(dev lsblk.BlockDevice, _ *symlinkResolver.Resolver) error {
 			switch dev.Type {
 			case lsblk.DeviceTypeLoop:
@@ -199,3 +231,35 @@ func DefaultFilters(ctx context.Context, opts *Options) Filters {
 		},
 	}
 }
+
+// healthProblems returns the reasons why the device is unhealthy according to the policy.
+func healthProblems(health *smart.DeviceHealth, policy *lvmv1alpha1.DeviceHealthPolicy) []string {
+	var problems []string
+	if !health.Passed {
+		problems = append(problems, "failed the SMART overall-health self-assessment")
+	}
+	for _, threshold := range []struct {
+		name    string
+		value   *int64
+		maximum *int64
+	}{
+		{"reallocated sectors", health.ReallocatedSectors, policy.MaxReallocatedSectors},
+		{"media errors", health.MediaErrors, policy.MaxMediaErrors},
+		{"percentage used", health.PercentageUsed, policy.MaxPercentageUsed},
+	} {
+		if threshold.value != nil && threshold.maximum != nil && *threshold.value > *threshold.maximum {
+			problems = append(problems, fmt.Sprintf("has %d %s, more than the maximum of %d",
+				*threshold.value, threshold.name, *threshold.maximum))
+		}
+	}
+	return problems
+}
+
+// isCarvedPartition checks if the device is a partition that was created for the volume group
+// out of the free space of one of its selected devices.
+func isCarvedPartition(dev lsblk.BlockDevice, vg *lvmv1alpha1.LVMVolumeGroup) bool {
+	if vg.Spec.DeviceSelector == nil || !ptr.Deref(vg.Spec.DeviceSelector.PartitionFreeSpace, false) {
+		return false
+	}
+	return dev.Type == lsblk.DeviceTypePart && dev.PartLabel == partition.NameForVolumeGroup(vg.GetName())
+}
@@ -9,7 +9,9 @@ import (
 	symlinkResolver "github.com/openshift/lvm-operator/v4/internal/controllers/symlink-resolver"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/smart"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 type filterTestCase struct {
@@ -111,6 +113,26 @@ func TestIsUsableDeviceType(t *testing.T) {
 	}
 }
 
+func TestNotMultipathMember(t *testing.T) {
+	testcases := []filterTestCase{
+		{label: "tc path of multipath device", device: lsblk.BlockDevice{Name: "/dev/sdb", Type: "disk", Children: []lsblk.BlockDevice{
+			{Name: "/dev/mapper/mpatha", KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath},
+		}}, expectErr: true},
+		{label: "tc multipath device", device: lsblk.BlockDevice{Name: "/dev/mapper/mpatha", KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath}, expectErr: false},
+		{label: "tc partitioned disk", device: lsblk.BlockDevice{Name: "/dev/sdc", Type: "disk", Children: []lsblk.BlockDevice{
+			{Name: "/dev/sdc1", Type: lsblk.DeviceTypePart},
+		}}, expectErr: false},
+	}
+	for _, tc := range testcases {
+		err := DefaultFilters(context.Background(), nil)[notMultipathMember](tc.device, symlinkResolver.NewWithDefaultResolver())
+		if tc.expectErr {
+			assert.ErrorIs(t, err, ErrMultipathMember)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func TestNoBiosBootInPartLabel(t *testing.T) {
 	testcases := []filterTestCase{
 		{label: "tc 1", device: lsblk.BlockDevice{Name: "dev1", PartLabel: ""}, expectErr: false},
@@ -162,6 +184,33 @@ func TestPartOfDeviceSelector(t *testing.T) {
 			volumeGroupSpec: &lvmv1alpha1.LVMVolumeGroupSpec{},
 			assertErr:       assert.NoError,
 		},
+		{label: "match carved partition of selected device",
+			device: lsblk.BlockDevice{KName: "dev1p2", PKName: "dev1", Type: lsblk.DeviceTypePart, PartLabel: "lvms-vg1"},
+			volumeGroupSpec: &lvmv1alpha1.LVMVolumeGroupSpec{DeviceSelector: &lvmv1alpha1.DeviceSelector{
+				Paths:              []lvmv1alpha1.DevicePath{"dev1"},
+				PartitionFreeSpace: ptr.To(true),
+			}},
+			assertErr: assert.NoError,
+		},
+		{label: "no match for carved partition without partitioning enabled",
+			device: lsblk.BlockDevice{KName: "dev1p2", PKName: "dev1", Type: lsblk.DeviceTypePart, PartLabel: "lvms-vg1"},
+			volumeGroupSpec: &lvmv1alpha1.LVMVolumeGroupSpec{DeviceSelector: &lvmv1alpha1.DeviceSelector{
+				Paths: []lvmv1alpha1.DevicePath{"dev1"},
+			}},
+			assertErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "is not part of the device selector")
+			},
+		},
+		{label: "no match for foreign partition of selected device",
+			device: lsblk.BlockDevice{KName: "dev1p1", PKName: "dev1", Type: lsblk.DeviceTypePart, PartLabel: "root"},
+			volumeGroupSpec: &lvmv1alpha1.LVMVolumeGroupSpec{DeviceSelector: &lvmv1alpha1.DeviceSelector{
+				Paths:              []lvmv1alpha1.DevicePath{"dev1"},
+				PartitionFreeSpace: ptr.To(true),
+			}},
+			assertErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "is not part of the device selector")
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.label, func(t *testing.T) {
@@ -243,3 +292,59 @@ func TestOnlyValidFilesystemSignatures(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthyDevice(t *testing.T) {
+	health := map[string]*smart.DeviceHealth{
+		"/dev/sda":  {Device: "/dev/sda", Passed: true, ReallocatedSectors: ptr.To[int64](0)},
+		"/dev/sda1": {Device: "/dev/sda", Passed: true, ReallocatedSectors: ptr.To[int64](0)},
+		"/dev/sdb":  {Device: "/dev/sdb", Passed: false},
+		"/dev/sdc":  {Device: "/dev/sdc", Passed: true, ReallocatedSectors: ptr.To[int64](20)},
+		"/dev/nvme0n1": {
+			Device: "/dev/nvme0n1", Passed: true, MediaErrors: ptr.To[int64](0), PercentageUsed: ptr.To[int64](98),
+		},
+	}
+	policy := &lvmv1alpha1.DeviceHealthPolicy{
+		MaxReallocatedSectors: ptr.To[int64](10),
+		MaxPercentageUsed:     ptr.To[int64](90),
+	}
+
+	testcases := []struct {
+		label     string
+		device    string
+		policy    *lvmv1alpha1.DeviceHealthPolicy
+		assertErr assert.ErrorAssertionFunc
+	}{
+		{label: "healthy device", device: "/dev/sda", policy: policy, assertErr: assert.NoError},
+		{label: "partition of a healthy device", device: "/dev/sda1", policy: policy, assertErr: assert.NoError},
+		{label: "device without health", device: "/dev/sdd", policy: policy, assertErr: assert.NoError},
+		{label: "failing device without policy", device: "/dev/sdb", assertErr: assert.NoError},
+		{
+			label: "failing device", device: "/dev/sdb", policy: &lvmv1alpha1.DeviceHealthPolicy{},
+			assertErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnhealthyDevice) &&
+					assert.ErrorContains(t, err, "failed the SMART overall-health self-assessment")
+			},
+		},
+		{
+			label: "too many reallocated sectors", device: "/dev/sdc", policy: policy,
+			assertErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "has 20 reallocated sectors, more than the maximum of 10")
+			},
+		},
+		{label: "reallocated sectors without threshold", device: "/dev/sdc", policy: &lvmv1alpha1.DeviceHealthPolicy{}, assertErr: assert.NoError},
+		{
+			label: "worn out nvme device", device: "/dev/nvme0n1", policy: policy,
+			assertErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "has 98 percentage used, more than the maximum of 90")
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.label, func(t *testing.T) {
+			vg := &lvmv1alpha1.LVMVolumeGroup{Spec: lvmv1alpha1.LVMVolumeGroupSpec{DeviceHealthPolicy: tc.policy}}
+			err := DefaultFilters(context.Background(), &Options{VG: vg, Health: health})[healthyDevice](
+				lsblk.BlockDevice{Name: tc.device, KName: tc.device}, symlinkResolver.NewWithDefaultResolver())
+			tc.assertErr(t, err)
+		})
+	}
+}
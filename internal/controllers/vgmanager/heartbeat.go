@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/version"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultHeartbeatInterval is the interval at which vgmanager refreshes the time of the last reconciliation
+// of the volume groups in the LVMVolumeGroupNodeStatus, so that the operator can detect nodes on which
+// vgmanager stopped working.
+const DefaultHeartbeatInterval = 5 * time.Minute
+
+var _ manager.LeaderElectionRunnable = &Heartbeat{}
+
+// Heartbeat periodically refreshes the heartbeat of every volume group reported in the LVMVolumeGroupNodeStatus
+// of the node with a single status patch, so that volume groups which do not need to be reconciled again
+// are not reconciled only to keep their heartbeat fresh.
+type Heartbeat struct {
+	client.Client
+
+	NodeName  string
+	Namespace string
+
+	// Interval is the interval in which the heartbeat is refreshed
+	Interval time.Duration
+}
+
+// Start implements controller-runtime's manager.Runnable and refreshes the heartbeat until the context is done.
+func (h *Heartbeat) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("heartbeat")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(h.Interval)
+	defer ticker.Stop()
+	for {
+		if err := h.refresh(ctx, time.Now()); err != nil {
+			logger.Error(err, "failed to refresh the heartbeat")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's manager.LeaderElectionRunnable.
+func (h *Heartbeat) NeedLeaderElection() bool {
+	return false
+}
+
+func (h *Heartbeat) refresh(ctx context.Context, now time.Time) error {
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+	if err := h.Get(ctx, client.ObjectKey{Name: h.NodeName, Namespace: h.Namespace}, nodeStatus); err != nil {
+		// the node status is created with the first reconciliation of a volume group
+		return client.IgnoreNotFound(err)
+	}
+	if len(nodeStatus.Status.VolumeGroups) == 0 {
+		return nil
+	}
+
+	original := nodeStatus.DeepCopy()
+	for i := range nodeStatus.Status.VolumeGroups {
+		nodeStatus.Status.VolumeGroups[i].LastReconcileTime = &metav1.Time{Time: now}
+		nodeStatus.Status.VolumeGroups[i].VGManagerVersion = version.Get()
+	}
+	err := h.Status().Patch(ctx, nodeStatus, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+	if k8serrors.IsConflict(err) {
+		// the status was updated by a reconciliation in the meantime, the heartbeat is refreshed with the next tick
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to patch the heartbeat in the LVMVolumeGroupNodeStatus: %w", err)
+	}
+	return nil
+}
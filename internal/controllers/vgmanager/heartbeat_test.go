@@ -0,0 +1,62 @@
+package vgmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestHeartbeat_Refresh(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	now := time.Now().Truncate(time.Second)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+
+	t.Run("missing node status", func(t *testing.T) {
+		h := &Heartbeat{
+			Client:    fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build(),
+			NodeName:  "node1",
+			Namespace: "openshift-lvm-storage",
+		}
+		require.NoError(t, h.refresh(ctx, now))
+	})
+
+	t.Run("every volume group is refreshed", func(t *testing.T) {
+		old := metav1.NewTime(now.Add(-time.Hour))
+		nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openshift-lvm-storage"},
+			Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+				VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{
+					{Name: "vg1", LastReconcileTime: &old, VGManagerVersion: "old"},
+					{Name: "vg2"},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(nodeStatus).
+			WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).
+			Build()
+		h := &Heartbeat{Client: fakeClient, NodeName: "node1", Namespace: "openshift-lvm-storage"}
+		require.NoError(t, h.refresh(ctx, now))
+
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+		require.Len(t, nodeStatus.Status.VolumeGroups, 2)
+		for _, vg := range nodeStatus.Status.VolumeGroups {
+			require.NotNil(t, vg.LastReconcileTime, vg.Name)
+			assert.True(t, vg.LastReconcileTime.Time.Equal(now), vg.Name)
+			assert.Equal(t, version.Get(), vg.VGManagerVersion, vg.Name)
+		}
+	})
+}
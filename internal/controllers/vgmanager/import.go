@@ -0,0 +1,305 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	ImportControllerName = "vg-manager-import"
+
+	EventReasonLogicalVolumeImported     = "LogicalVolumeImported"
+	EventReasonLogicalVolumeImportFailed = "LogicalVolumeImportFailed"
+
+	// provisionedByAnnotation marks the PersistentVolume as provisioned by TopoLVM, so that the external-provisioner
+	// of TopoLVM deletes its logical volume according to the reclaim policy like for dynamically provisioned volumes.
+	provisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+
+	// importCheckInterval is the interval in which the adoption of the imported logical volume by TopoLVM is checked.
+	importCheckInterval = 5 * time.Second
+)
+
+// ImportReconciler imports existing logical volumes in the volume groups of the node for PersistentVolumeClaims
+// labelled with constants.ImportNodeLabel for the node and annotated with constants.ImportLogicalVolumeAnnotation.
+// It creates the TopoLVM LogicalVolume for the logical volume and a PersistentVolume that is pre-bound to the claim,
+// so that the data of the logical volume can be used without copying it.
+//
+// TopoLVM names the logical volume of a LogicalVolume after the UID of the LogicalVolume and adopts an existing
+// logical volume with that name. The LogicalVolume is therefore created without a node first, so that TopoLVM
+// ignores it until the logical volume was renamed after its UID.
+type ImportReconciler struct {
+	client.Client
+	events.EventRecorder
+	LVM lvm.LVM
+
+	NodeName  string
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ImportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ImportControllerName).
+		For(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetLabels()[constants.ImportNodeLabel] == r.NodeName
+		}))).
+		WithOptions(controller.Options{SkipNameValidation: ptr.To(true)}).
+		Complete(r)
+}
+
+func (r *ImportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, req.NamespacedName, pvc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	lvName := pvc.Annotations[constants.ImportLogicalVolumeAnnotation]
+	if pvc.Labels[constants.ImportNodeLabel] != r.NodeName || lvName == "" ||
+		pvc.DeletionTimestamp != nil || pvc.Status.Phase == corev1.ClaimBound {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.importLogicalVolume(ctx, pvc, lvName)
+	if err != nil {
+		r.Eventf(pvc, nil, corev1.EventTypeWarning, EventReasonLogicalVolumeImportFailed, "ImportLogicalVolume",
+			"Failed to import logical volume %s on node %s: %v", lvName, r.NodeName, err)
+	}
+	return result, err
+}
+
+func (r *ImportReconciler) importLogicalVolume(ctx context.Context, pvc *corev1.PersistentVolumeClaim, lvName string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("LVName", lvName)
+
+	if pvc.Spec.StorageClassName == nil {
+		return ctrl.Result{}, fmt.Errorf("the PersistentVolumeClaim has no StorageClass")
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, storageClass); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get StorageClass %s: %w", *pvc.Spec.StorageClassName, err)
+	}
+	if storageClass.Provisioner != constants.TopolvmCSIDriverName {
+		return ctrl.Result{}, fmt.Errorf("StorageClass %s is not provisioned by LVMS", storageClass.Name)
+	}
+	deviceClass := storageClass.Parameters[constants.DeviceClassKey]
+	volumeGroup := &lvmv1alpha1.LVMVolumeGroup{}
+	if err := r.Get(ctx, client.ObjectKey{Name: deviceClass, Namespace: r.Namespace}, volumeGroup); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get LVMVolumeGroup %s: %w", deviceClass, err)
+	}
+
+	// the PersistentVolume and the LogicalVolume are named like for dynamically provisioned volumes,
+	// so that only one of them can be created if the claim is provisioned concurrently
+	pvName := "pvc-" + string(pvc.UID)
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	err := r.Get(ctx, client.ObjectKey{Name: pvName}, logicalVolume)
+	if k8serrors.IsNotFound(err) {
+		lv, err := r.findLogicalVolume(ctx, volumeGroup, lvName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		size := parseLVMQuantity(lv.LvSize)
+		if size == nil {
+			return ctrl.Result{}, fmt.Errorf("invalid size %q of logical volume %s", lv.LvSize, lvName)
+		}
+		if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok && size.Cmp(requested) < 0 {
+			return ctrl.Result{}, fmt.Errorf("the logical volume of size %s is smaller than the requested %s", size, &requested)
+		}
+		logicalVolume = &topolvmv1.LogicalVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        pvName,
+				Annotations: map[string]string{constants.ImportLogicalVolumeAnnotation: lvName},
+			},
+			Spec: topolvmv1.LogicalVolumeSpec{
+				Name:        pvName,
+				DeviceClass: deviceClass,
+				Size:        *size,
+			},
+		}
+		if err := r.Create(ctx, logicalVolume); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create LogicalVolume %s: %w", pvName, err)
+		}
+		logger.Info("created LogicalVolume for the imported logical volume", "LogicalVolume", pvName)
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get LogicalVolume %s: %w", pvName, err)
+	}
+
+	if logicalVolume.Spec.NodeName == "" {
+		source := logicalVolume.Annotations[constants.ImportLogicalVolumeAnnotation]
+		if err := adoptLogicalVolume(ctx, r.Client, r.LVM, r.NodeName, volumeGroup.Name, source, logicalVolume); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if logicalVolume.Spec.NodeName != r.NodeName {
+		return ctrl.Result{}, fmt.Errorf("LogicalVolume %s belongs to node %s", logicalVolume.Name, logicalVolume.Spec.NodeName)
+	}
+	if logicalVolume.Status.VolumeID == "" {
+		logger.V(1).Info("waiting for TopoLVM to adopt the imported logical volume", "LogicalVolume", pvName)
+		return ctrl.Result{RequeueAfter: importCheckInterval}, nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pvName}, pv); k8serrors.IsNotFound(err) {
+		pv = r.persistentVolume(pvc, storageClass, logicalVolume)
+		if err := r.Create(ctx, pv); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create PersistentVolume %s: %w", pvName, err)
+		}
+		logger.Info("created PersistentVolume for the imported logical volume", "PersistentVolume", pvName)
+		r.Eventf(pvc, nil, corev1.EventTypeNormal, EventReasonLogicalVolumeImported, "ImportLogicalVolume",
+			"Imported logical volume %s on node %s as PersistentVolume %s.", lvName, r.NodeName, pvName)
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get PersistentVolume %s: %w", pvName, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// findLogicalVolume returns the logical volume in the volume group if it can be provisioned by the device class,
+// i.e. if it is a thin volume of the thin pool of a thin device class or a regular volume of a thick device class.
+func (r *ImportReconciler) findLogicalVolume(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup, lvName string) (*lvm.LogicalVolume, error) {
+	report, err := r.LVM.ListLVs(ctx, volumeGroup.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", volumeGroup.Name, err)
+	}
+	for _, item := range report.Report {
+		for _, lv := range item.Lv {
+			if lv.Name != lvName {
+				continue
+			}
+			if err := checkProvisionable(volumeGroup, lv); err != nil {
+				return nil, err
+			}
+			return &lv, nil
+		}
+	}
+	return nil, fmt.Errorf("logical volume %s does not exist in volume group %s", lvName, volumeGroup.Name)
+}
+
+// checkProvisionable returns an error if the logical volume could not have been provisioned by the device class.
+func checkProvisionable(volumeGroup *lvmv1alpha1.LVMVolumeGroup, lv lvm.LogicalVolume) error {
+	if volumeGroup.Spec.ThinPoolConfig != nil {
+		if lv.PoolName != volumeGroup.Spec.ThinPoolConfig.Name {
+			return fmt.Errorf("logical volume %s is not a thin volume of thin pool %s",
+				lv.Name, volumeGroup.Spec.ThinPoolConfig.Name)
+		}
+	} else if lv.PoolName != "" || strings.HasPrefix(lv.LvAttr, "t") {
+		return fmt.Errorf("logical volume %s is a thin volume or thin pool, but device class %s is thick",
+			lv.Name, volumeGroup.Name)
+	}
+	return nil
+}
+
+// adoptLogicalVolume renames the logical volume after the UID of the LogicalVolume and assigns the LogicalVolume
+// to the node, so that TopoLVM adopts the logical volume instead of creating a new one.
+func adoptLogicalVolume(
+	ctx context.Context,
+	c client.Client,
+	lvmClient lvm.LVM,
+	nodeName, vgName, lvName string,
+	logicalVolume *topolvmv1.LogicalVolume,
+) error {
+	target := string(logicalVolume.UID)
+
+	lvs, err := lvmClient.ListLVsByName(ctx, vgName)
+	if err != nil {
+		return fmt.Errorf("failed to list logical volumes in volume group %s: %w", vgName, err)
+	}
+	// the logical volume was already renamed if the LogicalVolume could not be updated afterwards
+	if !slices.Contains(lvs, target) {
+		if !slices.Contains(lvs, lvName) {
+			return fmt.Errorf("logical volume %s does not exist in volume group %s", lvName, vgName)
+		}
+		if err := lvmClient.RenameLV(ctx, lvName, vgName, target); err != nil {
+			return err
+		}
+		log.FromContext(ctx).Info("renamed the logical volume after its LogicalVolume", "LVName", lvName, "newName", target)
+	}
+
+	logicalVolume.Spec.NodeName = nodeName
+	if err := c.Update(ctx, logicalVolume); err != nil {
+		return fmt.Errorf("failed to assign LogicalVolume %s to the node: %w", logicalVolume.Name, err)
+	}
+	return nil
+}
+
+// persistentVolume returns the PersistentVolume for the imported logical volume, pre-bound to the claim.
+func (r *ImportReconciler) persistentVolume(pvc *corev1.PersistentVolumeClaim, storageClass *storagev1.StorageClass, logicalVolume *topolvmv1.LogicalVolume) *corev1.PersistentVolume {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        logicalVolume.Name,
+			Annotations: map[string]string{provisionedByAnnotation: constants.TopolvmCSIDriverName},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: logicalVolume.Spec.Size},
+			AccessModes:                   pvc.Spec.AccessModes,
+			VolumeMode:                    pvc.Spec.VolumeMode,
+			StorageClassName:              storageClass.Name,
+			MountOptions:                  storageClass.MountOptions,
+			PersistentVolumeReclaimPolicy: ptr.Deref(storageClass.ReclaimPolicy, corev1.PersistentVolumeReclaimDelete),
+			ClaimRef: &corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Namespace:  pvc.Namespace,
+				Name:       pvc.Name,
+				UID:        pvc.UID,
+			},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       constants.TopolvmCSIDriverName,
+					VolumeHandle: logicalVolume.Status.VolumeID,
+				},
+			},
+			NodeAffinity: nodeAffinity(r.NodeName),
+		},
+	}
+	if ptr.Deref(pvc.Spec.VolumeMode, corev1.PersistentVolumeFilesystem) == corev1.PersistentVolumeFilesystem {
+		pv.Spec.CSI.FSType = storageClass.Parameters[constants.FsTypeKey]
+	}
+	return pv
+}
+
+// nodeAffinity returns the node affinity of the PersistentVolumes of TopoLVM on the node.
+func nodeAffinity(nodeName string) *corev1.VolumeNodeAffinity {
+	return &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      constants.TopologyNodeKey,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{nodeName},
+			}},
+		}},
+	}}
+}
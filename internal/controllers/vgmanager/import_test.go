@@ -0,0 +1,183 @@
+package vgmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func newImportReconciler(t *testing.T, thinPool bool, objs ...client.Object) (*ImportReconciler, *lvmmocks.MockLVM, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "openshift-lvm-storage"}}
+	if thinPool {
+		vg.Spec.ThinPoolConfig = &lvmv1alpha1.ThinPoolConfig{Name: "thin-pool-1"}
+	}
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:    metav1.ObjectMeta{Name: "lvms-vg1"},
+		Provisioner:   constants.TopolvmCSIDriverName,
+		Parameters:    map[string]string{constants.DeviceClassKey: "vg1", constants.FsTypeKey: "xfs"},
+		ReclaimPolicy: ptr.To(corev1.PersistentVolumeReclaimRetain),
+	}
+	recorder := events.NewFakeRecorder(10)
+	mockLVM := lvmmocks.NewMockLVM(t)
+	return &ImportReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(append(objs, vg, storageClass)...).
+			WithStatusSubresource(&topolvmv1.LogicalVolume{}).
+			WithInterceptorFuncs(interceptor.Funcs{Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				// the fake client does not assign UIDs like the API server
+				obj.SetUID(types.UID("8d0c2e1b-7f4a-4c2e-9a51-6b3d2f0e4c19"))
+				return c.Create(ctx, obj, opts...)
+			}}).Build(),
+		EventRecorder: recorder,
+		LVM:           mockLVM,
+		NodeName:      "node1",
+		Namespace:     "openshift-lvm-storage",
+	}, mockLVM, recorder
+}
+
+func importClaim() *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "data",
+			Namespace:   "app",
+			UID:         "3a1c4bf6-0c1e-4a36-9c6e-2f4f1b0d3e7a",
+			Labels:      map[string]string{constants.ImportNodeLabel: "node1"},
+			Annotations: map[string]string{constants.ImportLogicalVolumeAnnotation: "restored"},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("lvms-vg1"),
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+}
+
+func TestImportLogicalVolume(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	pvc := importClaim()
+	r, mockLVM, recorder := newImportReconciler(t, true, pvc)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pvc)}
+	pvName := "pvc-" + string(pvc.UID)
+
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-1", VgName: "vg1", LvAttr: "twi-aotz--", LvSize: "10737418240"},
+		{Name: "restored", VgName: "vg1", PoolName: "thin-pool-1", LvAttr: "Vwi-a-tz--", LvSize: "2147483648"},
+	}}}}, nil).Once()
+	mockLVM.EXPECT().ListLVsByName(mock.Anything, "vg1").Return([]string{"thin-pool-1", "restored"}, nil).Once()
+	var renamed string
+	mockLVM.EXPECT().RenameLV(mock.Anything, "restored", "vg1", mock.Anything).RunAndReturn(
+		func(ctx context.Context, lvName, vgName, newName string) error {
+			renamed = newName
+			return nil
+		}).Once()
+
+	result, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter, "the adoption of the logical volume by TopoLVM must be awaited")
+
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: pvName}, logicalVolume))
+	assert.Equal(t, string(logicalVolume.UID), renamed, "the logical volume must be named after the LogicalVolume")
+	assert.Equal(t, "node1", logicalVolume.Spec.NodeName)
+	assert.Equal(t, "vg1", logicalVolume.Spec.DeviceClass)
+	assert.Equal(t, int64(2147483648), logicalVolume.Spec.Size.Value())
+
+	// TopoLVM adopts the renamed logical volume
+	logicalVolume.Status.VolumeID = renamed
+	require.NoError(t, r.Status().Update(ctx, logicalVolume))
+
+	result, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, result)
+
+	pv := &corev1.PersistentVolume{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: pvName}, pv))
+	assert.Equal(t, renamed, pv.Spec.CSI.VolumeHandle)
+	assert.Equal(t, "xfs", pv.Spec.CSI.FSType)
+	assert.Equal(t, "lvms-vg1", pv.Spec.StorageClassName)
+	assert.Equal(t, corev1.PersistentVolumeReclaimRetain, pv.Spec.PersistentVolumeReclaimPolicy)
+	assert.Equal(t, pvc.UID, pv.Spec.ClaimRef.UID, "the volume must be pre-bound to the claim")
+	assert.Equal(t, []string{"node1"}, pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	assert.Equal(t, int64(2147483648), ptr.To(pv.Spec.Capacity[corev1.ResourceStorage]).Value())
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, EventReasonLogicalVolumeImported)
+}
+
+func TestImportLogicalVolume_Rejected(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	tests := []struct {
+		name     string
+		thinPool bool
+		lvs      []lvm.LogicalVolume
+		expected string
+	}{
+		{
+			name:     "missing logical volume",
+			lvs:      []lvm.LogicalVolume{{Name: "other", VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "2147483648"}},
+			expected: "logical volume restored does not exist in volume group vg1",
+		},
+		{
+			name:     "thick logical volume for a thin device class",
+			thinPool: true,
+			lvs:      []lvm.LogicalVolume{{Name: "restored", VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "2147483648"}},
+			expected: "logical volume restored is not a thin volume of thin pool thin-pool-1",
+		},
+		{
+			name:     "thin logical volume for a thick device class",
+			lvs:      []lvm.LogicalVolume{{Name: "restored", VgName: "vg1", PoolName: "thin-pool-1", LvAttr: "Vwi-a-tz--", LvSize: "2147483648"}},
+			expected: "device class vg1 is thick",
+		},
+		{
+			name:     "logical volume smaller than the claim",
+			lvs:      []lvm.LogicalVolume{{Name: "restored", VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "536870912"}},
+			expected: "smaller than the requested 1Gi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := importClaim()
+			r, mockLVM, recorder := newImportReconciler(t, tt.thinPool, pvc)
+			mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: tt.lvs}}}, nil)
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pvc)})
+			assert.ErrorContains(t, err, tt.expected)
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, EventReasonLogicalVolumeImportFailed)
+
+			logicalVolumes := &topolvmv1.LogicalVolumeList{}
+			require.NoError(t, r.List(ctx, logicalVolumes))
+			assert.Empty(t, logicalVolumes.Items, "no LogicalVolume must be created for a rejected import")
+		})
+	}
+}
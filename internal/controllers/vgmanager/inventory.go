@@ -0,0 +1,292 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	symlinkResolver "github.com/openshift/lvm-operator/v4/internal/controllers/symlink-resolver"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/filter"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// DefaultInventoryInterval is the interval in which the LVMDeviceInventory of the node is refreshed.
+	DefaultInventoryInterval = 5 * time.Minute
+
+	// DefaultByIDPath is the directory with the persistent by-id links of the block devices.
+	DefaultByIDPath = "/dev/disk/by-id"
+)
+
+var _ manager.LeaderElectionRunnable = &Inventory{}
+
+// Inventory keeps the LVMDeviceInventory of the node up to date with the block devices on the node.
+// It runs independently of the LVMVolumeGroups, so that the devices can be inspected before any
+// device class is configured.
+type Inventory struct {
+	client.Client
+	Scheme *runtime.Scheme
+	LSBLK  lsblk.LSBLK
+	LVM    lvm.LVM
+
+	// Filters are the filters that are applied to every device and reported in the inventory.
+	Filters filter.FilterSetup
+
+	NodeName  string
+	Namespace string
+
+	SymlinkResolveFn symlinkResolver.ResolveFn
+	// ByIDPath is the directory with the persistent by-id links of the block devices
+	ByIDPath string
+	// Interval is the interval in which the inventory is refreshed
+	Interval time.Duration
+}
+
+// Start implements controller-runtime's manager.Runnable and refreshes the inventory until the context is done.
+func (i *Inventory) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("inventory")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(i.Interval)
+	defer ticker.Stop()
+	for {
+		if err := i.refresh(ctx); err != nil {
+			logger.Error(err, "failed to refresh the LVMDeviceInventory")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's manager.LeaderElectionRunnable.
+func (i *Inventory) NeedLeaderElection() bool {
+	return false
+}
+
+func (i *Inventory) refresh(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	resolver := symlinkResolver.NewWithResolver(i.SymlinkResolveFn)
+
+	blockDevices, err := i.LSBLK.ListBlockDevices(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list block devices: %w", err)
+	}
+	pvs, err := i.LVM.ListPVs(ctx, "")
+	if err != nil {
+		return fmt.Errorf("physical volumes could not be fetched: %w", err)
+	}
+	bdi, err := i.LSBLK.BlockDeviceInfos(ctx, blockDevices)
+	if err != nil {
+		return fmt.Errorf("failed to get block device infos: %w", err)
+	}
+	byID, err := byIDLinks(i.ByIDPath, resolver)
+	if err != nil {
+		logger.Error(err, "failed to read the by-id links of the block devices")
+	}
+
+	// the filters are evaluated for a device class without a DeviceSelector or DeviceHealthPolicy,
+	// which is the widest set of devices vgmanager would pick up
+	filters := i.Filters(ctx, &filter.Options{
+		VG:  &lvmv1alpha1.LVMVolumeGroup{},
+		BDI: bdi,
+		PVs: pvs,
+	})
+	devices := inventoryDevices(blockDevices, pvOwners(pvs, resolver), filters, resolver, byID)
+
+	inventory := &lvmv1alpha1.LVMDeviceInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: i.NodeName, Namespace: i.Namespace},
+	}
+	if err := i.Get(ctx, client.ObjectKeyFromObject(inventory), inventory); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("could not get LVMDeviceInventory: %w", err)
+		}
+		if err := i.create(ctx, inventory); err != nil {
+			return err
+		}
+	}
+
+	original := inventory.DeepCopy()
+	inventory.Status.Devices = devices
+	inventory.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
+	if err := i.Status().Patch(ctx, inventory, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("LVMDeviceInventory could not be updated: %w", err)
+	}
+	logger.V(1).Info("LVMDeviceInventory refreshed", "devices", len(devices))
+	return nil
+}
+
+// create creates the LVMDeviceInventory owned by the node, so that it is removed together with the node.
+func (i *Inventory) create(ctx context.Context, inventory *lvmv1alpha1.LVMDeviceInventory) error {
+	node := &corev1.Node{}
+	if err := i.Get(ctx, client.ObjectKey{Name: i.NodeName}, node); err != nil {
+		return fmt.Errorf("could not get node %s: %w", i.NodeName, err)
+	}
+	if err := controllerutil.SetOwnerReference(node, inventory, i.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := i.Create(ctx, inventory); err != nil {
+		return fmt.Errorf("could not create LVMDeviceInventory: %w", err)
+	}
+	log.FromContext(ctx).Info("LVMDeviceInventory created", "name", inventory.Name)
+	return nil
+}
+
+// inventoryDevices lists the block devices with their children, in the order of lsblk.
+// Devices that are stacked on multiple devices, such as multipath devices, are only listed once.
+func inventoryDevices(
+	blockDevices []lsblk.BlockDevice,
+	owners map[string]string,
+	filters filter.Filters,
+	resolver *symlinkResolver.Resolver,
+	byID map[string][]string,
+) []lvmv1alpha1.InventoryDevice {
+	filterNames := make([]string, 0, len(filters))
+	for name := range filters {
+		filterNames = append(filterNames, name)
+	}
+	slices.Sort(filterNames)
+
+	var devices []lvmv1alpha1.InventoryDevice
+	seen := make(map[string]bool)
+	var add func(device lsblk.BlockDevice, parent string)
+	add = func(device lsblk.BlockDevice, parent string) {
+		if seen[device.KName] {
+			return
+		}
+		seen[device.KName] = true
+
+		inventoryDevice := lvmv1alpha1.InventoryDevice{
+			Name:      device.KName,
+			Parent:    parent,
+			Type:      device.Type,
+			Size:      parseBlockDeviceSize(device.Size),
+			Model:     strings.TrimSpace(device.Model),
+			Serial:    strings.TrimSpace(device.Serial),
+			ByID:      byID[device.KName],
+			FSType:    device.FSType,
+			Owner:     deviceOwner(device, owners),
+			Available: true,
+		}
+		for _, name := range filterNames {
+			result := lvmv1alpha1.DeviceFilterResult{Name: name, Passed: true}
+			if err := filters[name](device, resolver); err != nil {
+				result.Passed = false
+				result.Reason = err.Error()
+				inventoryDevice.Available = false
+			}
+			inventoryDevice.Filters = append(inventoryDevice.Filters, result)
+		}
+		devices = append(devices, inventoryDevice)
+
+		for _, child := range device.Children {
+			add(child, device.KName)
+		}
+	}
+	for _, device := range blockDevices {
+		add(device, "")
+	}
+	return devices
+}
+
+// pvOwners returns the volume groups of the physical volumes by kernel name.
+func pvOwners(pvs []lvm.PhysicalVolume, resolver *symlinkResolver.Resolver) map[string]string {
+	owners := make(map[string]string)
+	for _, pv := range pvs {
+		if pv.VgName == "" {
+			continue
+		}
+		if kname, err := resolver.Resolve(pv.PvName); err == nil {
+			owners[kname] = pv.VgName
+		}
+	}
+	return owners
+}
+
+// deviceOwner returns the volume group the device or one of its children is a physical volume of,
+// or lvmv1alpha1.DeviceOwnerSystem if the device or one of its children is mounted.
+func deviceOwner(device lsblk.BlockDevice, owners map[string]string) string {
+	if vg, ok := owners[device.KName]; ok {
+		return vg
+	}
+	if device.MountPoint != "" {
+		return lvmv1alpha1.DeviceOwnerSystem
+	}
+	for _, child := range device.Children {
+		if owner := deviceOwner(child, owners); owner != "" {
+			return owner
+		}
+	}
+	return ""
+}
+
+// byIDLinks returns the links in the by-id directory by the kernel name of the device they point to.
+func byIDLinks(dir string, resolver *symlinkResolver.Resolver) (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	links := make(map[string][]string)
+	for _, entry := range entries {
+		link := filepath.Join(dir, entry.Name())
+		kname, err := resolver.Resolve(link)
+		if err != nil {
+			continue
+		}
+		links[kname] = append(links[kname], link)
+	}
+	return links, nil
+}
+
+// parseBlockDeviceSize parses the size of a block device as reported by lsblk, e.g. 1.8T.
+// lsblk reports rounded sizes in powers of 1024 with single letter suffixes, which are rounded up to whole bytes.
+func parseBlockDeviceSize(size string) *resource.Quantity {
+	size = strings.TrimSuffix(strings.TrimSpace(size), "B")
+	if size == "" {
+		return nil
+	}
+	if strings.ContainsAny(size[len(size)-1:], "KMGTPE") {
+		size += "i"
+	}
+	quantity := parseLVMQuantity(size)
+	if quantity == nil {
+		return nil
+	}
+	return resource.NewQuantity(quantity.Value(), resource.BinarySI)
+}
@@ -0,0 +1,142 @@
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/filter"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	lsblkmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk/mocks"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestInventory_Refresh(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "node-uid"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(node).
+		WithStatusSubresource(&lvmv1alpha1.LVMDeviceInventory{}).
+		Build()
+
+	blockDevices := []lsblk.BlockDevice{
+		{Name: "/dev/sda", KName: "/dev/sda", Type: lsblk.DeviceTypeDisk, Size: "120G", Model: "QEMU HARDDISK  ", Serial: "root", Children: []lsblk.BlockDevice{
+			{Name: "/dev/sda1", KName: "/dev/sda1", Type: lsblk.DeviceTypePart, Size: "1M", PartLabel: "BIOS-BOOT"},
+			{Name: "/dev/sda2", KName: "/dev/sda2", Type: lsblk.DeviceTypePart, Size: "119.9G", FSType: "xfs", MountPoint: "/sysroot"},
+		}},
+		{Name: "/dev/sdb", KName: "/dev/sdb", Type: lsblk.DeviceTypeDisk, Size: "1.8T", FSType: filter.FSTypeLVM2Member, Serial: "disk-b"},
+		{Name: "/dev/sdc", KName: "/dev/sdc", Type: lsblk.DeviceTypeDisk, Size: "0B"},
+	}
+	mockLSBLK := lsblkmocks.NewMockLSBLK(t)
+	mockLSBLK.EXPECT().ListBlockDevices(mock.Anything).Return(blockDevices, nil)
+	mockLSBLK.EXPECT().BlockDeviceInfos(mock.Anything, blockDevices).Return(lsblk.BlockDeviceInfos{}, nil)
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListPVs(mock.Anything, "").Return([]lvm.PhysicalVolume{{PvName: "/dev/sdb", VgName: "vg1"}}, nil)
+
+	byIDPath := t.TempDir()
+	for _, link := range []string{"wwn-0x5000c500a0b1c2d3", "ata-DISK_disk-b"} {
+		require.NoError(t, os.WriteFile(filepath.Join(byIDPath, link), nil, 0644))
+	}
+
+	inventory := &Inventory{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		LSBLK:     mockLSBLK,
+		LVM:       mockLVM,
+		Filters:   filter.DefaultFilters,
+		NodeName:  "node1",
+		Namespace: "openshift-lvm-storage",
+		SymlinkResolveFn: func(path string) (string, error) {
+			switch filepath.Base(path) {
+			case "wwn-0x5000c500a0b1c2d3", "ata-DISK_disk-b":
+				return "/dev/sdb", nil
+			}
+			if filepath.Dir(path) == byIDPath {
+				return "", errors.New("broken link")
+			}
+			return path, nil
+		},
+		ByIDPath: byIDPath,
+		Interval: DefaultInventoryInterval,
+	}
+	require.NoError(t, inventory.refresh(ctx))
+
+	actual := &lvmv1alpha1.LVMDeviceInventory{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "node1", Namespace: "openshift-lvm-storage"}, actual))
+	require.Len(t, actual.OwnerReferences, 1)
+	assert.Equal(t, node.UID, actual.OwnerReferences[0].UID, "the inventory must be owned by the node")
+	assert.NotNil(t, actual.Status.LastUpdateTime)
+
+	devices := make(map[string]lvmv1alpha1.InventoryDevice)
+	var names []string
+	for _, device := range actual.Status.Devices {
+		devices[device.Name] = device
+		names = append(names, device.Name)
+		assert.Len(t, device.Filters, len(filter.DefaultFilters(ctx, &filter.Options{})), "all filters must be reported")
+	}
+	assert.Equal(t, []string{"/dev/sda", "/dev/sda1", "/dev/sda2", "/dev/sdb", "/dev/sdc"}, names)
+
+	assert.Equal(t, lvmv1alpha1.DeviceOwnerSystem, devices["/dev/sda"].Owner, "a disk with a mounted partition is used by the system")
+	assert.Equal(t, "QEMU HARDDISK", devices["/dev/sda"].Model)
+	assert.False(t, devices["/dev/sda"].Available)
+	assert.Equal(t, "/dev/sda", devices["/dev/sda1"].Parent)
+	assert.Empty(t, devices["/dev/sda1"].Owner)
+	assert.False(t, devices["/dev/sda1"].Available)
+	assert.Contains(t, devices["/dev/sda1"].Filters, lvmv1alpha1.DeviceFilterResult{
+		Name:   "noInvalidPartitionLabel",
+		Passed: false,
+		Reason: `/dev/sda1 has an invalid partition label "BIOS-BOOT"`,
+	})
+
+	assert.Equal(t, "vg1", devices["/dev/sdb"].Owner)
+	assert.Equal(t, int64(1979120929997), devices["/dev/sdb"].Size.Value())
+	assert.Equal(t, []string{
+		filepath.Join(byIDPath, "ata-DISK_disk-b"),
+		filepath.Join(byIDPath, "wwn-0x5000c500a0b1c2d3"),
+	}, devices["/dev/sdb"].ByID)
+	assert.False(t, devices["/dev/sdb"].Available, "a physical volume of another volume group cannot be used")
+
+	assert.True(t, devices["/dev/sdc"].Available)
+	assert.Empty(t, devices["/dev/sdc"].Owner)
+	assert.True(t, devices["/dev/sdc"].Size.IsZero())
+}
+
+func TestParseBlockDeviceSize(t *testing.T) {
+	for size, expected := range map[string]*int64{
+		"":       nil,
+		"0B":     ptr.To[int64](0),
+		"512":    ptr.To[int64](512),
+		"100G":   ptr.To[int64](100 << 30),
+		"119.9G": ptr.To[int64](128741644698),
+		"1.8T":   ptr.To[int64](1979120929997),
+		"n/a":    nil,
+	} {
+		quantity := parseBlockDeviceSize(size)
+		if expected == nil {
+			assert.Nil(t, quantity, size)
+			continue
+		}
+		require.NotNil(t, quantity, size)
+		assert.Equal(t, *expected, quantity.Value(), size)
+	}
+}
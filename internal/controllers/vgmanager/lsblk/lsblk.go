@@ -26,6 +26,18 @@ const (
 
 	// DeviceTypeLVM is the device type for lvm devices in lsblk output
 	DeviceTypeLVM = "lvm"
+
+	// DeviceTypeDisk is the device type for whole disks in lsblk output
+	DeviceTypeDisk = "disk"
+
+	// DeviceTypePart is the device type for partitions in lsblk output
+	DeviceTypePart = "part"
+
+	// DeviceTypeMultipath is the device type for dm-multipath devices in lsblk output
+	DeviceTypeMultipath = "mpath"
+
+	// PartTableTypeGPT is the partition table type for GUID partition tables in lsblk output
+	PartTableTypeGPT = "gpt"
 )
 
 // BlockDevice is the block device as output by lsblk.
@@ -43,6 +55,12 @@ type BlockDevice struct {
 	ReadOnly  bool          `json:"ro,omitempty"`
 	Serial    string        `json:"serial,omitempty"`
 	PartLabel string        `json:"partLabel,omitempty"`
+	// PartTableType is the partition table type of the device, e.g. "gpt" or "dos"
+	PartTableType string `json:"pttype,omitempty"`
+	// PKName is the kernel name of the parent device, set for partitions
+	PKName string `json:"pkname,omitempty"`
+	// MountPoint is the location where the device is mounted on the host
+	MountPoint string `json:"mountpoint,omitempty"`
 }
 
 type LSBLK interface {
@@ -75,7 +93,17 @@ func (b BlockDevice) HasChildren() bool {
 	return len(b.Children) > 0
 }
 
-const LSBLK_COLUMNS = "NAME,ROTA,TYPE,SIZE,MODEL,VENDOR,RO,STATE,KNAME,SERIAL,PARTLABEL,FSTYPE"
+// MultipathDevice returns the dm-multipath device the block device is a path of, if any.
+func (b BlockDevice) MultipathDevice() (BlockDevice, bool) {
+	for _, child := range b.Children {
+		if child.Type == DeviceTypeMultipath {
+			return child, true
+		}
+	}
+	return BlockDevice{}, false
+}
+
+const LSBLK_COLUMNS = "NAME,ROTA,TYPE,SIZE,MODEL,VENDOR,RO,STATE,KNAME,SERIAL,PARTLABEL,FSTYPE,PTTYPE,PKNAME,MOUNTPOINT"
 
 // ListBlockDevices lists the block devices using the lsblk command
 func (lsblk *HostLSBLK) ListBlockDevices(ctx context.Context) ([]BlockDevice, error) {
@@ -0,0 +1,252 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const LogicalVolumeTagsControllerName = "vg-manager-lv-tags"
+
+// LogicalVolumeTagReconciler tags the logical volumes of the TopoLVM LogicalVolumes on the node with their device
+// class and the namespace, name and UID of their PersistentVolumeClaim, so that the claim of a logical volume can be
+// seen with `lvs -o +lv_tags` on the node and recovered from the disk, see Recovery. It also maintains the symlinks
+// <SymlinkDir>/<namespace>/<claim> to the logical volumes.
+//
+// The symlinks are created in the /dev of the host, so they do not survive a reboot and are recreated when
+// vg-manager starts. Symlinks of deleted logical volumes are removed once they are dangling.
+type LogicalVolumeTagReconciler struct {
+	client.Client
+	LVM lvm.LVM
+
+	NodeName string
+	// SymlinkDir is the directory in which the symlinks to the logical volumes are maintained
+	SymlinkDir string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LogicalVolumeTagReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(LogicalVolumeTagsControllerName).
+		For(&topolvmv1.LogicalVolume{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			logicalVolume, ok := obj.(*topolvmv1.LogicalVolume)
+			return ok && logicalVolume.Spec.NodeName == r.NodeName
+		}))).
+		// TopoLVM names the PersistentVolume and the LogicalVolume alike,
+		// and the PersistentVolume is only created and bound after the logical volume
+		Watches(&corev1.PersistentVolume{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetName()}}}
+			}), builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			pv, ok := obj.(*corev1.PersistentVolume)
+			return ok && pv.Spec.CSI != nil && pv.Spec.CSI.Driver == constants.TopolvmCSIDriverName
+		}))).
+		WithOptions(controller.Options{SkipNameValidation: ptr.To(true)}).
+		Complete(r)
+}
+
+func (r *LogicalVolumeTagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	if err := r.Get(ctx, req.NamespacedName, logicalVolume); k8serrors.IsNotFound(err) {
+		return ctrl.Result{}, r.pruneSymlinks(ctx)
+	} else if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get LogicalVolume %s: %w", req.Name, err)
+	}
+	if logicalVolume.Spec.NodeName != r.NodeName || logicalVolume.Status.VolumeID == "" ||
+		logicalVolume.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	logger := log.FromContext(ctx).WithValues("VGName", logicalVolume.Spec.DeviceClass, "LVName", logicalVolume.Status.VolumeID)
+	ctx = log.IntoContext(ctx, logger)
+
+	lv, err := r.findLogicalVolume(ctx, logicalVolume)
+	if err != nil || lv == nil {
+		// logical volumes that do not exist are reported as missing by the LogicalVolumeAudit
+		return ctrl.Result{}, err
+	}
+
+	var claim *corev1.ObjectReference
+	pv := &corev1.PersistentVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: logicalVolume.Name}, pv); err == nil {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.VolumeHandle == logicalVolume.Status.VolumeID {
+			claim = pv.Spec.ClaimRef
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get PersistentVolume %s: %w", logicalVolume.Name, err)
+	}
+
+	current := slices.DeleteFunc(lv.TagList(), func(tag string) bool {
+		return !strings.HasPrefix(tag, constants.LogicalVolumeTagPrefix)
+	})
+	desired := logicalVolumeTags(logicalVolume.Spec.DeviceClass, claim)
+	addTags := slices.DeleteFunc(slices.Clone(desired), func(tag string) bool { return slices.Contains(current, tag) })
+	delTags := slices.DeleteFunc(slices.Clone(current), func(tag string) bool { return slices.Contains(desired, tag) })
+	if len(addTags) > 0 || len(delTags) > 0 {
+		if err := r.LVM.ChangeLVTags(ctx, lv.Name, lv.VgName, addTags, delTags); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("tagged the logical volume", "addedTags", addTags, "deletedTags", delTags)
+	}
+
+	target := deviceMapperPath(lv.VgName, lv.Name)
+	// the previous claim of the logical volume is taken from its tags, so that its symlink can be removed
+	// when the PersistentVolume was released and bound to another claim
+	if namespace, name, _ := claimFromTags(current); name != "" &&
+		(claim == nil || claim.Namespace != namespace || claim.Name != name) {
+		if err := r.removeSymlink(namespace, name, target); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if claim != nil && claim.Name != "" {
+		if err := r.ensureSymlink(ctx, claim.Namespace, claim.Name, target); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *LogicalVolumeTagReconciler) findLogicalVolume(ctx context.Context, logicalVolume *topolvmv1.LogicalVolume) (*lvm.LogicalVolume, error) {
+	report, err := r.LVM.ListLVs(ctx, logicalVolume.Spec.DeviceClass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", logicalVolume.Spec.DeviceClass, err)
+	}
+	for _, item := range report.Report {
+		for _, lv := range item.Lv {
+			if lv.Name == logicalVolume.Status.VolumeID {
+				return &lv, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ensureSymlink links <SymlinkDir>/<namespace>/<name> to the device of the logical volume.
+func (r *LogicalVolumeTagReconciler) ensureSymlink(ctx context.Context, namespace, name, target string) error {
+	link := filepath.Join(r.SymlinkDir, namespace, name)
+	if current, err := os.Readlink(link); err == nil && current == target {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		return fmt.Errorf("failed to create the symlink directory of namespace %s: %w", namespace, err)
+	}
+	if err := os.Remove(link); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to replace symlink %s: %w", link, err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", link, err)
+	}
+	log.FromContext(ctx).Info("linked the logical volume", "symlink", link)
+	return nil
+}
+
+// removeSymlink removes <SymlinkDir>/<namespace>/<name> if it links to the device of the logical volume.
+func (r *LogicalVolumeTagReconciler) removeSymlink(namespace, name, target string) error {
+	link := filepath.Join(r.SymlinkDir, namespace, name)
+	if current, err := os.Readlink(link); err != nil || current != target {
+		return nil
+	}
+	if err := os.Remove(link); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove symlink %s: %w", link, err)
+	}
+	// the directory of the namespace is only removed if it is empty
+	_ = os.Remove(filepath.Dir(link))
+	return nil
+}
+
+// pruneSymlinks removes the symlinks whose logical volume no longer exists.
+func (r *LogicalVolumeTagReconciler) pruneSymlinks(ctx context.Context) error {
+	namespaces, err := os.ReadDir(r.SymlinkDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read the symlink directory: %w", err)
+	}
+	for _, namespace := range namespaces {
+		dir := filepath.Join(r.SymlinkDir, namespace.Name())
+		links, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, link := range links {
+			path := filepath.Join(dir, link.Name())
+			if link.Type()&fs.ModeSymlink == 0 {
+				continue
+			}
+			if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+				if err := os.Remove(path); err != nil {
+					return fmt.Errorf("failed to remove dangling symlink %s: %w", path, err)
+				}
+				log.FromContext(ctx).Info("removed the symlink of a deleted logical volume", "symlink", path)
+			}
+		}
+		_ = os.Remove(dir)
+	}
+	return nil
+}
+
+// logicalVolumeTags returns the LVM tags of a logical volume of the device class bound to the claim.
+func logicalVolumeTags(deviceClass string, claim *corev1.ObjectReference) []string {
+	tags := []string{constants.LogicalVolumeDeviceClassTag + "=" + deviceClass}
+	if claim != nil && claim.Name != "" {
+		tags = append(tags,
+			constants.LogicalVolumeClaimNamespaceTag+"="+claim.Namespace,
+			constants.LogicalVolumeClaimNameTag+"="+claim.Name)
+		if claim.UID != "" {
+			tags = append(tags, constants.LogicalVolumeClaimUIDTag+"="+string(claim.UID))
+		}
+	}
+	return tags
+}
+
+// claimFromTags returns the claim of a logical volume recorded in its LVM tags.
+func claimFromTags(tags []string) (namespace, name string, uid types.UID) {
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, "=")
+		switch key {
+		case constants.LogicalVolumeClaimNamespaceTag:
+			namespace = value
+		case constants.LogicalVolumeClaimNameTag:
+			name = value
+		case constants.LogicalVolumeClaimUIDTag:
+			uid = types.UID(value)
+		}
+	}
+	return namespace, name, uid
+}
@@ -0,0 +1,133 @@
+package vgmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const taggedVolumeID = "8d2a5f0e-1b9c-4a6d-9e4f-3c0b9a8f7e2d"
+
+func newLogicalVolumeTagReconciler(t *testing.T, claim *corev1.ObjectReference) (*LogicalVolumeTagReconciler, *lvmmocks.MockLVM) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	logicalVolume := &topolvmv1.LogicalVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+		Spec:       topolvmv1.LogicalVolumeSpec{Name: "pvc-1", NodeName: "node1", DeviceClass: "vg1", Size: resource.MustParse("1Gi")},
+		Status:     topolvmv1.LogicalVolumeStatus{VolumeID: taggedVolumeID},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+		Spec: corev1.PersistentVolumeSpec{
+			ClaimRef: claim,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: constants.TopolvmCSIDriverName, VolumeHandle: taggedVolumeID},
+			},
+		},
+	}
+
+	mockLVM := lvmmocks.NewMockLVM(t)
+	return &LogicalVolumeTagReconciler{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(logicalVolume, pv).Build(),
+		LVM:        mockLVM,
+		NodeName:   "node1",
+		SymlinkDir: t.TempDir(),
+	}, mockLVM
+}
+
+func taggedLVReport(tags string) *lvm.LVReport {
+	return &lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: taggedVolumeID, VgName: "vg1", LvAttr: "-wi-ao----", LvSize: "1073741824", Tags: tags},
+	}}}}
+}
+
+func TestLogicalVolumeTagReconciler_Tag(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, mockLVM := newLogicalVolumeTagReconciler(t, &corev1.ObjectReference{Namespace: "app", Name: "data", UID: "claim-uid"})
+
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(taggedLVReport("backup,lvms.openshift.io/device-class=vg1"), nil).Once()
+	mockLVM.EXPECT().ChangeLVTags(mock.Anything, taggedVolumeID, "vg1", []string{
+		"lvms.openshift.io/pvc-namespace=app",
+		"lvms.openshift.io/pvc-name=data",
+		"lvms.openshift.io/pvc-uid=claim-uid",
+	}, []string{}).Return(nil).Once()
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "pvc-1"}})
+	require.NoError(t, err)
+
+	target, err := os.Readlink(filepath.Join(r.SymlinkDir, "app", "data"))
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/mapper/vg1-8d2a5f0e--1b9c--4a6d--9e4f--3c0b9a8f7e2d", target)
+
+	// a tagged and linked logical volume is left alone
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(taggedLVReport("backup,lvms.openshift.io/device-class=vg1,"+
+		"lvms.openshift.io/pvc-namespace=app,lvms.openshift.io/pvc-name=data,lvms.openshift.io/pvc-uid=claim-uid"), nil).Once()
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "pvc-1"}})
+	require.NoError(t, err)
+}
+
+func TestLogicalVolumeTagReconciler_Rebound(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, mockLVM := newLogicalVolumeTagReconciler(t, &corev1.ObjectReference{Namespace: "app", Name: "restored"})
+
+	target := deviceMapperPath("vg1", taggedVolumeID)
+	require.NoError(t, os.MkdirAll(filepath.Join(r.SymlinkDir, "app"), 0755))
+	require.NoError(t, os.Symlink(target, filepath.Join(r.SymlinkDir, "app", "data")))
+
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(taggedLVReport("lvms.openshift.io/device-class=vg1,"+
+		"lvms.openshift.io/pvc-namespace=app,lvms.openshift.io/pvc-name=data,lvms.openshift.io/pvc-uid=claim-uid"), nil).Once()
+	mockLVM.EXPECT().ChangeLVTags(mock.Anything, taggedVolumeID, "vg1",
+		[]string{"lvms.openshift.io/pvc-name=restored"},
+		[]string{"lvms.openshift.io/pvc-name=data", "lvms.openshift.io/pvc-uid=claim-uid"},
+	).Return(nil).Once()
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "pvc-1"}})
+	require.NoError(t, err)
+
+	_, err = os.Lstat(filepath.Join(r.SymlinkDir, "app", "data"))
+	assert.True(t, os.IsNotExist(err), "the symlink of the previous claim must be removed")
+	current, err := os.Readlink(filepath.Join(r.SymlinkDir, "app", "restored"))
+	require.NoError(t, err)
+	assert.Equal(t, target, current)
+}
+
+func TestLogicalVolumeTagReconciler_PruneSymlinks(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, _ := newLogicalVolumeTagReconciler(t, nil)
+
+	existing := filepath.Join(t.TempDir(), "device")
+	require.NoError(t, os.WriteFile(existing, nil, 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(r.SymlinkDir, "app"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(r.SymlinkDir, "deleted"), 0755))
+	require.NoError(t, os.Symlink(existing, filepath.Join(r.SymlinkDir, "app", "data")))
+	require.NoError(t, os.Symlink(deviceMapperPath("vg1", taggedVolumeID), filepath.Join(r.SymlinkDir, "deleted", "data")))
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "pvc-deleted"}})
+	require.NoError(t, err)
+
+	_, err = os.Lstat(filepath.Join(r.SymlinkDir, "app", "data"))
+	assert.NoError(t, err, "symlinks of existing logical volumes must be kept")
+	_, err = os.Lstat(filepath.Join(r.SymlinkDir, "deleted"))
+	assert.True(t, os.IsNotExist(err), "dangling symlinks and their empty directories must be removed")
+}
@@ -35,20 +35,23 @@ type ExitError interface {
 }
 
 const (
-	vgsCmd        = "/usr/sbin/vgs"
-	pvsCmd        = "/usr/sbin/pvs"
-	lvsCmd        = "/usr/sbin/lvs"
-	vgCreateCmd   = "/usr/sbin/vgcreate"
-	vgChangeCmd   = "/usr/sbin/vgchange"
-	vgExtendCmd   = "/usr/sbin/vgextend"
-	vgReduceCmd   = "/usr/sbin/vgreduce"
-	vgRemoveCmd   = "/usr/sbin/vgremove"
-	pvRemoveCmd   = "/usr/sbin/pvremove"
-	lvCreateCmd   = "/usr/sbin/lvcreate"
-	lvExtendCmd   = "/usr/sbin/lvextend"
-	lvRemoveCmd   = "/usr/sbin/lvremove"
-	lvChangeCmd   = "/usr/sbin/lvchange"
-	lvmDevicesCmd = "/usr/sbin/lvmdevices"
+	vgsCmd          = "/usr/sbin/vgs"
+	pvsCmd          = "/usr/sbin/pvs"
+	lvsCmd          = "/usr/sbin/lvs"
+	vgCreateCmd     = "/usr/sbin/vgcreate"
+	vgChangeCmd     = "/usr/sbin/vgchange"
+	vgExtendCmd     = "/usr/sbin/vgextend"
+	vgReduceCmd     = "/usr/sbin/vgreduce"
+	vgRemoveCmd     = "/usr/sbin/vgremove"
+	pvRemoveCmd     = "/usr/sbin/pvremove"
+	lvCreateCmd     = "/usr/sbin/lvcreate"
+	lvExtendCmd     = "/usr/sbin/lvextend"
+	lvRemoveCmd     = "/usr/sbin/lvremove"
+	lvChangeCmd     = "/usr/sbin/lvchange"
+	lvRenameCmd     = "/usr/sbin/lvrename"
+	lvmDevicesCmd   = "/usr/sbin/lvmdevices"
+	vgCfgBackupCmd  = "/usr/sbin/vgcfgbackup"
+	vgCfgRestoreCmd = "/usr/sbin/vgcfgrestore"
 
 	DefaultTag = "@lvms"
 )
@@ -60,9 +63,13 @@ var (
 		"pool_lv",
 		"lv_attr",
 		"lv_size",
+		"data_percent",
 		"metadata_percent",
 		"chunk_size",
 		"lv_metadata_size",
+		"lv_kernel_minor",
+		"lv_time",
+		"lv_tags",
 	}
 )
 
@@ -72,6 +79,7 @@ type VGReport struct {
 		Vg []struct {
 			Name   string `json:"vg_name"`
 			VgSize string `json:"vg_size"`
+			VgFree string `json:"vg_free"`
 			Tags   string `json:"vg_tags"`
 		} `json:"vg"`
 	} `json:"report"`
@@ -99,9 +107,22 @@ type LogicalVolume struct {
 	PoolName        string `json:"pool_lv"`
 	LvAttr          string `json:"lv_attr"`
 	LvSize          string `json:"lv_size"`
+	DataPercent     string `json:"data_percent"`
 	MetadataPercent string `json:"metadata_percent"`
 	ChunkSize       string `json:"chunk_size"`
 	MetadataSize    string `json:"lv_metadata_size"`
+	KernelMinor     string `json:"lv_kernel_minor"`
+	Time            string `json:"lv_time"`
+	// Tags is the comma separated list of tags of the logical volume
+	Tags string `json:"lv_tags"`
+}
+
+// TagList returns the tags of the logical volume.
+func (lv LogicalVolume) TagList() []string {
+	if lv.Tags == "" {
+		return nil
+	}
+	return strings.Split(lv.Tags, ",")
 }
 
 type LVM interface {
@@ -111,6 +132,8 @@ type LVM interface {
 	DeleteVG(ctx context.Context, vg VolumeGroup) error
 	GetVG(ctx context.Context, name string) (VolumeGroup, error)
 	ReduceVG(ctx context.Context, vgName string, devices string) error
+	BackupVGMetadata(ctx context.Context, vgName string, file string) error
+	RestoreVGMetadata(ctx context.Context, vgName string, file string) error
 
 	ListPVs(ctx context.Context, vgName string) ([]PhysicalVolume, error)
 	RemovePV(ctx context.Context, devicePath string) error
@@ -124,6 +147,8 @@ type LVM interface {
 	ExtendThinPoolMetadata(ctx context.Context, lvName, vgName string, metadataSizeBytes int64) error
 	ActivateLV(ctx context.Context, lvName, vgName string) error
 	DeleteLV(ctx context.Context, lvName, vgName string) error
+	RenameLV(ctx context.Context, lvName, vgName, newName string) error
+	ChangeLVTags(ctx context.Context, lvName, vgName string, addTags, delTags []string) error
 }
 
 type HostLVM struct {
@@ -146,6 +171,9 @@ type VolumeGroup struct {
 	// VgSize is the size of the volume group
 	VgSize string `json:"vg_size"`
 
+	// VgFree is the unallocated space of the volume group
+	VgFree string `json:"vg_free"`
+
 	// PVs is the list of physical volumes associated with the volume group
 	PVs []PhysicalVolume `json:"pvs"`
 
@@ -315,6 +343,7 @@ func (hlvm *HostLVM) GetVG(ctx context.Context, name string) (VolumeGroup, error
 			if vg.Name == name {
 				volumeGroup.Name = vg.Name
 				volumeGroup.VgSize = vg.VgSize
+				volumeGroup.VgFree = vg.VgFree
 				vgFound = true
 				break
 			}
@@ -372,7 +401,7 @@ func (hlvm *HostLVM) ListVGs(ctx context.Context, tagged bool) ([]VolumeGroup, e
 	res := new(VGReport)
 
 	args := []string{
-		"-o", "vg_name,vg_size,vg_tags", "--units", "b", "--nosuffix", "--reportformat", "json",
+		"-o", "vg_name,vg_size,vg_free,vg_tags", "--units", "b", "--nosuffix", "--reportformat", "json",
 	}
 	if tagged {
 		args = append(args, DefaultTag)
@@ -388,6 +417,7 @@ func (hlvm *HostLVM) ListVGs(ctx context.Context, tagged bool) ([]VolumeGroup, e
 			vgList = append(vgList, VolumeGroup{
 				Name:   vg.Name,
 				VgSize: vg.VgSize,
+				VgFree: vg.VgFree,
 				PVs:    []PhysicalVolume{},
 				Tags:   strings.Split(vg.Tags, ","),
 			})
@@ -491,6 +521,33 @@ func (hlvm *HostLVM) DeleteLV(ctx context.Context, lvName, vgName string) error
 	return nil
 }
 
+// RenameLV renames the logical volume in the volume group
+func (hlvm *HostLVM) RenameLV(ctx context.Context, lvName, vgName, newName string) error {
+	if err := hlvm.RunCommandAsHost(ctx, lvRenameCmd, vgName, lvName, newName); err != nil {
+		return fmt.Errorf("failed to rename logical volume %s in volume group %s to %s: %w", lvName, vgName, newName, err)
+	}
+	return nil
+}
+
+// ChangeLVTags adds and deletes tags of the logical volume in the volume group
+func (hlvm *HostLVM) ChangeLVTags(ctx context.Context, lvName, vgName string, addTags, delTags []string) error {
+	if len(addTags) == 0 && len(delTags) == 0 {
+		return nil
+	}
+	var args []string
+	for _, tag := range addTags {
+		args = append(args, "--addtag", tag)
+	}
+	for _, tag := range delTags {
+		args = append(args, "--deltag", tag)
+	}
+	args = append(args, fmt.Sprintf("%s/%s", vgName, lvName))
+	if err := hlvm.RunCommandAsHost(ctx, lvChangeCmd, args...); err != nil {
+		return fmt.Errorf("failed to change the tags of logical volume %s in volume group %s: %w", lvName, vgName, err)
+	}
+	return nil
+}
+
 // CreateLV creates the logical volume
 func (hlvm *HostLVM) CreateLV(ctx context.Context, lvName, vgName string, sizePercent int, chunkSizeBytes, metadataSizeBytes int64) error {
 	if vgName == "" {
@@ -601,6 +658,23 @@ func (hlvm *HostLVM) RemovePV(ctx context.Context, devicePath string) error {
 	return nil
 }
 
+// BackupVGMetadata writes a backup of the metadata of the volume group to the file on the host using vgcfgbackup.
+func (hlvm *HostLVM) BackupVGMetadata(ctx context.Context, vgName string, file string) error {
+	if err := hlvm.RunCommandAsHost(ctx, vgCfgBackupCmd, "--file", file, vgName); err != nil {
+		return fmt.Errorf("failed to back up the metadata of volume group %s: %w", vgName, err)
+	}
+	return nil
+}
+
+// RestoreVGMetadata restores the metadata of the volume group from the backup file on the host using vgcfgrestore.
+// The restore is forced, as vgcfgrestore refuses to restore volume groups with thin pools otherwise.
+func (hlvm *HostLVM) RestoreVGMetadata(ctx context.Context, vgName string, file string) error {
+	if err := hlvm.RunCommandAsHost(ctx, vgCfgRestoreCmd, "--force", "--file", file, vgName); err != nil {
+		return fmt.Errorf("failed to restore the metadata of volume group %s: %w", vgName, err)
+	}
+	return nil
+}
+
 func untaggedVGs(vgs []VolumeGroup) []VolumeGroup {
 	var untaggedVGs []VolumeGroup
 	for _, vg := range vgs {
@@ -583,6 +583,68 @@ func TestHostLVM_ActivateLV(t *testing.T) {
 	}
 }
 
+func TestHostLVM_BackupAndRestoreVGMetadata(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	var commands [][]string
+	executor := &test.MockExecutor{MockRunCommandAsHost: func(ctx context.Context, command string, args ...string) error {
+		commands = append(commands, append([]string{command}, args...))
+		return nil
+	}}
+
+	hlvm := NewHostLVM(executor)
+	assert.NoError(t, hlvm.BackupVGMetadata(ctx, "vg1", "/var/lib/lvms/metadata/vg1.vg"))
+	assert.NoError(t, hlvm.RestoreVGMetadata(ctx, "vg1", "/var/lib/lvms/metadata/vg1.vg"))
+	assert.Equal(t, [][]string{
+		{vgCfgBackupCmd, "--file", "/var/lib/lvms/metadata/vg1.vg", "vg1"},
+		{vgCfgRestoreCmd, "--force", "--file", "/var/lib/lvms/metadata/vg1.vg", "vg1"},
+	}, commands)
+
+	executor.MockRunCommandAsHost = func(ctx context.Context, command string, args ...string) error {
+		return fmt.Errorf("mocked error")
+	}
+	assert.Error(t, hlvm.BackupVGMetadata(ctx, "vg1", "/var/lib/lvms/metadata/vg1.vg"))
+	assert.Error(t, hlvm.RestoreVGMetadata(ctx, "vg1", "/var/lib/lvms/metadata/vg1.vg"))
+}
+
+func TestHostLVM_RenameLV(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	var commands [][]string
+	executor := &test.MockExecutor{MockRunCommandAsHost: func(ctx context.Context, command string, args ...string) error {
+		commands = append(commands, append([]string{command}, args...))
+		return nil
+	}}
+
+	hlvm := NewHostLVM(executor)
+	assert.NoError(t, hlvm.RenameLV(ctx, "restored", "vg1", "0b5e1fd5-4c2a-4a53-9d5e-3b0a4b3f0c11"))
+	assert.Equal(t, [][]string{{lvRenameCmd, "vg1", "restored", "0b5e1fd5-4c2a-4a53-9d5e-3b0a4b3f0c11"}}, commands)
+
+	executor.MockRunCommandAsHost = func(ctx context.Context, command string, args ...string) error {
+		return fmt.Errorf("mocked error")
+	}
+	assert.Error(t, hlvm.RenameLV(ctx, "restored", "vg1", "0b5e1fd5-4c2a-4a53-9d5e-3b0a4b3f0c11"))
+}
+
+func TestHostLVM_ChangeLVTags(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	var commands [][]string
+	executor := &test.MockExecutor{MockRunCommandAsHost: func(ctx context.Context, command string, args ...string) error {
+		commands = append(commands, append([]string{command}, args...))
+		return nil
+	}}
+
+	hlvm := NewHostLVM(executor)
+	assert.NoError(t, hlvm.ChangeLVTags(ctx, "lv1", "vg1", nil, nil))
+	assert.Empty(t, commands, "no command must be run without tags to change")
+
+	assert.NoError(t, hlvm.ChangeLVTags(ctx, "lv1", "vg1", []string{"a=1", "b=2"}, []string{"c=3"}))
+	assert.Equal(t, [][]string{{lvChangeCmd, "--addtag", "a=1", "--addtag", "b=2", "--deltag", "c=3", "vg1/lv1"}}, commands)
+
+	executor.MockRunCommandAsHost = func(ctx context.Context, command string, args ...string) error {
+		return fmt.Errorf("mocked error")
+	}
+	assert.Error(t, hlvm.ChangeLVTags(ctx, "lv1", "vg1", []string{"a=1"}, nil))
+}
+
 func TestNewDefaultHostLVM(t *testing.T) {
 	lvm := NewDefaultHostLVM()
 	assert.NotNilf(t, lvm, "lvm should not be nil")
@@ -158,6 +158,144 @@ func (_c *MockLVM_AddTagToVG_Call) RunAndReturn(run func(ctx context.Context, vg
 	return _c
 }
 
+// BackupVGMetadata provides a mock function for the type MockLVM
+func (_mock *MockLVM) BackupVGMetadata(ctx context.Context, vgName string, file string) error {
+	ret := _mock.Called(ctx, vgName, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackupVGMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, vgName, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLVM_BackupVGMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BackupVGMetadata'
+type MockLVM_BackupVGMetadata_Call struct {
+	*mock.Call
+}
+
+// BackupVGMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vgName string
+//   - file string
+func (_e *MockLVM_Expecter) BackupVGMetadata(ctx interface{}, vgName interface{}, file interface{}) *MockLVM_BackupVGMetadata_Call {
+	return &MockLVM_BackupVGMetadata_Call{Call: _e.mock.On("BackupVGMetadata", ctx, vgName, file)}
+}
+
+func (_c *MockLVM_BackupVGMetadata_Call) Run(run func(ctx context.Context, vgName string, file string)) *MockLVM_BackupVGMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLVM_BackupVGMetadata_Call) Return(err error) *MockLVM_BackupVGMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLVM_BackupVGMetadata_Call) RunAndReturn(run func(ctx context.Context, vgName string, file string) error) *MockLVM_BackupVGMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeLVTags provides a mock function for the type MockLVM
+func (_mock *MockLVM) ChangeLVTags(ctx context.Context, lvName string, vgName string, addTags []string, delTags []string) error {
+	ret := _mock.Called(ctx, lvName, vgName, addTags, delTags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeLVTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string, []string) error); ok {
+		r0 = returnFunc(ctx, lvName, vgName, addTags, delTags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLVM_ChangeLVTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeLVTags'
+type MockLVM_ChangeLVTags_Call struct {
+	*mock.Call
+}
+
+// ChangeLVTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lvName string
+//   - vgName string
+//   - addTags []string
+//   - delTags []string
+func (_e *MockLVM_Expecter) ChangeLVTags(ctx interface{}, lvName interface{}, vgName interface{}, addTags interface{}, delTags interface{}) *MockLVM_ChangeLVTags_Call {
+	return &MockLVM_ChangeLVTags_Call{Call: _e.mock.On("ChangeLVTags", ctx, lvName, vgName, addTags, delTags)}
+}
+
+func (_c *MockLVM_ChangeLVTags_Call) Run(run func(ctx context.Context, lvName string, vgName string, addTags []string, delTags []string)) *MockLVM_ChangeLVTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLVM_ChangeLVTags_Call) Return(err error) *MockLVM_ChangeLVTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLVM_ChangeLVTags_Call) RunAndReturn(run func(ctx context.Context, lvName string, vgName string, addTags []string, delTags []string) error) *MockLVM_ChangeLVTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateLV provides a mock function for the type MockLVM
 func (_mock *MockLVM) CreateLV(ctx context.Context, lvName string, vgName string, sizePercent int, chunkSizeBytes int64, metadataSizeBytes int64) error {
 	ret := _mock.Called(ctx, lvName, vgName, sizePercent, chunkSizeBytes, metadataSizeBytes)
@@ -1161,3 +1299,135 @@ func (_c *MockLVM_RemovePV_Call) RunAndReturn(run func(ctx context.Context, devi
 	_c.Call.Return(run)
 	return _c
 }
+
+// RenameLV provides a mock function for the type MockLVM
+func (_mock *MockLVM) RenameLV(ctx context.Context, lvName string, vgName string, newName string) error {
+	ret := _mock.Called(ctx, lvName, vgName, newName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameLV")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, lvName, vgName, newName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLVM_RenameLV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameLV'
+type MockLVM_RenameLV_Call struct {
+	*mock.Call
+}
+
+// RenameLV is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lvName string
+//   - vgName string
+//   - newName string
+func (_e *MockLVM_Expecter) RenameLV(ctx interface{}, lvName interface{}, vgName interface{}, newName interface{}) *MockLVM_RenameLV_Call {
+	return &MockLVM_RenameLV_Call{Call: _e.mock.On("RenameLV", ctx, lvName, vgName, newName)}
+}
+
+func (_c *MockLVM_RenameLV_Call) Run(run func(ctx context.Context, lvName string, vgName string, newName string)) *MockLVM_RenameLV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLVM_RenameLV_Call) Return(err error) *MockLVM_RenameLV_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLVM_RenameLV_Call) RunAndReturn(run func(ctx context.Context, lvName string, vgName string, newName string) error) *MockLVM_RenameLV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreVGMetadata provides a mock function for the type MockLVM
+func (_mock *MockLVM) RestoreVGMetadata(ctx context.Context, vgName string, file string) error {
+	ret := _mock.Called(ctx, vgName, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreVGMetadata")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, vgName, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLVM_RestoreVGMetadata_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreVGMetadata'
+type MockLVM_RestoreVGMetadata_Call struct {
+	*mock.Call
+}
+
+// RestoreVGMetadata is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vgName string
+//   - file string
+func (_e *MockLVM_Expecter) RestoreVGMetadata(ctx interface{}, vgName interface{}, file interface{}) *MockLVM_RestoreVGMetadata_Call {
+	return &MockLVM_RestoreVGMetadata_Call{Call: _e.mock.On("RestoreVGMetadata", ctx, vgName, file)}
+}
+
+func (_c *MockLVM_RestoreVGMetadata_Call) Run(run func(ctx context.Context, vgName string, file string)) *MockLVM_RestoreVGMetadata_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLVM_RestoreVGMetadata_Call) Return(err error) *MockLVM_RestoreVGMetadata_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLVM_RestoreVGMetadata_Call) RunAndReturn(run func(ctx context.Context, vgName string, file string) error) *MockLVM_RestoreVGMetadata_Call {
+	_c.Call.Return(run)
+	return _c
+}
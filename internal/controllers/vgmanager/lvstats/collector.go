@@ -0,0 +1,274 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lvstats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/prometheus/client_golang/prometheus"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	DefaultSysBlockPath    = "/sys/block"
+	DefaultRefreshInterval = time.Minute
+
+	namespace = "lvms"
+	subsystem = "vgmanager"
+)
+
+var labels = []string{"logical_volume", "device_class", "persistentvolumeclaim_namespace", "persistentvolumeclaim", "node"}
+
+var (
+	sizeBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_size_bytes"),
+		"Allocated size of a logical volume managed by LVMS.",
+		labels, nil,
+	)
+	thinDataPercentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_thin_data_percent"),
+		"Percentage of the size of a thin logical volume that is allocated in its thin pool.",
+		labels, nil,
+	)
+	readsCompletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_reads_completed_total"),
+		"Number of reads completed on a logical volume managed by LVMS.",
+		labels, nil,
+	)
+	readBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_read_bytes_total"),
+		"Number of bytes read from a logical volume managed by LVMS.",
+		labels, nil,
+	)
+	writesCompletedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_writes_completed_total"),
+		"Number of writes completed on a logical volume managed by LVMS.",
+		labels, nil,
+	)
+	writtenBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystem, "logical_volume_written_bytes_total"),
+		"Number of bytes written to a logical volume managed by LVMS.",
+		labels, nil,
+	)
+)
+
+// volume is a logical volume on the node as resolved during the last refresh.
+type volume struct {
+	labels          []string
+	sizeBytes       float64
+	thinDataPercent *float64
+	// dmDevice is the name of the device mapper device of the logical volume in sysfs, e.g. dm-3.
+	// It is empty if the logical volume is not active.
+	dmDevice string
+}
+
+var (
+	_ prometheus.Collector           = &Collector{}
+	_ manager.LeaderElectionRunnable = &Collector{}
+)
+
+// Collector exports the usage and I/O statistics of the logical volumes managed by LVMS on the node.
+// The logical volumes are the ones of the TopoLVM LogicalVolumes of the node, labelled with the
+// PersistentVolumeClaim they are bound to. As resolving them requires calls to lvs and the API server,
+// they are refreshed periodically, while the I/O statistics are read from sysfs on every scrape.
+type Collector struct {
+	client       client.Reader
+	lvm          lvm.LVM
+	nodeName     string
+	sysBlockPath string
+	interval     time.Duration
+
+	mu      sync.RWMutex
+	volumes []volume
+	logger  logr.Logger
+}
+
+// NewCollector returns a Collector for the logical volumes on the node.
+// It has to be added to the manager to be refreshed and registered with a prometheus.Registerer to be exported.
+func NewCollector(client client.Reader, lvm lvm.LVM, nodeName string) *Collector {
+	return &Collector{
+		client:       client,
+		lvm:          lvm,
+		nodeName:     nodeName,
+		sysBlockPath: DefaultSysBlockPath,
+		interval:     DefaultRefreshInterval,
+		logger:       logr.Discard(),
+	}
+}
+
+// Start implements controller-runtime's manager.Runnable and refreshes the logical volumes until the context is done.
+func (c *Collector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("lvstats")
+	c.mu.Lock()
+	c.logger = logger
+	c.mu.Unlock()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.refresh(ctx); err != nil {
+			logger.Error(err, "failed to refresh the logical volume statistics")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's manager.LeaderElectionRunnable.
+func (c *Collector) NeedLeaderElection() bool {
+	return false
+}
+
+// refresh resolves the logical volumes of the TopoLVM LogicalVolumes on the node.
+// LVMS names the volume group of a device class after the device class.
+func (c *Collector) refresh(ctx context.Context) error {
+	logicalVolumes := &topolvmv1.LogicalVolumeList{}
+	if err := c.client.List(ctx, logicalVolumes); err != nil {
+		return fmt.Errorf("failed to list TopoLVM LogicalVolumes: %w", err)
+	}
+
+	reports := make(map[string]map[string]lvm.LogicalVolume)
+	var volumes []volume
+	for _, logicalVolume := range logicalVolumes.Items {
+		if logicalVolume.Spec.NodeName != c.nodeName || logicalVolume.Status.VolumeID == "" {
+			continue
+		}
+		deviceClass := logicalVolume.Spec.DeviceClass
+
+		lvs, ok := reports[deviceClass]
+		if !ok {
+			var err error
+			if lvs, err = c.listLVs(ctx, deviceClass); err != nil {
+				return err
+			}
+			reports[deviceClass] = lvs
+		}
+		lv, ok := lvs[logicalVolume.Status.VolumeID]
+		if !ok {
+			continue
+		}
+
+		pvcNamespace, pvcName, err := c.claim(ctx, logicalVolume.Spec.Name)
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, newVolume(lv, []string{lv.Name, deviceClass, pvcNamespace, pvcName, c.nodeName}))
+	}
+
+	c.mu.Lock()
+	c.volumes = volumes
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Collector) listLVs(ctx context.Context, vgName string) (map[string]lvm.LogicalVolume, error) {
+	report, err := c.lvm.ListLVs(ctx, vgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", vgName, err)
+	}
+	lvs := make(map[string]lvm.LogicalVolume)
+	for _, item := range report.Report {
+		for _, lv := range item.Lv {
+			lvs[lv.Name] = lv
+		}
+	}
+	return lvs, nil
+}
+
+// claim returns the namespace and name of the PersistentVolumeClaim bound to the PersistentVolume.
+// TopoLVM names the LogicalVolume after the PersistentVolume it was provisioned for.
+// Empty values are returned if the PersistentVolume does not exist or is not bound, e.g. for snapshots.
+func (c *Collector) claim(ctx context.Context, pvName string) (string, string, error) {
+	pv := &corev1.PersistentVolume{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: pvName}, pv); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("failed to get PersistentVolume %s: %w", pvName, err)
+	}
+	if pv.Spec.ClaimRef == nil {
+		return "", "", nil
+	}
+	return pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name, nil
+}
+
+func newVolume(lv lvm.LogicalVolume, labels []string) volume {
+	v := volume{labels: labels}
+	if size, err := strconv.ParseFloat(lv.LvSize, 64); err == nil {
+		v.sizeBytes = size
+	}
+	// the data usage is only reported for thin volumes and pools
+	if lv.PoolName != "" && lv.DataPercent != "" {
+		if dataPercent, err := strconv.ParseFloat(lv.DataPercent, 64); err == nil {
+			v.thinDataPercent = &dataPercent
+		}
+	}
+	// inactive logical volumes report a minor of -1
+	if minor, err := strconv.Atoi(lv.KernelMinor); err == nil && minor >= 0 {
+		v.dmDevice = fmt.Sprintf("dm-%d", minor)
+	}
+	return v
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sizeBytesDesc
+	ch <- thinDataPercentDesc
+	ch <- readsCompletedDesc
+	ch <- readBytesDesc
+	ch <- writesCompletedDesc
+	ch <- writtenBytesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, v := range c.volumes {
+		ch <- prometheus.MustNewConstMetric(sizeBytesDesc, prometheus.GaugeValue, v.sizeBytes, v.labels...)
+		if v.thinDataPercent != nil {
+			ch <- prometheus.MustNewConstMetric(thinDataPercentDesc, prometheus.GaugeValue, *v.thinDataPercent, v.labels...)
+		}
+		if v.dmDevice == "" {
+			continue
+		}
+		stat, err := ReadDiskStat(c.sysBlockPath, v.dmDevice)
+		if err != nil {
+			c.logger.V(1).Info("could not read the I/O statistics of the logical volume", "lv", v.labels[0], "reason", err.Error())
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(readsCompletedDesc, prometheus.CounterValue, float64(stat.ReadsCompleted), v.labels...)
+		ch <- prometheus.MustNewConstMetric(readBytesDesc, prometheus.CounterValue, float64(stat.ReadBytes), v.labels...)
+		ch <- prometheus.MustNewConstMetric(writesCompletedDesc, prometheus.CounterValue, float64(stat.WritesCompleted), v.labels...)
+		ch <- prometheus.MustNewConstMetric(writtenBytesDesc, prometheus.CounterValue, float64(stat.WrittenBytes), v.labels...)
+	}
+}
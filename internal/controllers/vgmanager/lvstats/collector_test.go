@@ -0,0 +1,106 @@
+package lvstats
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestParseDiskStat(t *testing.T) {
+	stat, err := ParseDiskStat([]byte("    1200        0    96000      512     340       10     8192     1024        0      900     1536        0        0        0        0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, DiskStat{
+		ReadsCompleted:  1200,
+		ReadBytes:       96000 * 512,
+		WritesCompleted: 340,
+		WrittenBytes:    8192 * 512,
+	}, stat)
+
+	_, err = ParseDiskStat([]byte("1 2 3"))
+	assert.Error(t, err)
+	_, err = ParseDiskStat([]byte("1 2 x 4 5 6 7 8 9 10 11"))
+	assert.Error(t, err)
+}
+
+func TestCollector(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	logicalVolume := func(name, node, volumeID string) *topolvmv1.LogicalVolume {
+		return &topolvmv1.LogicalVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       topolvmv1.LogicalVolumeSpec{Name: name, NodeName: node, DeviceClass: "vg1"},
+			Status:     topolvmv1.LogicalVolumeStatus{VolumeID: volumeID},
+		}
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		logicalVolume("pvc-1", "node1", "lv-1"),
+		logicalVolume("pvc-2", "node1", "lv-2"),
+		logicalVolume("pvc-3", "node2", "lv-3"),
+		logicalVolume("pvc-4", "node1", ""),
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+			Spec:       corev1.PersistentVolumeSpec{ClaimRef: &corev1.ObjectReference{Namespace: "app", Name: "data"}},
+		},
+	).Build()
+
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-1", VgName: "vg1", LvSize: "10737418240", DataPercent: "20.00", KernelMinor: "2"},
+		{Name: "lv-1", VgName: "vg1", PoolName: "thin-pool-1", LvSize: "1073741824", DataPercent: "12.50", KernelMinor: "3"},
+		{Name: "lv-2", VgName: "vg1", PoolName: "thin-pool-1", LvSize: "2147483648", DataPercent: "0.00", KernelMinor: "-1"},
+	}}}}, nil).Once()
+
+	sysBlockPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(sysBlockPath, "dm-3"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sysBlockPath, "dm-3", "stat"),
+		[]byte("10 0 80 5 4 0 16 2 0 7 7 0 0 0 0\n"), 0644))
+
+	c := NewCollector(client, mockLVM, "node1")
+	c.sysBlockPath = sysBlockPath
+	require.NoError(t, c.refresh(ctx))
+
+	expected := `
+# HELP lvms_vgmanager_logical_volume_read_bytes_total Number of bytes read from a logical volume managed by LVMS.
+# TYPE lvms_vgmanager_logical_volume_read_bytes_total counter
+lvms_vgmanager_logical_volume_read_bytes_total{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 40960
+# HELP lvms_vgmanager_logical_volume_reads_completed_total Number of reads completed on a logical volume managed by LVMS.
+# TYPE lvms_vgmanager_logical_volume_reads_completed_total counter
+lvms_vgmanager_logical_volume_reads_completed_total{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 10
+# HELP lvms_vgmanager_logical_volume_size_bytes Allocated size of a logical volume managed by LVMS.
+# TYPE lvms_vgmanager_logical_volume_size_bytes gauge
+lvms_vgmanager_logical_volume_size_bytes{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 1.073741824e+09
+lvms_vgmanager_logical_volume_size_bytes{device_class="vg1",logical_volume="lv-2",node="node1",persistentvolumeclaim="",persistentvolumeclaim_namespace=""} 2.147483648e+09
+# HELP lvms_vgmanager_logical_volume_thin_data_percent Percentage of the size of a thin logical volume that is allocated in its thin pool.
+# TYPE lvms_vgmanager_logical_volume_thin_data_percent gauge
+lvms_vgmanager_logical_volume_thin_data_percent{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 12.5
+lvms_vgmanager_logical_volume_thin_data_percent{device_class="vg1",logical_volume="lv-2",node="node1",persistentvolumeclaim="",persistentvolumeclaim_namespace=""} 0
+# HELP lvms_vgmanager_logical_volume_writes_completed_total Number of writes completed on a logical volume managed by LVMS.
+# TYPE lvms_vgmanager_logical_volume_writes_completed_total counter
+lvms_vgmanager_logical_volume_writes_completed_total{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 4
+# HELP lvms_vgmanager_logical_volume_written_bytes_total Number of bytes written to a logical volume managed by LVMS.
+# TYPE lvms_vgmanager_logical_volume_written_bytes_total counter
+lvms_vgmanager_logical_volume_written_bytes_total{device_class="vg1",logical_volume="lv-1",node="node1",persistentvolumeclaim="data",persistentvolumeclaim_namespace="app"} 8192
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+}
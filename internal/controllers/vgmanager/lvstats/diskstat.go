@@ -0,0 +1,72 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lvstats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sectorSize is the unit of the sector counters in the block device statistics, independent of the
+// logical block size of the device.
+const sectorSize = 512
+
+// DiskStat is the subset of the I/O statistics of a block device reported in /sys/block/<device>/stat,
+// see https://www.kernel.org/doc/Documentation/block/stat.txt.
+type DiskStat struct {
+	ReadsCompleted  uint64
+	ReadBytes       uint64
+	WritesCompleted uint64
+	WrittenBytes    uint64
+}
+
+// ParseDiskStat parses the content of /sys/block/<device>/stat.
+func ParseDiskStat(data []byte) (DiskStat, error) {
+	fields := strings.Fields(string(data))
+	// the first 11 fields are reported by all kernel versions, later ones added discard and flush statistics
+	if len(fields) < 11 {
+		return DiskStat{}, fmt.Errorf("expected at least 11 fields in block device statistics, got %d", len(fields))
+	}
+
+	values := make([]uint64, 7)
+	for i := range values {
+		value, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return DiskStat{}, fmt.Errorf("failed to parse field %d of block device statistics: %w", i+1, err)
+		}
+		values[i] = value
+	}
+
+	return DiskStat{
+		ReadsCompleted:  values[0],
+		ReadBytes:       values[2] * sectorSize,
+		WritesCompleted: values[4],
+		WrittenBytes:    values[6] * sectorSize,
+	}, nil
+}
+
+// ReadDiskStat reads the I/O statistics of the device from the sysfs block directory.
+func ReadDiskStat(sysBlockPath, device string) (DiskStat, error) {
+	data, err := os.ReadFile(filepath.Join(sysBlockPath, device, "stat"))
+	if err != nil {
+		return DiskStat{}, fmt.Errorf("failed to read block device statistics of %s: %w", device, err)
+	}
+	return ParseDiskStat(data)
+}
@@ -0,0 +1,324 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// DefaultMetadataBackupInterval is the interval in which the metadata of the volume groups is backed up.
+	DefaultMetadataBackupInterval = time.Hour
+
+	// DefaultMetadataBackupHistory is the number of metadata backups that are kept per node and volume group.
+	DefaultMetadataBackupHistory = 10
+
+	// MetadataBackupKey is the key of the vgcfgbackup file in the data of the backup Secrets.
+	MetadataBackupKey = "metadata"
+
+	metadataBackupPrefix = "lvms-vg-metadata"
+
+	// metadataBackupHashLength is the length of the hash that replaces the end of names and label values
+	// that are too long.
+	metadataBackupHashLength = 16
+)
+
+var (
+	// ErrMetadataRestoreNotConfirmed is returned if the backup to restore is not annotated with the confirmation.
+	ErrMetadataRestoreNotConfirmed = errors.New("the metadata restore is not confirmed")
+
+	// seqnoPattern matches the sequence number of the volume group in a vgcfgbackup file,
+	// which is the first seqno as the logical volumes do not have one.
+	seqnoPattern = regexp.MustCompile(`(?m)^\s*seqno\s*=\s*(\d+)\s*$`)
+
+	// idPattern matches the UUID of the volume group in a vgcfgbackup file,
+	// which is the first id as the volume group is described before its physical and logical volumes.
+	idPattern = regexp.MustCompile(`(?m)^\s*id\s*=\s*"([^"]+)"\s*$`)
+)
+
+var _ manager.LeaderElectionRunnable = &MetadataBackup{}
+
+// MetadataBackup periodically backs up the LVM metadata of every volume group tagged by LVMS on the node into
+// Secrets in the operator namespace, so that it survives a reinstallation of the node. A new backup is only
+// stored when the UUID or the sequence number of the metadata changed, and only the latest History backups are kept.
+// The UUID distinguishes a volume group that was recreated with the same name, whose sequence numbers start over.
+type MetadataBackup struct {
+	client.Client
+	LVM lvm.LVM
+
+	NodeName  string
+	Namespace string
+
+	// Dir is the directory on the host vgcfgbackup writes the backups to. It has to be mounted at the same path.
+	Dir string
+	// Interval is the interval in which the metadata is backed up
+	Interval time.Duration
+	// History is the number of backups that are kept per volume group
+	History int
+}
+
+// Start implements controller-runtime's manager.Runnable and backs up the metadata until the context is done.
+func (b *MetadataBackup) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("metadata-backup")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(b.Interval)
+	defer ticker.Stop()
+	for {
+		if err := b.backup(ctx); err != nil {
+			logger.Error(err, "failed to back up the metadata of the volume groups")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's manager.LeaderElectionRunnable.
+func (b *MetadataBackup) NeedLeaderElection() bool {
+	return false
+}
+
+func (b *MetadataBackup) backup(ctx context.Context) error {
+	vgs, err := b.LVM.ListVGs(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list volume groups: %w", err)
+	}
+	var errs []error
+	for _, vg := range vgs {
+		if err := b.backupVolumeGroup(ctx, vg.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *MetadataBackup) backupVolumeGroup(ctx context.Context, vgName string) error {
+	logger := log.FromContext(ctx).WithValues("VGName", vgName)
+
+	if err := os.MkdirAll(b.Dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the metadata backup directory: %w", err)
+	}
+	file := filepath.Join(b.Dir, vgName+".vg")
+	if err := b.LVM.BackupVGMetadata(ctx, vgName, file); err != nil {
+		return err
+	}
+	metadata, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read the metadata backup of volume group %s: %w", vgName, err)
+	}
+	revision, err := metadataRevision(metadata)
+	if err != nil {
+		return fmt.Errorf("invalid metadata backup of volume group %s: %w", vgName, err)
+	}
+	id, err := metadataVolumeGroupID(metadata)
+	if err != nil {
+		return fmt.Errorf("invalid metadata backup of volume group %s: %w", vgName, err)
+	}
+
+	backups, err := ListMetadataBackups(ctx, b.Client, b.Namespace, b.NodeName, vgName)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(backups, func(secret corev1.Secret) bool {
+		return secret.Labels[constants.MetadataBackupVolumeGroupIDLabel] == id && backupRevision(&secret) == revision
+	}) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      metadataBackupName(b.NodeName, vgName, id, revision),
+				Namespace: b.Namespace,
+				Labels: map[string]string{
+					constants.MetadataBackupNodeLabel:          MetadataBackupLabelValue(b.NodeName),
+					constants.MetadataBackupVolumeGroupLabel:   MetadataBackupLabelValue(vgName),
+					constants.MetadataBackupVolumeGroupIDLabel: id,
+					constants.MetadataBackupRevisionLabel:      strconv.Itoa(revision),
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{MetadataBackupKey: metadata},
+		}
+		if err := b.Create(ctx, secret); k8serrors.IsAlreadyExists(err) {
+			// the cache did not observe the backup yet, the name is unique for the UUID and revision of the metadata
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to store the metadata backup of volume group %s: %w", vgName, err)
+		}
+		logger.Info("backed up the volume group metadata", "revision", revision, "secret", secret.Name)
+		backups = append(backups, *secret)
+	}
+
+	// prune the oldest backups beyond the history
+	for i := 0; i < len(backups)-b.History; i++ {
+		if err := b.Delete(ctx, &backups[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to prune metadata backup %s: %w", backups[i].Name, err)
+		}
+		logger.V(1).Info("pruned volume group metadata backup", "secret", backups[i].Name)
+	}
+	return nil
+}
+
+// ListMetadataBackups returns the metadata backups of the volume group on the node, ordered from the oldest to the
+// latest one. Backups are ordered by their creation and then by their revision, as the revision starts over when
+// the volume group is recreated.
+func ListMetadataBackups(ctx context.Context, c client.Reader, namespace, node, vgName string) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{
+		constants.MetadataBackupNodeLabel:        MetadataBackupLabelValue(node),
+		constants.MetadataBackupVolumeGroupLabel: MetadataBackupLabelValue(vgName),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list the metadata backups of volume group %s: %w", vgName, err)
+	}
+	backups := secrets.Items
+	slices.SortFunc(backups, func(a, b corev1.Secret) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return backupRevision(&a) - backupRevision(&b)
+	})
+	return backups, nil
+}
+
+// MetadataBackupLabelValue returns the value of the node and volume group labels of the metadata backups.
+// Values that exceed the length of a label value are shortened and made unique with a hash.
+func MetadataBackupLabelValue(value string) string {
+	return shortenWithHash(value, validation.LabelValueMaxLength)
+}
+
+// RestoreMetadata restores the metadata of the volume group on the node with vgcfgrestore from the backup with the
+// given revision, or from the latest backup if the revision is 0. As this overwrites the current metadata,
+// the backup Secret has to be annotated with constants.MetadataRestoreConfirmationAnnotation set to the name of
+// the volume group. The annotation is removed once the metadata was restored, so that every restore is confirmed.
+func RestoreMetadata(ctx context.Context, c client.Client, lvmClient lvm.LVM, dir, namespace, node, vgName string, revision int) error {
+	logger := log.FromContext(ctx).WithValues("VGName", vgName)
+
+	backups, err := ListMetadataBackups(ctx, c, namespace, node, vgName)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		return fmt.Errorf("there is no metadata backup of volume group %s on node %s", vgName, node)
+	}
+	backup := &backups[len(backups)-1]
+	if revision != 0 {
+		// if the volume group was recreated, the revision refers to the latest backup with it
+		i := len(backups) - 1
+		for i >= 0 && backupRevision(&backups[i]) != revision {
+			i--
+		}
+		if i < 0 {
+			return fmt.Errorf("there is no metadata backup of volume group %s on node %s with revision %d", vgName, node, revision)
+		}
+		backup = &backups[i]
+	}
+
+	if backup.Annotations[constants.MetadataRestoreConfirmationAnnotation] != vgName {
+		return fmt.Errorf("annotate Secret %s with %s=%s to restore revision %d of volume group %s: %w",
+			backup.Name, constants.MetadataRestoreConfirmationAnnotation, vgName, backupRevision(backup), vgName,
+			ErrMetadataRestoreNotConfirmed)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create the metadata backup directory: %w", err)
+	}
+	file := filepath.Join(dir, fmt.Sprintf("%s-restore-%d.vg", vgName, backupRevision(backup)))
+	if err := os.WriteFile(file, backup.Data[MetadataBackupKey], 0600); err != nil {
+		return fmt.Errorf("failed to write the metadata backup of volume group %s: %w", vgName, err)
+	}
+	defer func() {
+		_ = os.Remove(file)
+	}()
+
+	if err := lvmClient.RestoreVGMetadata(ctx, vgName, file); err != nil {
+		return err
+	}
+	logger.Info("restored the volume group metadata", "revision", backupRevision(backup), "secret", backup.Name)
+
+	original := backup.DeepCopy()
+	delete(backup.Annotations, constants.MetadataRestoreConfirmationAnnotation)
+	if err := c.Patch(ctx, backup, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to remove the restore confirmation from Secret %s: %w", backup.Name, err)
+	}
+	return nil
+}
+
+// metadataRevision returns the sequence number of the volume group in the vgcfgbackup file.
+func metadataRevision(metadata []byte) (int, error) {
+	match := seqnoPattern.FindSubmatch(metadata)
+	if match == nil {
+		return 0, errors.New("no seqno found")
+	}
+	return strconv.Atoi(string(match[1]))
+}
+
+func backupRevision(secret *corev1.Secret) int {
+	revision, _ := strconv.Atoi(secret.Labels[constants.MetadataBackupRevisionLabel])
+	return revision
+}
+
+// metadataVolumeGroupID returns the UUID of the volume group in the vgcfgbackup file.
+func metadataVolumeGroupID(metadata []byte) (string, error) {
+	match := idPattern.FindSubmatch(metadata)
+	if match == nil {
+		return "", errors.New("no volume group id found")
+	}
+	return string(match[1]), nil
+}
+
+// metadataBackupName returns the name of the backup Secret. It contains a hash of the UUID of the volume group,
+// so that the backups of a volume group that was recreated with the same name do not collide.
+func metadataBackupName(node, vgName, id string, revision int) string {
+	name := fmt.Sprintf("%s-%s-%s-%s-%d", metadataBackupPrefix, node, vgName, hashOf(id)[:8], revision)
+	return shortenWithHash(name, validation.DNS1123SubdomainMaxLength)
+}
+
+// shortenWithHash returns the value if it does not exceed the maximum length. Otherwise, the end of the value is
+// replaced with a hash of the complete value.
+func shortenWithHash(value string, maxLength int) string {
+	if len(value) <= maxLength {
+		return value
+	}
+	prefix := strings.TrimRight(value[:maxLength-metadataBackupHashLength-1], "-._")
+	return prefix + "-" + hashOf(value)[:metadataBackupHashLength]
+}
+
+func hashOf(value string) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(value))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
@@ -0,0 +1,212 @@
+package vgmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const testVGID = "2Pp4Yx-0Zyj-0Ebz-mPb3-UxB2-4uNE-cb5HuJ"
+
+func vgcfgbackup(seqno int) string {
+	return vgcfgbackupWithID(testVGID, seqno)
+}
+
+func vgcfgbackupWithID(id string, seqno int) string {
+	return fmt.Sprintf(`# Generated by LVM2 version 2.03.24(2) (2024-05-16): Mon Jan  6 10:00:00 2025
+
+contents = "Text Format Volume Group"
+version = 1
+
+vg1 {
+	id = "%s"
+	seqno = %d
+	format = "lvm2"
+	status = ["RESIZEABLE", "READ", "WRITE"]
+	tags = ["lvms"]
+}
+`, id, seqno)
+}
+
+func TestMetadataBackup(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	seqno := 1
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+	mockLVM.EXPECT().BackupVGMetadata(mock.Anything, "vg1", mock.Anything).RunAndReturn(
+		func(ctx context.Context, vgName string, file string) error {
+			return os.WriteFile(file, []byte(vgcfgbackup(seqno)), 0600)
+		})
+
+	backup := &MetadataBackup{
+		Client:    fakeClient,
+		LVM:       mockLVM,
+		NodeName:  "node1",
+		Namespace: "openshift-lvm-storage",
+		Dir:       t.TempDir(),
+		History:   2,
+	}
+	revisions := func() []string {
+		backups, err := ListMetadataBackups(ctx, fakeClient, backup.Namespace, backup.NodeName, "vg1")
+		require.NoError(t, err)
+		var revisions []string
+		for _, secret := range backups {
+			revisions = append(revisions, secret.Labels[constants.MetadataBackupRevisionLabel])
+		}
+		return revisions
+	}
+
+	require.NoError(t, backup.backup(ctx))
+	require.NoError(t, backup.backup(ctx))
+	assert.Equal(t, []string{"1"}, revisions(), "unchanged metadata must not be backed up again")
+
+	for seqno = 2; seqno <= 10; seqno++ {
+		require.NoError(t, backup.backup(ctx))
+	}
+	assert.Equal(t, []string{"9", "10"}, revisions(), "only the latest backups must be kept")
+
+	secret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{
+		Name:      metadataBackupName("node1", "vg1", testVGID, 10),
+		Namespace: backup.Namespace,
+	}, secret))
+	assert.Equal(t, vgcfgbackup(10), string(secret.Data[MetadataBackupKey]))
+	assert.Equal(t, testVGID, secret.Labels[constants.MetadataBackupVolumeGroupIDLabel])
+}
+
+func TestMetadataBackup_RecreatedVolumeGroup(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	// the fake client does not set the creation timestamp the backups are ordered by
+	created := time.Now()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			created = created.Add(time.Minute)
+			obj.SetCreationTimestamp(metav1.NewTime(created))
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	id, seqno := testVGID, 5
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+	mockLVM.EXPECT().BackupVGMetadata(mock.Anything, "vg1", mock.Anything).RunAndReturn(
+		func(ctx context.Context, vgName string, file string) error {
+			return os.WriteFile(file, []byte(vgcfgbackupWithID(id, seqno)), 0600)
+		})
+	backup := &MetadataBackup{
+		Client:    fakeClient,
+		LVM:       mockLVM,
+		NodeName:  "node1",
+		Namespace: "openshift-lvm-storage",
+		Dir:       t.TempDir(),
+		History:   2,
+	}
+
+	require.NoError(t, backup.backup(ctx))
+	seqno = 6
+	require.NoError(t, backup.backup(ctx))
+
+	// the recreated volume group starts over with its sequence number
+	id, seqno = "3Qq5Zy-1Azk-1Fca-nQc4-VyC3-5vOF-dc6IvK", 5
+	require.NoError(t, backup.backup(ctx))
+
+	backups, err := ListMetadataBackups(ctx, fakeClient, backup.Namespace, backup.NodeName, "vg1")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, metadataBackupName("node1", "vg1", testVGID, 6), backups[0].Name)
+	assert.Equal(t, metadataBackupName("node1", "vg1", id, 5), backups[1].Name, "the recreated volume group must be backed up")
+	assert.Equal(t, vgcfgbackupWithID(id, 5), string(backups[1].Data[MetadataBackupKey]))
+}
+
+func TestMetadataBackupNames(t *testing.T) {
+	node := strings.Repeat("node.", 50) + "example"
+	vgName := strings.Repeat("vg", 60)
+
+	name := metadataBackupName(node, vgName, testVGID, 42)
+	assert.Empty(t, validation.IsDNS1123Subdomain(name))
+	assert.NotEqual(t, name, metadataBackupName(node, vgName, testVGID, 43), "shortened names must stay unique")
+
+	for _, value := range []string{node, vgName} {
+		labelValue := MetadataBackupLabelValue(value)
+		assert.Empty(t, validation.IsValidLabelValue(labelValue))
+	}
+	assert.Equal(t, "node1", MetadataBackupLabelValue("node1"), "short values must be kept")
+}
+
+func TestRestoreMetadata(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	namespace := "openshift-lvm-storage"
+	backupSecret := func(revision int) *corev1.Secret {
+		secret := &corev1.Secret{}
+		secret.Name = metadataBackupName("node1", "vg1", testVGID, revision)
+		secret.Namespace = namespace
+		secret.Labels = map[string]string{
+			constants.MetadataBackupNodeLabel:          "node1",
+			constants.MetadataBackupVolumeGroupLabel:   "vg1",
+			constants.MetadataBackupVolumeGroupIDLabel: testVGID,
+			constants.MetadataBackupRevisionLabel:      fmt.Sprint(revision),
+		}
+		secret.Data = map[string][]byte{MetadataBackupKey: []byte(vgcfgbackup(revision))}
+		return secret
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(backupSecret(3), backupSecret(4)).Build()
+	mockLVM := lvmmocks.NewMockLVM(t)
+	dir := t.TempDir()
+
+	err := RestoreMetadata(ctx, fakeClient, mockLVM, dir, namespace, "node1", "vg1", 3)
+	assert.ErrorIs(t, err, ErrMetadataRestoreNotConfirmed)
+	err = RestoreMetadata(ctx, fakeClient, mockLVM, dir, namespace, "node1", "vg1", 5)
+	assert.ErrorContains(t, err, "no metadata backup of volume group vg1 on node node1 with revision 5")
+
+	secret := backupSecret(3)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	secret.Annotations = map[string]string{constants.MetadataRestoreConfirmationAnnotation: "vg1"}
+	require.NoError(t, fakeClient.Update(ctx, secret))
+
+	mockLVM.EXPECT().RestoreVGMetadata(mock.Anything, "vg1", mock.Anything).RunAndReturn(
+		func(ctx context.Context, vgName string, file string) error {
+			metadata, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, vgcfgbackup(3), string(metadata), "the chosen revision must be restored")
+			return nil
+		}).Once()
+	require.NoError(t, RestoreMetadata(ctx, fakeClient, mockLVM, dir, namespace, "node1", "vg1", 3))
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	assert.NotContains(t, secret.Annotations, constants.MetadataRestoreConfirmationAnnotation,
+		"the confirmation must only be valid for one restore")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the restored backup file must be removed")
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PrometheusRuleName = "vg-manager-rules"
+	AlertRuleGroupName = "vg-manager.rules"
+
+	AlertReconcileFailing = "VGManagerReconcileFailing"
+	AlertReconcileStale   = "VGManagerReconcileStale"
+
+	// ReconcileFailureStreakThreshold is the number of consecutive failed reconciliations
+	// of a volume group after which the failure is alerted on.
+	ReconcileFailureStreakThreshold = 5
+
+	// ReconcileStaleThreshold is the time between the last successful and the last reconciliation of a volume group
+	// after which the volume group is considered stale. Volume groups that are not reconciled at all because
+	// nothing changed are not stale, while failed reconciliations are retried with a backoff and keep
+	// advancing the last reconciliation.
+	ReconcileStaleThreshold = 10 * time.Minute
+
+	alertFor = monitoringv1.Duration("5m")
+)
+
+// PrometheusRule returns the alerts on the vgmanager metrics.
+func PrometheusRule() *monitoringv1.PrometheusRule {
+	return &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1.PrometheusRuleKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: PrometheusRuleName,
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{AlertRuleGroup()},
+		},
+	}
+}
+
+// PrometheusRuleYAML renders the PrometheusRule as it is shipped in config/prometheus.
+func PrometheusRuleYAML() ([]byte, error) {
+	return yaml.Marshal(PrometheusRule())
+}
+
+// AlertRuleGroup returns the rule group with the alerts on reconciliation failures and stale reconciliations.
+func AlertRuleGroup() monitoringv1.RuleGroup {
+	return monitoringv1.RuleGroup{
+		Name: AlertRuleGroupName,
+		Rules: []monitoringv1.Rule{
+			{
+				Alert: AlertReconcileFailing,
+				Expr: intstr.FromString(fmt.Sprintf("%s_%s_reconcile_consecutive_failures >= %d",
+					namespace, subsystem, ReconcileFailureStreakThreshold)),
+				For: ptr.To(alertFor),
+				Labels: map[string]string{
+					"severity": "warning",
+				},
+				Annotations: map[string]string{
+					"description": "The volume group keeps failing to reconcile on the node. Check the events and the status of the LVMVolumeGroupNodeStatus for the reason.",
+					"message": fmt.Sprintf("VolumeGroup {{ $labels.volume_group }} failed to reconcile at least %d times in a row on {{ $labels.pod }}.",
+						ReconcileFailureStreakThreshold),
+				},
+			},
+			{
+				Alert: AlertReconcileStale,
+				Expr: intstr.FromString(fmt.Sprintf("%[1]s_%[2]s_last_reconcile_timestamp_seconds - %[1]s_%[2]s_last_successful_reconcile_timestamp_seconds > %[3]d",
+					namespace, subsystem, int(ReconcileStaleThreshold.Seconds()))),
+				For: ptr.To(alertFor),
+				Labels: map[string]string{
+					"severity": "warning",
+				},
+				Annotations: map[string]string{
+					"description": "The volume group is reconciled but none of the reconciliations succeeded for a while. Its status on the node might be outdated.",
+					"message": fmt.Sprintf("VolumeGroup {{ $labels.volume_group }} was not reconciled successfully for more than %d minutes on {{ $labels.pod }}.",
+						int(ReconcileStaleThreshold.Minutes())),
+				},
+			},
+		},
+	}
+}
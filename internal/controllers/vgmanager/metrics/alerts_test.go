@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrometheusRuleIsUpToDate verifies that the shipped PrometheusRule matches its Go definition.
+// Run "make build-vgmanager-alert-rules" to regenerate it.
+func TestPrometheusRuleIsUpToDate(t *testing.T) {
+	expected, err := PrometheusRuleYAML()
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile("../../../../config/prometheus/vgmanager_prometheus_rules.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestAlertRuleGroup(t *testing.T) {
+	group := AlertRuleGroup()
+	assert.Equal(t, AlertRuleGroupName, group.Name)
+	assert.Len(t, group.Rules, 2)
+	for _, rule := range group.Rules {
+		assert.NotEmpty(t, rule.Expr.String())
+		assert.Equal(t, "warning", rule.Labels["severity"])
+		assert.Contains(t, rule.Annotations["message"], "{{ $labels.volume_group }}")
+	}
+}
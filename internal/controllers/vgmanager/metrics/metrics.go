@@ -0,0 +1,306 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "lvms"
+	subsystem = "vgmanager"
+
+	ResultSuccess = "success"
+	ResultError   = "error"
+
+	DeviceStateAvailable = "available"
+	DeviceStateExcluded  = "excluded"
+	DeviceStateUsed      = "used"
+)
+
+var (
+	ReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "reconcile_total",
+		Help:      "Number of reconciliations of an LVMVolumeGroup on the node by result.",
+	}, []string{"volume_group", "result"})
+
+	ReconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of errors reported while reconciling an LVMVolumeGroup on the node by reason.",
+	}, []string{"volume_group", "reason"})
+
+	ReconcileConsecutiveFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "reconcile_consecutive_failures",
+		Help:      "Number of reconciliations of an LVMVolumeGroup on the node that failed since the last successful one.",
+	}, []string{"volume_group"})
+
+	LastReconcileTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "last_reconcile_timestamp_seconds",
+		Help:      "Unix timestamp of the last reconciliation of an LVMVolumeGroup on the node, regardless of its result.",
+	}, []string{"volume_group"})
+
+	LastSuccessfulReconcileTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "last_successful_reconcile_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful reconciliation of an LVMVolumeGroup on the node.",
+	}, []string{"volume_group"})
+
+	VolumeGroupStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "volume_group_status",
+		Help:      "Status of the volume group on the node as reported in the LVMVolumeGroupNodeStatus. The current status is 1, all others are 0.",
+	}, []string{"volume_group", "status"})
+
+	MissingPhysicalVolumes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "missing_physical_volumes",
+		Help:      "Number of physical volumes of the volume group on the node that lvm2 reports as missing.",
+	}, []string{"volume_group"})
+
+	CommandDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "command_duration_seconds",
+		Help:      "Duration of the host commands run by vgmanager, such as the lvm2 commands or lsblk.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"command", "result"})
+
+	Devices = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "devices",
+		Help:      "Number of devices on the node that are available to, excluded from or used by a device class.",
+	}, []string{"device_class", "state"})
+
+	DeviceWipesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_wipes_total",
+		Help:      "Number of devices wiped for a device class by result.",
+	}, []string{"device_class", "result"})
+
+	DeviceRemovalsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_removals_total",
+		Help:      "Number of devices removed from the volume group of a device class by result.",
+	}, []string{"device_class", "result"})
+
+	DeviceSMARTHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_smart_healthy",
+		Help:      "Whether the disk passed its SMART overall-health self-assessment (1) or not (0).",
+	}, []string{"device"})
+
+	DeviceReallocatedSectors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_reallocated_sectors",
+		Help:      "Number of reallocated sectors of an ATA or SCSI disk as reported by SMART.",
+	}, []string{"device"})
+
+	DeviceMediaErrors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_media_errors",
+		Help:      "Number of unrecovered media and data integrity errors of an NVMe disk as reported by SMART.",
+	}, []string{"device"})
+
+	DevicePercentageUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_percentage_used",
+		Help:      "Estimate of the used endurance of an NVMe disk in percent as reported by SMART.",
+	}, []string{"device"})
+
+	DeviceTemperatureCelsius = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "device_temperature_celsius",
+		Help:      "Current temperature of the disk in degrees Celsius as reported by SMART.",
+	}, []string{"device"})
+)
+
+// Collectors returns all metrics of vgmanager.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		ReconcileTotal,
+		ReconcileErrorsTotal,
+		ReconcileConsecutiveFailures,
+		LastReconcileTimestamp,
+		LastSuccessfulReconcileTimestamp,
+		VolumeGroupStatus,
+		MissingPhysicalVolumes,
+		CommandDurationSeconds,
+		Devices,
+		DeviceWipesTotal,
+		DeviceRemovalsTotal,
+		DeviceSMARTHealthy,
+		DeviceReallocatedSectors,
+		DeviceMediaErrors,
+		DevicePercentageUsed,
+		DeviceTemperatureCelsius,
+	}
+}
+
+// Register registers all metrics of vgmanager with the registerer.
+// Metrics that are already registered are skipped.
+func Register(registerer prometheus.Registerer) error {
+	for _, collector := range Collectors() {
+		if err := registerer.Register(collector); err != nil {
+			var alreadyRegistered prometheus.AlreadyRegisteredError
+			if errors.As(err, &alreadyRegistered) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Result returns the result label value for the error.
+func Result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
+
+// ObserveReconcile records the outcome of a reconciliation of the volume group.
+func ObserveReconcile(volumeGroup string, err error) {
+	ReconcileTotal.WithLabelValues(volumeGroup, Result(err)).Inc()
+	LastReconcileTimestamp.WithLabelValues(volumeGroup).SetToCurrentTime()
+	if err != nil {
+		ReconcileConsecutiveFailures.WithLabelValues(volumeGroup).Inc()
+		return
+	}
+	ReconcileConsecutiveFailures.WithLabelValues(volumeGroup).Set(0)
+	LastSuccessfulReconcileTimestamp.WithLabelValues(volumeGroup).SetToCurrentTime()
+}
+
+// ObserveCommand records the duration of a host command. Only the base name of the command is used as label
+// to keep the cardinality low.
+func ObserveCommand(command string, duration time.Duration, err error) {
+	CommandDurationSeconds.WithLabelValues(filepath.Base(command), Result(err)).Observe(duration.Seconds())
+}
+
+// ObserveDeviceWipe records a wipe of a device selected for the device class.
+func ObserveDeviceWipe(deviceClass string, err error) {
+	DeviceWipesTotal.WithLabelValues(deviceClass, Result(err)).Inc()
+}
+
+// ObserveDeviceRemoval records a removal of a device from the volume group of the device class.
+func ObserveDeviceRemoval(deviceClass string, err error) {
+	DeviceRemovalsTotal.WithLabelValues(deviceClass, Result(err)).Inc()
+}
+
+// volumeGroupStatuses are all statuses a volume group can report on a node.
+var volumeGroupStatuses = []lvmv1alpha1.VGStatusType{
+	lvmv1alpha1.VGStatusProgressing,
+	lvmv1alpha1.VGStatusReady,
+	lvmv1alpha1.VGStatusDegraded,
+	lvmv1alpha1.VGStatusFailed,
+	lvmv1alpha1.VGStatusOrphaned,
+}
+
+// SetVolumeGroupStatus records the status of the volume group and the number of its missing physical volumes.
+func SetVolumeGroupStatus(status *lvmv1alpha1.VGStatus) {
+	for _, vgStatus := range volumeGroupStatuses {
+		value := float64(0)
+		if status.Status == vgStatus {
+			value = 1
+		}
+		VolumeGroupStatus.WithLabelValues(status.Name, string(vgStatus)).Set(value)
+	}
+
+	missing := 0
+	for _, pv := range status.PhysicalVolumes {
+		if pv.Missing {
+			missing++
+		}
+	}
+	MissingPhysicalVolumes.WithLabelValues(status.Name).Set(float64(missing))
+}
+
+// SetDevices records the number of devices by state for the device class.
+func SetDevices(deviceClass string, available, excluded, used int) {
+	Devices.WithLabelValues(deviceClass, DeviceStateAvailable).Set(float64(available))
+	Devices.WithLabelValues(deviceClass, DeviceStateExcluded).Set(float64(excluded))
+	Devices.WithLabelValues(deviceClass, DeviceStateUsed).Set(float64(used))
+}
+
+// SetDeviceHealth records the health of the disk. Attributes that are not reported for the type of the disk are skipped.
+func SetDeviceHealth(health *lvmv1alpha1.DeviceHealth) {
+	healthy := float64(0)
+	if health.Healthy {
+		healthy = 1
+	}
+	DeviceSMARTHealthy.WithLabelValues(health.Device).Set(healthy)
+
+	for _, attribute := range []struct {
+		gauge *prometheus.GaugeVec
+		value *int64
+	}{
+		{DeviceReallocatedSectors, health.ReallocatedSectors},
+		{DeviceMediaErrors, health.MediaErrors},
+		{DevicePercentageUsed, health.PercentageUsed},
+		{DeviceTemperatureCelsius, health.Temperature},
+	} {
+		if attribute.value != nil {
+			attribute.gauge.WithLabelValues(health.Device).Set(float64(*attribute.value))
+		}
+	}
+}
+
+// DeleteVolumeGroup removes all series of the volume group, e.g. after the volume group was removed from the node.
+func DeleteVolumeGroup(volumeGroup string) {
+	for _, vec := range []*prometheus.MetricVec{
+		ReconcileTotal.MetricVec,
+		ReconcileErrorsTotal.MetricVec,
+		ReconcileConsecutiveFailures.MetricVec,
+		LastReconcileTimestamp.MetricVec,
+		LastSuccessfulReconcileTimestamp.MetricVec,
+		VolumeGroupStatus.MetricVec,
+		MissingPhysicalVolumes.MetricVec,
+	} {
+		vec.DeletePartialMatch(prometheus.Labels{"volume_group": volumeGroup})
+	}
+	for _, vec := range []*prometheus.MetricVec{
+		Devices.MetricVec,
+		DeviceWipesTotal.MetricVec,
+		DeviceRemovalsTotal.MetricVec,
+	} {
+		vec.DeletePartialMatch(prometheus.Labels{"device_class": volumeGroup})
+	}
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	t.Cleanup(func() { DeleteVolumeGroup("vg-reconcile") })
+
+	ObserveReconcile("vg-reconcile", errors.New("failed"))
+	ObserveReconcile("vg-reconcile", errors.New("failed"))
+	assert.Equal(t, float64(2), testutil.ToFloat64(ReconcileConsecutiveFailures.WithLabelValues("vg-reconcile")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(ReconcileTotal.WithLabelValues("vg-reconcile", ResultError)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(LastSuccessfulReconcileTimestamp.WithLabelValues("vg-reconcile")))
+	assert.NotZero(t, testutil.ToFloat64(LastReconcileTimestamp.WithLabelValues("vg-reconcile")))
+
+	before := time.Now().Unix()
+	ObserveReconcile("vg-reconcile", nil)
+	assert.Equal(t, float64(0), testutil.ToFloat64(ReconcileConsecutiveFailures.WithLabelValues("vg-reconcile")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ReconcileTotal.WithLabelValues("vg-reconcile", ResultSuccess)))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(LastSuccessfulReconcileTimestamp.WithLabelValues("vg-reconcile")), float64(before))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(LastReconcileTimestamp.WithLabelValues("vg-reconcile")), float64(before))
+}
+
+func TestObserveCommand(t *testing.T) {
+	CommandDurationSeconds.Reset()
+	t.Cleanup(CommandDurationSeconds.Reset)
+
+	ObserveCommand("/usr/sbin/vgs", time.Second, nil)
+	ObserveCommand("vgs", time.Second, nil)
+	ObserveCommand("/usr/bin/lsblk", time.Second, errors.New("failed"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(CommandDurationSeconds))
+	assert.Equal(t, 1, testutil.CollectAndCount(CommandDurationSeconds.WithLabelValues("vgs", ResultSuccess).(prometheus.Histogram)))
+}
+
+func TestDeleteVolumeGroup(t *testing.T) {
+	SetDevices("vg-delete", 2, 1, 3)
+	ObserveReconcile("vg-delete", nil)
+	ObserveDeviceWipe("vg-delete", nil)
+	ObserveDeviceRemoval("vg-delete", errors.New("failed"))
+	ObserveReconcile("vg-other", nil)
+	t.Cleanup(func() { DeleteVolumeGroup("vg-other") })
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(Devices.WithLabelValues("vg-delete", DeviceStateAvailable)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(Devices.WithLabelValues("vg-delete", DeviceStateExcluded)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(Devices.WithLabelValues("vg-delete", DeviceStateUsed)))
+
+	DeleteVolumeGroup("vg-delete")
+	assert.Equal(t, 0, testutil.CollectAndCount(Devices))
+	assert.Equal(t, 0, testutil.CollectAndCount(DeviceWipesTotal))
+	assert.Equal(t, 0, testutil.CollectAndCount(DeviceRemovalsTotal))
+	assert.Equal(t, 1, testutil.CollectAndCount(ReconcileTotal))
+	assert.Equal(t, 1, testutil.CollectAndCount(LastSuccessfulReconcileTimestamp))
+}
+
+func TestRegister(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, Register(registry))
+	assert.NoError(t, Register(registry), "registering the metrics twice should be a no-op")
+
+	problems, err := testutil.GatherAndLint(registry)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestSetVolumeGroupStatus(t *testing.T) {
+	t.Cleanup(func() { DeleteVolumeGroup("vg-status") })
+
+	SetVolumeGroupStatus(&lvmv1alpha1.VGStatus{
+		Name:   "vg-status",
+		Status: lvmv1alpha1.VGStatusDegraded,
+		PhysicalVolumes: []lvmv1alpha1.PhysicalVolumeStatus{
+			{Name: "/dev/sda"},
+			{Name: "/dev/sdb", Missing: true},
+		},
+	})
+	assert.Equal(t, float64(1), testutil.ToFloat64(VolumeGroupStatus.WithLabelValues("vg-status", string(lvmv1alpha1.VGStatusDegraded))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(VolumeGroupStatus.WithLabelValues("vg-status", string(lvmv1alpha1.VGStatusReady))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(MissingPhysicalVolumes.WithLabelValues("vg-status")))
+
+	SetVolumeGroupStatus(&lvmv1alpha1.VGStatus{Name: "vg-status", Status: lvmv1alpha1.VGStatusReady})
+	assert.Equal(t, float64(0), testutil.ToFloat64(VolumeGroupStatus.WithLabelValues("vg-status", string(lvmv1alpha1.VGStatusDegraded))))
+	assert.Equal(t, float64(1), testutil.ToFloat64(VolumeGroupStatus.WithLabelValues("vg-status", string(lvmv1alpha1.VGStatusReady))))
+	assert.Equal(t, float64(0), testutil.ToFloat64(MissingPhysicalVolumes.WithLabelValues("vg-status")))
+}
+
+func TestSetDeviceHealth(t *testing.T) {
+	for _, gauge := range []*prometheus.GaugeVec{
+		DeviceSMARTHealthy, DeviceReallocatedSectors, DeviceMediaErrors, DevicePercentageUsed, DeviceTemperatureCelsius,
+	} {
+		gauge.Reset()
+		t.Cleanup(gauge.Reset)
+	}
+
+	SetDeviceHealth(&lvmv1alpha1.DeviceHealth{Device: "/dev/sda", Healthy: false, ReallocatedSectors: ptr.To[int64](8), Temperature: ptr.To[int64](40)})
+	SetDeviceHealth(&lvmv1alpha1.DeviceHealth{Device: "/dev/nvme0n1", Healthy: true, MediaErrors: ptr.To[int64](0), PercentageUsed: ptr.To[int64](3)})
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(DeviceSMARTHealthy.WithLabelValues("/dev/sda")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(DeviceSMARTHealthy.WithLabelValues("/dev/nvme0n1")))
+	assert.Equal(t, float64(8), testutil.ToFloat64(DeviceReallocatedSectors.WithLabelValues("/dev/sda")))
+	assert.Equal(t, float64(40), testutil.ToFloat64(DeviceTemperatureCelsius.WithLabelValues("/dev/sda")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(DevicePercentageUsed.WithLabelValues("/dev/nvme0n1")))
+	assert.Equal(t, 1, testutil.CollectAndCount(DeviceReallocatedSectors), "attributes that are not reported must be skipped")
+	assert.Equal(t, 1, testutil.CollectAndCount(DeviceMediaErrors))
+}
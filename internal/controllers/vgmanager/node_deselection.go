@@ -0,0 +1,174 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MessageOrphanedVolumeGroup is the reason of the Orphaned status of a volume group that is retained on a node
+// which no longer matches the node selector of the device class.
+const MessageOrphanedVolumeGroup = "the node no longer matches the node selector of the device class, " +
+	"the volume group is orphaned but retained because it still holds %s"
+
+// processDeselectedNode handles a volume group whose node selector no longer matches this node after the volume group
+// was set up on it. The volume group is removed from the node like on deletion, unless it still holds
+// persistent volumes, logical volumes or TopoLVM LogicalVolumes, in which case it is retained and reported
+// as orphaned until they are removed.
+func (r *Reconciler) processDeselectedNode(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("VGName", volumeGroup.Name)
+
+	vgs, err := r.ListVGs(ctx, true)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list volume groups: %w", err)
+	}
+	retained, err := r.retainedVolumesOnNode(ctx, volumeGroup, vgs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(retained) == 0 {
+		logger.Info("node no longer matches the node selector, removing the volume group from the node")
+		if err := r.processDelete(ctx, volumeGroup); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove volume group %s from the node: %w", volumeGroup.Name, err)
+		}
+		metrics.DeleteVolumeGroup(volumeGroup.GetName())
+		return ctrl.Result{}, nil
+	}
+
+	reason := fmt.Sprintf(MessageOrphanedVolumeGroup, strings.Join(retained, "; "))
+	updated, err := r.setVolumeGroupOrphanedStatus(ctx, volumeGroup, vgs, reason)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set orphaned status: %w", err)
+	}
+	if updated {
+		r.WarningEvent(ctx, volumeGroup, EventReasonErrorVolumeGroupOrphaned, errors.New(reason))
+	}
+
+	// the persistent volumes are not watched, so check again later if they were removed
+	return reconcileAgain, nil
+}
+
+// retainedVolumesOnNode describes the persistent volumes, the logical volumes apart from the thin pool and the
+// TopoLVM LogicalVolumes of the volume group on this node that prevent its removal. Each of them is checked, as a
+// persistent volume can be deleted while its logical volume is still in use or retained by the reclaim policy.
+func (r *Reconciler) retainedVolumesOnNode(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup) ([]string, error) {
+	var retained []string
+
+	pvs, err := r.persistentVolumesOnNode(ctx, volumeGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) > 0 {
+		retained = append(retained, fmt.Sprintf("%d PersistentVolume(s): %s", len(pvs), strings.Join(pvs, ", ")))
+	}
+
+	if slices.ContainsFunc(vgs, func(vg lvm.VolumeGroup) bool { return vg.Name == volumeGroup.Name }) {
+		lvs, err := r.ListLVsByName(ctx, volumeGroup.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", volumeGroup.Name, err)
+		}
+		lvs = slices.DeleteFunc(slices.Clone(lvs), func(lv string) bool {
+			return volumeGroup.Spec.ThinPoolConfig != nil && lv == volumeGroup.Spec.ThinPoolConfig.Name
+		})
+		slices.Sort(lvs)
+		if len(lvs) > 0 {
+			retained = append(retained, fmt.Sprintf("%d logical volume(s): %s", len(lvs), strings.Join(lvs, ", ")))
+		}
+	}
+
+	logicalVolumes, err := r.logicalVolumesOnNode(ctx, volumeGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(logicalVolumes) > 0 {
+		retained = append(retained, fmt.Sprintf("%d LogicalVolume(s): %s", len(logicalVolumes), strings.Join(logicalVolumes, ", ")))
+	}
+
+	return retained, nil
+}
+
+// logicalVolumesOnNode returns the sorted names of the TopoLVM LogicalVolumes that are assigned to the device class
+// of the volume group on this node.
+func (r *Reconciler) logicalVolumesOnNode(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup) ([]string, error) {
+	logicalVolumeList := &topolvmv1.LogicalVolumeList{}
+	if err := r.List(ctx, logicalVolumeList); err != nil {
+		return nil, fmt.Errorf("failed to list TopoLVM LogicalVolumes: %w", err)
+	}
+
+	var logicalVolumes []string
+	for _, logicalVolume := range logicalVolumeList.Items {
+		if logicalVolume.Spec.NodeName == r.NodeName && logicalVolume.Spec.DeviceClass == volumeGroup.Name {
+			logicalVolumes = append(logicalVolumes, logicalVolume.Name)
+		}
+	}
+	slices.Sort(logicalVolumes)
+	return logicalVolumes, nil
+}
+
+// persistentVolumesOnNode returns the sorted names of the persistent volumes that are provisioned
+// from the volume group on this node.
+func (r *Reconciler) persistentVolumesOnNode(ctx context.Context, volumeGroup *lvmv1alpha1.LVMVolumeGroup) ([]string, error) {
+	pvList := &corev1.PersistentVolumeList{}
+	if err := r.List(ctx, pvList); err != nil {
+		return nil, fmt.Errorf("failed to list PersistentVolumes: %w", err)
+	}
+
+	var pvs []string
+	for _, pv := range pvList.Items {
+		if pv.Spec.StorageClassName != constants.StorageClassPrefix+volumeGroup.Name {
+			continue
+		}
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != constants.TopolvmCSIDriverName {
+			continue
+		}
+		if persistentVolumeNode(&pv) == r.NodeName {
+			pvs = append(pvs, pv.Name)
+		}
+	}
+	slices.Sort(pvs)
+	return pvs, nil
+}
+
+// persistentVolumeNode returns the node the persistent volume was provisioned on by TopoLVM.
+func persistentVolumeNode(pv *corev1.PersistentVolume) string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == constants.TopologyNodeKey &&
+				expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) > 0 {
+				return expression.Values[0]
+			}
+		}
+	}
+	return ""
+}
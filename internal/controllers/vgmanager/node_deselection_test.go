@@ -0,0 +1,153 @@
+package vgmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func topolvmPersistentVolume(name, storageClass, node string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName: storageClass,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: constants.TopolvmCSIDriverName, VolumeHandle: name},
+			},
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      constants.TopologyNodeKey,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{node},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestProcessDeselectedNode(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "test", UID: "uid"}}
+	mockLVM := lvmmocks.NewMockLVM(t)
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(
+				topolvmPersistentVolume("pv-b", "lvms-vg1", "test-node"),
+				topolvmPersistentVolume("pv-a", "lvms-vg1", "test-node"),
+				topolvmPersistentVolume("other-node", "lvms-vg1", "other-node"),
+				topolvmPersistentVolume("other-device-class", "lvms-vg2", "test-node"),
+			).
+			WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build(),
+		Scheme:        scheme,
+		EventRecorder: events.NewFakeRecorder(10),
+		LVM:           mockLVM,
+		NodeName:      "test-node",
+		Namespace:     "test",
+	}
+
+	pvs, err := r.persistentVolumesOnNode(ctx, vg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pv-a", "pv-b"}, pvs, "only the volumes of the device class on this node must be considered")
+
+	mockLVM.EXPECT().ListVGs(ctx, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+	mockLVM.EXPECT().ListLVsByName(ctx, "vg1").Return(nil, nil)
+	result, err := r.processDeselectedNode(ctx, vg)
+	require.NoError(t, err)
+	assert.Equal(t, reconcileAgain, result, "the volume group must be checked again until the volumes are removed")
+
+	nodeStatus := r.getLVMVolumeGroupNodeStatus()
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+	require.Len(t, nodeStatus.Spec.LVMVGStatus, 1)
+	assert.Equal(t, lvmv1alpha1.VGStatusOrphaned, nodeStatus.Spec.LVMVGStatus[0].Status)
+	assert.Contains(t, nodeStatus.Spec.LVMVGStatus[0].Reason, "pv-a, pv-b")
+}
+
+func TestProcessDeselectedNode_RemainingLogicalVolumes(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	vg := &lvmv1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "test", UID: "uid"},
+		Spec: lvmv1alpha1.LVMVolumeGroupSpec{
+			ThinPoolConfig: &lvmv1alpha1.ThinPoolConfig{Name: "thin-pool-1"},
+		},
+	}
+	newReconciler := func(objs ...client.Object) (*Reconciler, *lvmmocks.MockLVM) {
+		mockLVM := lvmmocks.NewMockLVM(t)
+		return &Reconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(&lvmv1alpha1.LVMVolumeGroupNodeStatus{}).Build(),
+			Scheme:        scheme,
+			EventRecorder: events.NewFakeRecorder(10),
+			LVM:           mockLVM,
+			NodeName:      "test-node",
+			Namespace:     "test",
+		}, mockLVM
+	}
+	assertOrphaned := func(t *testing.T, r *Reconciler, result ctrl.Result, err error, reason string) {
+		require.NoError(t, err)
+		assert.Equal(t, reconcileAgain, result)
+		nodeStatus := r.getLVMVolumeGroupNodeStatus()
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+		require.Len(t, nodeStatus.Spec.LVMVGStatus, 1)
+		assert.Equal(t, lvmv1alpha1.VGStatusOrphaned, nodeStatus.Spec.LVMVGStatus[0].Status)
+		assert.Contains(t, nodeStatus.Spec.LVMVGStatus[0].Reason, reason)
+	}
+
+	t.Run("logical volume without persistent volume", func(t *testing.T) {
+		r, mockLVM := newReconciler()
+		// the volume group must not be removed, so no other LVM operations are expected
+		mockLVM.EXPECT().ListVGs(ctx, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+		mockLVM.EXPECT().ListLVsByName(ctx, "vg1").Return([]string{"thin-pool-1", "retained-lv"}, nil)
+
+		result, err := r.processDeselectedNode(ctx, vg)
+		assertOrphaned(t, r, result, err, "1 logical volume(s): retained-lv")
+	})
+
+	t.Run("LogicalVolume assigned to the node", func(t *testing.T) {
+		r, mockLVM := newReconciler(
+			&topolvmv1.LogicalVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pending"},
+				Spec:       topolvmv1.LogicalVolumeSpec{NodeName: "test-node", DeviceClass: "vg1"},
+			},
+			&topolvmv1.LogicalVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-node"},
+				Spec:       topolvmv1.LogicalVolumeSpec{NodeName: "other-node", DeviceClass: "vg1"},
+			},
+		)
+		mockLVM.EXPECT().ListVGs(ctx, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+		mockLVM.EXPECT().ListLVsByName(ctx, "vg1").Return([]string{"thin-pool-1"}, nil)
+
+		result, err := r.processDeselectedNode(ctx, vg)
+		assertOrphaned(t, r, result, err, "1 LogicalVolume(s): pending")
+	})
+}
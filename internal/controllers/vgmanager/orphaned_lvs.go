@@ -0,0 +1,243 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// DefaultLogicalVolumeAuditInterval is the interval in which the logical volumes are compared
+	// with the TopoLVM LogicalVolumes of the node.
+	DefaultLogicalVolumeAuditInterval = 10 * time.Minute
+
+	// DefaultOrphanedLogicalVolumeGracePeriod is the time an orphaned logical volume is retained after it was first
+	// found to be orphaned if the OrphanedLogicalVolumePolicy does not specify a grace period.
+	DefaultOrphanedLogicalVolumeGracePeriod = 24 * time.Hour
+
+	// orphanedLogicalVolumeMinAge is the age a logical volume needs to reach before it is considered orphaned,
+	// as TopoLVM only records the volume ID in the LogicalVolume after the logical volume was created.
+	orphanedLogicalVolumeMinAge = 5 * time.Minute
+
+	// lvTimeLayout is the layout of the lv_time column of lvs.
+	lvTimeLayout = "2006-01-02 15:04:05 -0700"
+)
+
+var _ manager.LeaderElectionRunnable = &LogicalVolumeAudit{}
+
+// LogicalVolumeAudit periodically compares the logical volumes in the volume groups on the node with the
+// TopoLVM LogicalVolumes of the node. Logical volumes provisioned by TopoLVM that no LogicalVolume refers to are
+// reported as orphaned, and LogicalVolumes whose logical volume does not exist are reported as missing, in the
+// status of the LVMVolumeGroupNodeStatus. Orphaned logical volumes are deleted once they were reported as orphaned
+// for longer than the grace period if the OrphanedLogicalVolumePolicy of the volume group says so.
+// Missing logical volumes are only reported.
+type LogicalVolumeAudit struct {
+	client.Client
+	LVM lvm.LVM
+
+	NodeName  string
+	Namespace string
+
+	// Interval is the interval in which the logical volumes are audited
+	Interval time.Duration
+}
+
+// Start implements controller-runtime's manager.Runnable and audits the logical volumes until the context is done.
+func (a *LogicalVolumeAudit) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("logical-volume-audit")
+	ctx = log.IntoContext(ctx, logger)
+
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	for {
+		if err := a.audit(ctx, time.Now()); err != nil {
+			logger.Error(err, "failed to audit the logical volumes")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements controller-runtime's manager.LeaderElectionRunnable.
+func (a *LogicalVolumeAudit) NeedLeaderElection() bool {
+	return false
+}
+
+func (a *LogicalVolumeAudit) audit(ctx context.Context, now time.Time) error {
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{}
+	if err := a.Get(ctx, client.ObjectKey{Name: a.NodeName, Namespace: a.Namespace}, nodeStatus); err != nil {
+		// the node status is created once the first volume group is reconciled
+		return client.IgnoreNotFound(err)
+	}
+
+	volumeGroups := &lvmv1alpha1.LVMVolumeGroupList{}
+	if err := a.List(ctx, volumeGroups, client.InNamespace(a.Namespace)); err != nil {
+		return fmt.Errorf("failed to list LVMVolumeGroups: %w", err)
+	}
+	logicalVolumes := &topolvmv1.LogicalVolumeList{}
+	if err := a.List(ctx, logicalVolumes); err != nil {
+		return fmt.Errorf("failed to list TopoLVM LogicalVolumes: %w", err)
+	}
+	vgs, err := a.LVM.ListVGs(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list volume groups: %w", err)
+	}
+
+	original := nodeStatus.DeepCopy()
+	var errs []error
+	for i := range nodeStatus.Status.VolumeGroups {
+		status := &nodeStatus.Status.VolumeGroups[i]
+		j := slices.IndexFunc(volumeGroups.Items, func(vg lvmv1alpha1.LVMVolumeGroup) bool { return vg.Name == status.Name })
+		if j < 0 || !slices.ContainsFunc(vgs, func(vg lvm.VolumeGroup) bool { return vg.Name == status.Name }) {
+			continue
+		}
+		if err := a.auditVolumeGroup(ctx, &volumeGroups.Items[j], logicalVolumes.Items, status, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(original.Status, nodeStatus.Status) {
+		if err := a.Status().Patch(ctx, nodeStatus, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+			errs = append(errs, fmt.Errorf("failed to report the audited logical volumes: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// auditVolumeGroup sets the orphaned and missing logical volumes of the volume group in its status
+// and deletes the orphaned logical volumes whose grace period expired according to the policy.
+func (a *LogicalVolumeAudit) auditVolumeGroup(
+	ctx context.Context,
+	vg *lvmv1alpha1.LVMVolumeGroup,
+	logicalVolumes []topolvmv1.LogicalVolume,
+	status *lvmv1alpha1.VolumeGroupConditions,
+	now time.Time,
+) error {
+	logger := log.FromContext(ctx).WithValues("VGName", vg.Name)
+
+	report, err := a.LVM.ListLVs(ctx, vg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list logical volumes in volume group %s: %w", vg.Name, err)
+	}
+	var lvs []lvm.LogicalVolume
+	for _, item := range report.Report {
+		lvs = append(lvs, item.Lv...)
+	}
+
+	// volume IDs of all LogicalVolumes of the volume group on the node, including the ones being deleted
+	referenced := make(map[string]struct{})
+	var missing []lvmv1alpha1.MissingLogicalVolume
+	for _, logicalVolume := range logicalVolumes {
+		if logicalVolume.Spec.DeviceClass != vg.Name {
+			continue
+		}
+		// TopoLVM names the logical volume after the UID of the LogicalVolume before it records the volume ID
+		referenced[string(logicalVolume.UID)] = struct{}{}
+		if logicalVolume.Spec.NodeName != a.NodeName || logicalVolume.Status.VolumeID == "" {
+			continue
+		}
+		referenced[logicalVolume.Status.VolumeID] = struct{}{}
+		if logicalVolume.DeletionTimestamp != nil {
+			continue
+		}
+		if !slices.ContainsFunc(lvs, func(lv lvm.LogicalVolume) bool { return lv.Name == logicalVolume.Status.VolumeID }) {
+			missing = append(missing, lvmv1alpha1.MissingLogicalVolume{
+				Name:     logicalVolume.Name,
+				VolumeID: logicalVolume.Status.VolumeID,
+			})
+		}
+	}
+
+	policy := vg.Spec.OrphanedLogicalVolumePolicy
+	var orphaned []lvmv1alpha1.OrphanedLogicalVolume
+	var errs []error
+	for _, lv := range lvs {
+		if _, ok := referenced[lv.Name]; ok || !isTopoLVMVolume(lv) {
+			continue
+		}
+		orphan := lvmv1alpha1.OrphanedLogicalVolume{Name: lv.Name, Size: parseLVMQuantity(lv.LvSize)}
+		if created, err := time.Parse(lvTimeLayout, lv.Time); err == nil {
+			if now.Sub(created) < orphanedLogicalVolumeMinAge {
+				continue
+			}
+			orphan.CreationTime = &metav1.Time{Time: created}
+		}
+		// the grace period starts once the logical volume is found to be orphaned, not when it was created,
+		// as long-lived volumes would otherwise be deleted as soon as their LogicalVolume disappears
+		orphan.OrphanedSince = &metav1.Time{Time: now}
+		if i := slices.IndexFunc(status.OrphanedLogicalVolumes, func(reported lvmv1alpha1.OrphanedLogicalVolume) bool {
+			return reported.Name == lv.Name && reported.OrphanedSince != nil
+		}); i >= 0 {
+			orphan.OrphanedSince = status.OrphanedLogicalVolumes[i].OrphanedSince
+		}
+
+		// logical volumes without a known creation time are never deleted, as their minimum age cannot be verified
+		if policy != nil && policy.Action == lvmv1alpha1.OrphanedLogicalVolumeActionDelete && orphan.CreationTime != nil {
+			gracePeriod := DefaultOrphanedLogicalVolumeGracePeriod
+			if policy.GracePeriod != nil {
+				gracePeriod = policy.GracePeriod.Duration
+			}
+			deletionTime := orphan.OrphanedSince.Add(gracePeriod)
+			if !now.Before(deletionTime) {
+				if err := a.LVM.DeleteLV(ctx, lv.Name, vg.Name); err != nil {
+					errs = append(errs, fmt.Errorf("failed to delete orphaned logical volume %s in volume group %s: %w", lv.Name, vg.Name, err))
+				} else {
+					logger.Info("deleted orphaned logical volume", "LVName", lv.Name, "size", lv.LvSize,
+						"creationTime", orphan.CreationTime, "orphanedSince", orphan.OrphanedSince)
+					continue
+				}
+			}
+			orphan.DeletionTime = &metav1.Time{Time: deletionTime}
+		}
+		orphaned = append(orphaned, orphan)
+	}
+
+	if len(orphaned) != len(status.OrphanedLogicalVolumes) || len(missing) != len(status.MissingLogicalVolumes) {
+		logger.Info("audited logical volumes", "orphaned", len(orphaned), "missing", len(missing))
+	}
+	status.OrphanedLogicalVolumes = orphaned
+	status.MissingLogicalVolumes = missing
+	return errors.Join(errs...)
+}
+
+// isTopoLVMVolume returns true if the logical volume was provisioned by TopoLVM, which names the logical volumes
+// with a UUID. This excludes the thin pool and any logical volumes that were created outside LVMS.
+func isTopoLVMVolume(lv lvm.LogicalVolume) bool {
+	if strings.HasPrefix(lv.LvAttr, "t") {
+		return false
+	}
+	_, err := uuid.Parse(lv.Name)
+	return err == nil
+}
@@ -0,0 +1,179 @@
+package vgmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestLogicalVolumeAudit(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	const (
+		namespace  = "openshift-lvm-storage"
+		referenced = "0b5e1fd5-4c2a-4a53-9d5e-3b0a4b3f0c11"
+		missing    = "1f0d8c9e-2e8b-4d5c-8c61-1f3c0b2b9a22"
+		expired    = "2c7a3e51-6f4d-4b8e-9a0c-5d2e1f3b4c33"
+		retained   = "3d8b4f62-7a5e-4c9f-8b1d-6e3f2a4c5d44"
+		young      = "4e9c5a73-8b6f-4dae-9c2e-7f4a3b5d6e55"
+	)
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	lvTime := func(age time.Duration) string {
+		return now.Add(-age).Format(lvTimeLayout)
+	}
+	logicalVolume := func(name, node, volumeID string) *topolvmv1.LogicalVolume {
+		return &topolvmv1.LogicalVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       topolvmv1.LogicalVolumeSpec{Name: name, NodeName: node, DeviceClass: "vg1"},
+			Status:     topolvmv1.LogicalVolumeStatus{VolumeID: volumeID},
+		}
+	}
+
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: namespace},
+		Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+			VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{{
+				Name: "vg1",
+				// the expired volume was found to be orphaned by an earlier audit
+				OrphanedLogicalVolumes: []lvmv1alpha1.OrphanedLogicalVolume{
+					{Name: expired, OrphanedSince: &metav1.Time{Time: now.Add(-2 * time.Hour)}},
+				},
+			}},
+		},
+	}
+	vg := &lvmv1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: namespace},
+		Spec: lvmv1alpha1.LVMVolumeGroupSpec{
+			ThinPoolConfig: &lvmv1alpha1.ThinPoolConfig{Name: "thin-pool-1"},
+			OrphanedLogicalVolumePolicy: &lvmv1alpha1.OrphanedLogicalVolumePolicy{
+				Action:      lvmv1alpha1.OrphanedLogicalVolumeActionDelete,
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			nodeStatus, vg,
+			logicalVolume("pvc-1", "node1", referenced),
+			logicalVolume("pvc-2", "node1", missing),
+			logicalVolume("pvc-3", "node2", expired),
+			logicalVolume("pvc-4", "node1", ""),
+		).
+		WithStatusSubresource(nodeStatus).Build()
+
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-1", VgName: "vg1", LvAttr: "twi-aotz--", LvSize: "10737418240", Time: lvTime(48 * time.Hour)},
+		{Name: "custom", VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824", Time: lvTime(48 * time.Hour)},
+		{Name: referenced, VgName: "vg1", LvAttr: "Vwi-aotz--", LvSize: "1073741824", Time: lvTime(48 * time.Hour)},
+		{Name: expired, VgName: "vg1", LvAttr: "Vwi-a-tz--", LvSize: "1073741824", Time: lvTime(2 * time.Hour)},
+		{Name: retained, VgName: "vg1", LvAttr: "Vwi-a-tz--", LvSize: "2147483648", Time: lvTime(30 * time.Minute)},
+		{Name: young, VgName: "vg1", LvAttr: "Vwi-a-tz--", LvSize: "1073741824", Time: lvTime(time.Minute)},
+	}}}}, nil)
+	mockLVM.EXPECT().DeleteLV(mock.Anything, expired, "vg1").Return(nil).Once()
+
+	audit := &LogicalVolumeAudit{
+		Client:    fakeClient,
+		LVM:       mockLVM,
+		NodeName:  "node1",
+		Namespace: namespace,
+	}
+	require.NoError(t, audit.audit(ctx, now))
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+	require.Len(t, nodeStatus.Status.VolumeGroups, 1)
+	status := nodeStatus.Status.VolumeGroups[0]
+
+	require.Len(t, status.OrphanedLogicalVolumes, 1, "only TopoLVM volumes past the minimum age that were not deleted must be reported")
+	orphan := status.OrphanedLogicalVolumes[0]
+	assert.Equal(t, retained, orphan.Name)
+	assert.Equal(t, int64(2147483648), orphan.Size.Value())
+	assert.True(t, now.Add(-30*time.Minute).Equal(orphan.CreationTime.Time))
+	assert.True(t, now.Equal(orphan.OrphanedSince.Time))
+	assert.True(t, now.Add(time.Hour).Equal(orphan.DeletionTime.Time), "the orphan must be deleted after the grace period")
+
+	assert.Equal(t, []lvmv1alpha1.MissingLogicalVolume{{Name: "pvc-2", VolumeID: missing}}, status.MissingLogicalVolumes)
+}
+
+func TestLogicalVolumeAudit_GracePeriodStartsWhenOrphaned(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	const (
+		namespace = "openshift-lvm-storage"
+		old       = "5fad6b84-9c7a-4ebf-8d3f-8a5b4c6e7f66"
+	)
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	nodeStatus := &lvmv1alpha1.LVMVolumeGroupNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: namespace},
+		Status: lvmv1alpha1.LVMVolumeGroupNodeStatusStatus{
+			VolumeGroups: []lvmv1alpha1.VolumeGroupConditions{{Name: "vg1"}},
+		},
+	}
+	vg := &lvmv1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: namespace},
+		Spec: lvmv1alpha1.LVMVolumeGroupSpec{
+			OrphanedLogicalVolumePolicy: &lvmv1alpha1.OrphanedLogicalVolumePolicy{
+				Action:      lvmv1alpha1.OrphanedLogicalVolumeActionDelete,
+				GracePeriod: &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(nodeStatus, vg).
+		WithStatusSubresource(nodeStatus).Build()
+
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil)
+	// the logical volume was created long before its LogicalVolume disappeared
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: old, VgName: "vg1", LvAttr: "Vwi-a-tz--", LvSize: "1073741824", Time: now.Add(-30 * 24 * time.Hour).Format(lvTimeLayout)},
+	}}}}, nil)
+
+	audit := &LogicalVolumeAudit{
+		Client:    fakeClient,
+		LVM:       mockLVM,
+		NodeName:  "node1",
+		Namespace: namespace,
+	}
+	orphans := func() []lvmv1alpha1.OrphanedLogicalVolume {
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(nodeStatus), nodeStatus))
+		return nodeStatus.Status.VolumeGroups[0].OrphanedLogicalVolumes
+	}
+
+	require.NoError(t, audit.audit(ctx, now))
+	require.Len(t, orphans(), 1, "an old logical volume must not be deleted when it is first found to be orphaned")
+	assert.True(t, now.Equal(orphans()[0].OrphanedSince.Time))
+	assert.True(t, now.Add(time.Hour).Equal(orphans()[0].DeletionTime.Time))
+
+	require.NoError(t, audit.audit(ctx, now.Add(30*time.Minute)))
+	require.Len(t, orphans(), 1)
+	assert.True(t, now.Equal(orphans()[0].OrphanedSince.Time), "the time it was first found to be orphaned must be kept")
+
+	mockLVM.EXPECT().DeleteLV(mock.Anything, old, "vg1").Return(nil).Once()
+	require.NoError(t, audit.audit(ctx, now.Add(time.Hour)))
+	assert.Empty(t, orphans(), "the logical volume must be deleted once the grace period expired")
+}
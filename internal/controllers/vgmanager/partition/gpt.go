@@ -0,0 +1,319 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partition
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/diskfs/go-diskfs/backend"
+	"github.com/diskfs/go-diskfs/partition/gpt"
+)
+
+const (
+	gptMaxNameLength = 36
+
+	// Alignment is the alignment of the start and the size of carved partitions.
+	Alignment = 1024 * 1024
+
+	// MinimumSize is the smallest free region that is considered for a new partition.
+	MinimumSize = 1024 * 1024 * 1024
+)
+
+var (
+	ErrNoGPT                 = errors.New("device does not carry a valid GUID partition table")
+	ErrNoFreeSpace           = errors.New("no unpartitioned free region large enough for a new partition")
+	ErrNoFreePartitionEntry  = errors.New("no unused entry left in the GUID partition table")
+	ErrInvalidBackupHeader   = errors.New("backup GUID partition table header is invalid or not located at the end of the device")
+	ErrPartitionNameTooLong  = fmt.Errorf("partition name must not exceed %d characters", gptMaxNameLength)
+	ErrInvalidPartitionEntry = errors.New("GUID partition table contains an invalid partition entry")
+	ErrUnsupportedLayout     = errors.New("GUID partition table can not be rewritten without modifying existing partition entries")
+)
+
+// Region is a range of logical blocks on a device. Both Start and End are inclusive.
+type Region struct {
+	Start uint64
+	End   uint64
+}
+
+// Sectors returns the amount of logical blocks in the region.
+func (r Region) Sectors() uint64 {
+	return r.End - r.Start + 1
+}
+
+// Entry is a used partition entry in the GUID partition table.
+type Entry struct {
+	// Number is the 1-based partition number as used by the kernel.
+	Number int
+	Type   gpt.Type
+	Region
+	Name string
+}
+
+// Table is a GUID partition table read from a device.
+// Parsing and serialization are done by go-diskfs, which does not expose the usable area of the device
+// nor the size of the partition entry array, so both are read from the validated primary header.
+type Table struct {
+	*gpt.Table
+	firstUsableLBA uint64
+	maxEntries     int
+	// existing is the amount of partitions on the device when the table was read.
+	existing int
+	// device is the content of the device the table was read from. Every write of go-diskfs is
+	// compared with it before it is applied, so that existing partition entries are never modified.
+	device backend.File
+}
+
+// ReadTable reads and validates the GUID partition table of a device with the given
+// logical sector size and total size in bytes.
+func ReadTable(device backend.File, sectorSize, diskSize uint64) (*Table, error) {
+	if sectorSize == 0 || diskSize/sectorSize < 3 {
+		return nil, fmt.Errorf("invalid device geometry (sector size %d, size %d)", sectorSize, diskSize)
+	}
+
+	table, err := gpt.Read(device, int(sectorSize), int(sectorSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoGPT, err)
+	}
+	if err := table.Verify(device, diskSize); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBackupHeader, err)
+	}
+
+	// The first usable LBA and the number of partition entries are at offset 40 and 80 of the header.
+	header := make([]byte, 84)
+	if _, err := device.ReadAt(header, int64(sectorSize)); err != nil {
+		return nil, fmt.Errorf("failed to read primary GUID partition table header: %w", err)
+	}
+	t := &Table{
+		Table:          table,
+		firstUsableLBA: binary.LittleEndian.Uint64(header[40:48]),
+		maxEntries:     int(binary.LittleEndian.Uint32(header[80:84])),
+		existing:       len(table.Partitions),
+		device:         device,
+	}
+	if t.firstUsableLBA > t.LastDataSector() {
+		return nil, fmt.Errorf("%w: invalid usable range %d-%d", ErrNoGPT, t.firstUsableLBA, t.LastDataSector())
+	}
+
+	for _, e := range t.Entries() {
+		if e.Start < t.firstUsableLBA || e.End > t.LastDataSector() || e.Start > e.End {
+			return nil, fmt.Errorf("%w: partition %d spans %d-%d", ErrInvalidPartitionEntry, e.Number, e.Start, e.End)
+		}
+	}
+
+	// go-diskfs compacts the partition entries and always places the entry array right after the header.
+	// Rewriting tables with unused entries between used ones or other layouts would renumber or move
+	// existing partitions, so such tables are refused.
+	if _, err := t.stage(0); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// SectorSize returns the logical sector size the table was read with.
+func (t *Table) SectorSize() uint64 {
+	return uint64(t.LogicalSectorSize)
+}
+
+// Entries returns all used partition entries ordered by their partition number.
+func (t *Table) Entries() []Entry {
+	entries := make([]Entry, 0, len(t.Partitions))
+	for i, p := range t.Partitions {
+		entries = append(entries, Entry{
+			Number: i + 1,
+			Type:   p.Type,
+			Region: Region{Start: p.Start, End: p.End},
+			Name:   p.Name,
+		})
+	}
+	return entries
+}
+
+// FindByName returns the used partition entry with the given name, if any.
+func (t *Table) FindByName(name string) (Entry, bool) {
+	for _, e := range t.Entries() {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// FreeRegions returns all unpartitioned regions of the usable area of the device
+// after aligning them to Alignment. Regions smaller than the alignment are omitted.
+func (t *Table) FreeRegions() []Region {
+	used := t.Entries()
+	slices.SortFunc(used, func(a, b Entry) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	var free []Region
+	add := func(r Region) {
+		if aligned, ok := t.align(r); ok {
+			free = append(free, aligned)
+		}
+	}
+
+	cursor := t.firstUsableLBA
+	for _, e := range used {
+		if e.Start > cursor {
+			add(Region{Start: cursor, End: e.Start - 1})
+		}
+		if e.End+1 > cursor {
+			cursor = e.End + 1
+		}
+	}
+	if cursor <= t.LastDataSector() {
+		add(Region{Start: cursor, End: t.LastDataSector()})
+	}
+
+	return free
+}
+
+// LargestFreeRegion returns the largest aligned free region that is at least MinimumSize in size.
+func (t *Table) LargestFreeRegion() (Region, error) {
+	var largest Region
+	found := false
+	for _, r := range t.FreeRegions() {
+		if !found || r.Sectors() > largest.Sectors() {
+			largest = r
+			found = true
+		}
+	}
+	if !found || largest.Sectors()*t.SectorSize() < MinimumSize {
+		return Region{}, ErrNoFreeSpace
+	}
+	return largest, nil
+}
+
+// AddPartition records a new Linux LVM partition spanning the given region in the first unused entry
+// and returns the new entry. The region must not overlap any existing partition.
+// The change is only kept in memory until Write is called.
+func (t *Table) AddPartition(region Region, name string) (Entry, error) {
+	if len([]rune(name)) > gptMaxNameLength {
+		return Entry{}, ErrPartitionNameTooLong
+	}
+	if region.Start < t.firstUsableLBA || region.End > t.LastDataSector() || region.Start > region.End {
+		return Entry{}, fmt.Errorf("region %d-%d is outside of the usable area %d-%d",
+			region.Start, region.End, t.firstUsableLBA, t.LastDataSector())
+	}
+	for _, e := range t.Entries() {
+		if region.Start <= e.End && e.Start <= region.End {
+			return Entry{}, fmt.Errorf("region %d-%d overlaps with partition %d", region.Start, region.End, e.Number)
+		}
+	}
+	if len(t.Partitions) >= t.maxEntries {
+		return Entry{}, ErrNoFreePartitionEntry
+	}
+
+	t.Partitions = append(t.Partitions, &gpt.Partition{
+		Start: region.Start,
+		End:   region.End,
+		Type:  gpt.LinuxLVM,
+		Name:  name,
+	})
+	return Entry{Number: len(t.Partitions), Type: gpt.LinuxLVM, Region: region, Name: name}, nil
+}
+
+// Write persists the partitions added since the table was read to w. The backup table is written
+// before the primary one so that an interrupted write leaves at least one consistent table.
+func (t *Table) Write(w backend.WritableFile) error {
+	writes, err := t.stage(len(t.Partitions) - t.existing)
+	if err != nil {
+		return err
+	}
+	for i := len(writes) - 1; i >= 0; i-- {
+		if _, err := w.WriteAt(writes[i].data, writes[i].offset); err != nil {
+			return fmt.Errorf("failed to write GUID partition table at offset %d: %w", writes[i].offset, err)
+		}
+	}
+	t.existing = len(t.Partitions)
+	return nil
+}
+
+type stagedWrite struct {
+	offset int64
+	data   []byte
+}
+
+// stagingFile collects the writes of go-diskfs instead of applying them to the device.
+type stagingFile struct {
+	backend.File
+	writes []stagedWrite
+}
+
+func (f *stagingFile) WriteAt(p []byte, off int64) (int, error) {
+	f.writes = append(f.writes, stagedWrite{offset: off, data: bytes.Clone(p)})
+	return len(p), nil
+}
+
+// stage serializes the table with go-diskfs and returns the resulting writes in the order they were issued.
+// The partition entry arrays may only differ from the device in exactly added previously unused entries,
+// headers and the protective MBR are expected to change with them.
+func (t *Table) stage(added int) ([]stagedWrite, error) {
+	staging := &stagingFile{File: t.device}
+	if err := t.Table.Write(staging, int64(t.TotalSize())); err != nil {
+		return nil, fmt.Errorf("failed to serialize GUID partition table: %w", err)
+	}
+
+	for _, write := range staging.writes {
+		if len(write.data) <= t.LogicalSectorSize {
+			continue
+		}
+		current := make([]byte, len(write.data))
+		if _, err := t.device.ReadAt(current, write.offset); err != nil {
+			return nil, fmt.Errorf("failed to read partition entries at offset %d: %w", write.offset, err)
+		}
+		changed := 0
+		for i := 0; i+gpt.PartitionEntrySize <= len(current); i += gpt.PartitionEntrySize {
+			before, after := current[i:i+gpt.PartitionEntrySize], write.data[i:i+gpt.PartitionEntrySize]
+			if bytes.Equal(before, after) {
+				continue
+			}
+			if !bytes.Equal(before, make([]byte, gpt.PartitionEntrySize)) {
+				return nil, fmt.Errorf("%w: entry %d would change", ErrUnsupportedLayout, i/gpt.PartitionEntrySize+1)
+			}
+			changed++
+		}
+		if changed != added {
+			return nil, fmt.Errorf("%w: %d entries would change instead of %d", ErrUnsupportedLayout, changed, added)
+		}
+	}
+	return staging.writes, nil
+}
+
+// align shrinks the region so that its start and size are multiples of Alignment.
+func (t *Table) align(r Region) (Region, bool) {
+	alignment := uint64(Alignment) / t.SectorSize()
+	if alignment == 0 {
+		alignment = 1
+	}
+	start := (r.Start + alignment - 1) / alignment * alignment
+	if start > r.End {
+		return Region{}, false
+	}
+	sectors := (r.End - start + 1) / alignment * alignment
+	if sectors == 0 {
+		return Region{}, false
+	}
+	return Region{Start: start, End: start + sectors - 1}, true
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package partition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode"
+	"unsafe"
+
+	"github.com/diskfs/go-diskfs/backend"
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Partitioner creates partitions in the unpartitioned free space of devices carrying a GUID partition table.
+type Partitioner interface {
+	// CarvePartition creates a Linux LVM partition with the given name spanning the largest free region of the device
+	// and returns the path of the partition. If a partition with the name already exists on the device,
+	// its path is returned instead and the device is left untouched.
+	CarvePartition(ctx context.Context, device string, name string) (string, error)
+}
+
+type HostPartitioner struct{}
+
+func NewDefaultHostPartitioner() *HostPartitioner {
+	return &HostPartitioner{}
+}
+
+// CarvePartition creates a new partition on the device and informs the kernel about it.
+// Existing partitions are never modified.
+func (p *HostPartitioner) CarvePartition(ctx context.Context, device string, name string) (string, error) {
+	logger := log.FromContext(ctx).WithValues("device", device, "partitionName", name)
+
+	file, err := os.OpenFile(device, os.O_RDWR|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to open device %s: %w", device, err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Error(err, "failed to close device")
+		}
+	}()
+
+	fd := int(file.Fd())
+	sectorSize, err := unix.IoctlGetInt(fd, unix.BLKSSZGET)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine logical sector size of %s: %w", device, err)
+	}
+	diskSize, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine size of %s: %w", device, err)
+	}
+
+	entry, created, err := carve(file, uint64(sectorSize), uint64(diskSize), name)
+	if err != nil {
+		return "", fmt.Errorf("failed to carve partition on %s: %w", device, err)
+	}
+	partitionPath := PartitionPath(device, entry.Number)
+	if !created {
+		logger.V(1).Info("partition already exists", "partition", partitionPath)
+		return partitionPath, nil
+	}
+
+	if err := file.Sync(); err != nil {
+		return "", fmt.Errorf("failed to sync partition table of %s: %w", device, err)
+	}
+	if err := addKernelPartition(fd, entry, uint64(sectorSize)); err != nil {
+		return "", fmt.Errorf("partition %d was written to %s but the kernel could not be informed about it: %w",
+			entry.Number, device, err)
+	}
+
+	logger.Info("created partition", "partition", partitionPath,
+		"startSector", entry.Start, "endSector", entry.End, "sectorSize", sectorSize)
+	return partitionPath, nil
+}
+
+// carve adds a partition with the given name in the largest free region of the table stored in rw.
+// It reports whether a new partition was created or an existing one with the same name was found.
+func carve(rw backend.WritableFile, sectorSize, diskSize uint64, name string) (Entry, bool, error) {
+	table, err := ReadTable(rw, sectorSize, diskSize)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	if existing, ok := table.FindByName(name); ok {
+		return existing, false, nil
+	}
+
+	region, err := table.LargestFreeRegion()
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	entry, err := table.AddPartition(region, name)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	if err := table.Write(rw); err != nil {
+		return Entry{}, false, err
+	}
+
+	return entry, true, nil
+}
+
+// addKernelPartition registers the partition with the kernel without re-reading the whole partition table,
+// which would fail while other partitions of the device are in use.
+func addKernelPartition(fd int, entry Entry, sectorSize uint64) error {
+	part := unix.BlkpgPartition{
+		Start:  int64(entry.Start * sectorSize),
+		Length: int64(entry.Sectors() * sectorSize),
+		Pno:    int32(entry.Number),
+	}
+	arg := unix.BlkpgIoctlArg{
+		Op:      unix.BLKPG_ADD_PARTITION,
+		Datalen: int32(unsafe.Sizeof(part)),
+		Data:    (*byte)(unsafe.Pointer(&part)),
+	}
+
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.BLKPG, uintptr(unsafe.Pointer(&arg)))
+	if errno != 0 && !errors.Is(errno, unix.EBUSY) {
+		return errno
+	}
+	return nil
+}
+
+// PartitionPath returns the path of the partition with the given number on the device.
+// Device names ending in a digit (e.g. nvme0n1) use a "p" separator before the partition number.
+func PartitionPath(device string, number int) string {
+	base := filepath.Base(device)
+	if base != "" && unicode.IsDigit(rune(base[len(base)-1])) {
+		return fmt.Sprintf("%sp%d", device, number)
+	}
+	return fmt.Sprintf("%s%d", device, number)
+}
+
+// NameForVolumeGroup returns the partition name used to mark partitions carved for the volume group.
+// Names exceeding the maximum length of a GPT partition name are truncated, and the end of the truncated name
+// is replaced by a hash of the volume group name, so that volume groups sharing a long prefix do not share a name.
+func NameForVolumeGroup(vgName string) string {
+	name := []rune("lvms-" + vgName)
+	if len(name) <= gptMaxNameLength {
+		return string(name)
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(vgName))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+	return string(name[:gptMaxNameLength-len(suffix)]) + suffix
+}
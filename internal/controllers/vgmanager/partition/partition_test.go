@@ -0,0 +1,218 @@
+package partition
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/diskfs/go-diskfs/partition/gpt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSectorSize = 512
+	testDiskSize   = 4 * 1024 * 1024 * 1024
+)
+
+// newTestImage creates a sparse image file carrying an empty GUID partition table
+// with the common layout of 128 entries of 128 bytes.
+func newTestImage(t *testing.T, sectorSize, diskSize uint64, partitions ...*gpt.Partition) *os.File {
+	t.Helper()
+	file, err := os.Create(filepath.Join(t.TempDir(), "disk.img"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+	require.NoError(t, file.Truncate(int64(diskSize)))
+
+	table := &gpt.Table{
+		LogicalSectorSize:  int(sectorSize),
+		PhysicalSectorSize: int(sectorSize),
+		ProtectiveMBR:      true,
+		Partitions:         partitions,
+	}
+	require.NoError(t, table.Write(file, int64(diskSize)))
+	return file
+}
+
+// rewritePrimaryEntries changes the primary partition entry array and updates the checksums of the primary header.
+func rewritePrimaryEntries(t *testing.T, file *os.File, change func(entries []byte)) {
+	t.Helper()
+	header := make([]byte, 92)
+	_, err := file.ReadAt(header, testSectorSize)
+	require.NoError(t, err)
+	entries := make([]byte, 128*128)
+	_, err = file.ReadAt(entries, 2*testSectorSize)
+	require.NoError(t, err)
+
+	change(entries)
+	binary.LittleEndian.PutUint32(header[88:92], crc32.ChecksumIEEE(entries))
+	binary.LittleEndian.PutUint32(header[16:20], 0)
+	binary.LittleEndian.PutUint32(header[16:20], crc32.ChecksumIEEE(header))
+	writeAt(t, file, entries, 2*testSectorSize)
+	writeAt(t, file, header, testSectorSize)
+}
+
+func TestCarve(t *testing.T) {
+	file := newTestImage(t, testSectorSize, testDiskSize)
+
+	table, err := ReadTable(file, testSectorSize, testDiskSize)
+	require.NoError(t, err)
+	assert.Empty(t, table.Entries())
+
+	// an existing partition occupying the first GiB of the device must not be touched
+	existing, err := table.AddPartition(Region{Start: 2048, End: 2048 + 2*1024*1024 - 1}, "boot")
+	require.NoError(t, err)
+	require.NoError(t, table.Write(file))
+
+	entry, created, err := carve(file, testSectorSize, testDiskSize, "lvms-vg1")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 2, entry.Number)
+	assert.Equal(t, gpt.LinuxLVM, entry.Type)
+	assert.Equal(t, existing.End+1, entry.Start)
+	assert.Zero(t, entry.Start*testSectorSize%Alignment)
+	assert.Zero(t, entry.Sectors()*testSectorSize%Alignment)
+
+	table, err = ReadTable(file, testSectorSize, testDiskSize)
+	require.NoError(t, err)
+	entries := table.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, existing, entries[0])
+	assert.Equal(t, "lvms-vg1", entries[1].Name)
+	assert.Empty(t, table.FreeRegions())
+
+	// carving again must be idempotent
+	again, created, err := carve(file, testSectorSize, testDiskSize, "lvms-vg1")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, entry.Number, again.Number)
+
+	// no space is left for a differently named partition
+	_, _, err = carve(file, testSectorSize, testDiskSize, "lvms-vg2")
+	assert.ErrorIs(t, err, ErrNoFreeSpace)
+}
+
+func TestBackupTableIsValid(t *testing.T) {
+	file := newTestImage(t, testSectorSize, testDiskSize)
+	_, _, err := carve(file, testSectorSize, testDiskSize, "lvms-vg1")
+	require.NoError(t, err)
+
+	table, err := ReadTable(file, testSectorSize, testDiskSize)
+	require.NoError(t, err)
+	require.NoError(t, table.Verify(file, testDiskSize))
+
+	primaryEntries := make([]byte, 128*128)
+	_, err = file.ReadAt(primaryEntries, int64(2*testSectorSize))
+	require.NoError(t, err)
+	backupEntries := make([]byte, len(primaryEntries))
+	_, err = file.ReadAt(backupEntries, int64((testDiskSize/testSectorSize-33)*testSectorSize))
+	require.NoError(t, err)
+	assert.Equal(t, primaryEntries, backupEntries)
+}
+
+func TestReadTableRejectsInvalidTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, file *os.File)
+		size    uint64
+		err     error
+	}{
+		{
+			name:    "no partition table",
+			corrupt: func(t *testing.T, file *os.File) { writeAt(t, file, make([]byte, testSectorSize), testSectorSize) },
+			size:    testDiskSize,
+			err:     ErrNoGPT,
+		},
+		{
+			name:    "corrupted header",
+			corrupt: func(t *testing.T, file *os.File) { writeAt(t, file, []byte{0xff}, testSectorSize+40) },
+			size:    testDiskSize,
+			err:     ErrNoGPT,
+		},
+		{
+			name:    "corrupted entries",
+			corrupt: func(t *testing.T, file *os.File) { writeAt(t, file, []byte{0xff}, 2*testSectorSize) },
+			size:    testDiskSize,
+			err:     ErrNoGPT,
+		},
+		{
+			name:    "device grew after partitioning",
+			corrupt: func(t *testing.T, file *os.File) {},
+			size:    2 * testDiskSize,
+			err:     ErrInvalidBackupHeader,
+		},
+		{
+			name: "unused entry before a used one",
+			corrupt: func(t *testing.T, file *os.File) {
+				rewritePrimaryEntries(t, file, func(entries []byte) {
+					copy(entries[128:256], entries[0:128])
+					clear(entries[0:128])
+				})
+			},
+			size: testDiskSize,
+			err:  ErrUnsupportedLayout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := newTestImage(t, testSectorSize, testDiskSize, &gpt.Partition{
+				Start: 2048, End: 4095, Type: gpt.LinuxFilesystem, Name: "boot",
+			})
+			tt.corrupt(t, file)
+			require.NoError(t, file.Truncate(int64(tt.size)))
+			_, err := ReadTable(file, testSectorSize, tt.size)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLargestFreeRegion(t *testing.T) {
+	file := newTestImage(t, testSectorSize, testDiskSize)
+	table, err := ReadTable(file, testSectorSize, testDiskSize)
+	require.NoError(t, err)
+
+	gib := uint64(1024 * 1024 * 1024 / testSectorSize)
+	_, err = table.AddPartition(Region{Start: 2048, End: 2048 + gib/2 - 1}, "a")
+	require.NoError(t, err)
+	_, err = table.AddPartition(Region{Start: 2048 + gib, End: 2048 + gib + gib/2 - 1}, "b")
+	require.NoError(t, err)
+
+	free := table.FreeRegions()
+	require.Len(t, free, 2)
+	largest, err := table.LargestFreeRegion()
+	require.NoError(t, err)
+	assert.Equal(t, free[1], largest)
+	assert.Greater(t, largest.Sectors(), free[0].Sectors())
+
+	_, err = table.AddPartition(Region{Start: 2048 + gib/4, End: 2048 + gib}, "overlap")
+	assert.Error(t, err)
+
+	_, err = table.AddPartition(free[0], "a-name-that-is-much-too-long-for-a-gpt-entry")
+	assert.ErrorIs(t, err, ErrPartitionNameTooLong)
+}
+
+func TestPartitionPath(t *testing.T) {
+	assert.Equal(t, "/dev/sda3", PartitionPath("/dev/sda", 3))
+	assert.Equal(t, "/dev/nvme0n1p2", PartitionPath("/dev/nvme0n1", 2))
+	assert.Equal(t, "/dev/mmcblk0p1", PartitionPath("/dev/mmcblk0", 1))
+}
+
+func writeAt(t *testing.T, file *os.File, data []byte, offset int64) {
+	t.Helper()
+	_, err := file.WriteAt(data, offset)
+	require.NoError(t, err)
+}
+
+func TestNameForVolumeGroup(t *testing.T) {
+	assert.Equal(t, "lvms-vg1", NameForVolumeGroup("vg1"))
+	name := NameForVolumeGroup("a-very-long-device-class-name-that-exceeds-the-limit")
+	assert.Len(t, []rune(name), gptMaxNameLength)
+	assert.Equal(t, name, NameForVolumeGroup("a-very-long-device-class-name-that-exceeds-the-limit"))
+
+	// volume groups sharing a prefix longer than the name must not share a partition name
+	other := NameForVolumeGroup("a-very-long-device-class-name-that-exceeds-the-limit-too")
+	assert.Len(t, []rune(other), gptMaxNameLength)
+	assert.NotEqual(t, name, other)
+}
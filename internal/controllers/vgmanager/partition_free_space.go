@@ -0,0 +1,210 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	symlinkResolver "github.com/openshift/lvm-operator/v4/internal/controllers/symlink-resolver"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/partition"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// rootFilesystemMountPoints are the host mount points that identify a device as holding the operating system.
+// Devices with any of these mounted on them or their children are never partitioned.
+var rootFilesystemMountPoints = []string{"/", "/sysroot", "/boot"}
+
+// shouldPartitionFreeSpace checks if the volume group uses the free space of its selected devices
+// based on the PartitionFreeSpace field in the DeviceSelector.
+func shouldPartitionFreeSpace(vg *lvmv1alpha1.LVMVolumeGroup) bool {
+	return vg.Spec.DeviceSelector != nil && ptr.Deref(vg.Spec.DeviceSelector.PartitionFreeSpace, false)
+}
+
+// carvePartitions creates a partition in the largest free region of every selected device that carries a GPT
+// partition table and has no partition for the volume group yet. Devices without any partition table are left
+// as they are and used as whole devices. It returns true if at least one partition was created.
+// Failures on mandatory paths are returned, while failures on optional paths are only logged.
+func (r *Reconciler) carvePartitions(
+	ctx context.Context,
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	blockDevices []lsblk.BlockDevice,
+	resolver *symlinkResolver.Resolver,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if !shouldPartitionFreeSpace(volumeGroup) {
+		return false, nil
+	}
+
+	created := false
+	for _, path := range volumeGroup.Spec.DeviceSelector.Paths {
+		pathResolved, err := resolver.Resolve(path.Unresolved())
+		if err != nil {
+			return false, fmt.Errorf("failed to partition device %s: %w", path, err)
+		}
+		if carved, err := r.carvePartition(ctx, volumeGroup, pathResolved, blockDevices); err != nil {
+			return false, fmt.Errorf("failed to partition device %s: %w", path, err)
+		} else if carved {
+			created = true
+		}
+	}
+	for _, path := range volumeGroup.Spec.DeviceSelector.OptionalPaths {
+		pathResolved, err := resolver.Resolve(path.Unresolved())
+		if err != nil {
+			logger.Info(fmt.Sprintf("skipping partitioning optional device %s: %v", path, err))
+			continue
+		}
+		if carved, err := r.carvePartition(ctx, volumeGroup, pathResolved, blockDevices); err != nil {
+			logger.Info(fmt.Sprintf("skipping partitioning optional device %s: %v", path, err))
+		} else if carved {
+			created = true
+		}
+	}
+
+	return created, nil
+}
+
+func (r *Reconciler) carvePartition(
+	ctx context.Context,
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	deviceName string,
+	blockDevices []lsblk.BlockDevice,
+) (bool, error) {
+	logger := log.FromContext(ctx).WithValues("deviceName", deviceName)
+
+	device, found := lsblk.FlattenedBlockDevices(blockDevices)[deviceName]
+	if !found {
+		// missing devices are reported by the mandatory device path verification
+		return false, nil
+	}
+
+	name := partition.NameForVolumeGroup(volumeGroup.Name)
+	if _, found := findCarvedPartition(device, name); found {
+		return false, nil
+	}
+	if device.PartTableType == "" {
+		logger.V(1).Info("device has no partition table and is used as a whole")
+		return false, nil
+	}
+	if err := verifyPartitionable(device); err != nil {
+		return false, err
+	}
+
+	partitionPath, err := r.CarvePartition(ctx, device.KName, name)
+	if err != nil {
+		return false, err
+	}
+	logger.Info("partitioned free space of device", "partition", partitionPath, "partitionName", name)
+	return true, nil
+}
+
+// verifyPartitionable checks that a new partition can safely be added to the device.
+func verifyPartitionable(device lsblk.BlockDevice) error {
+	if device.Type != lsblk.DeviceTypeDisk {
+		return fmt.Errorf("%s has a device type of %q, only disks can be partitioned", device.KName, device.Type)
+	}
+	if device.PartTableType != lsblk.PartTableTypeGPT {
+		return fmt.Errorf("%s has a partition table of type %q, only gpt partition tables are supported", device.KName, device.PartTableType)
+	}
+	if device.ReadOnly {
+		return fmt.Errorf("%s cannot be read-only", device.KName)
+	}
+	// the partition table would change behind the back of device-mapper, leaving the other paths and the map stale
+	if mpath, ok := device.MultipathDevice(); ok {
+		return fmt.Errorf("%s is a path of the active multipath device %s and is never partitioned, "+
+			"select the multipath device instead", device.KName, mpath.Name)
+	}
+	if mountPoint, found := findRootFilesystemMountPoint(device); found {
+		return fmt.Errorf("%s holds the host operating system (mounted at %s) and is never partitioned", device.KName, mountPoint)
+	}
+	return nil
+}
+
+func findRootFilesystemMountPoint(device lsblk.BlockDevice) (string, bool) {
+	if slices.Contains(rootFilesystemMountPoints, device.MountPoint) {
+		return device.MountPoint, true
+	}
+	for _, child := range device.Children {
+		if mountPoint, found := findRootFilesystemMountPoint(child); found {
+			return mountPoint, true
+		}
+	}
+	return "", false
+}
+
+// findCarvedPartition returns the partition of the device that was carved with the given name.
+func findCarvedPartition(device lsblk.BlockDevice, name string) (lsblk.BlockDevice, bool) {
+	for _, child := range device.Children {
+		if child.Type == lsblk.DeviceTypePart && child.PartLabel == name {
+			return child, true
+		}
+	}
+	return lsblk.BlockDevice{}, false
+}
+
+// carvedPartitionPaths maps the resolved paths of the selected devices to the partitions carved out of them
+// for the volume group. It is empty if the volume group does not partition free space.
+func carvedPartitionPaths(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	blockDevices []lsblk.BlockDevice,
+	resolver *symlinkResolver.Resolver,
+) map[string]string {
+	carved := make(map[string]string)
+	if !shouldPartitionFreeSpace(volumeGroup) {
+		return carved
+	}
+
+	flattened := lsblk.FlattenedBlockDevices(blockDevices)
+	name := partition.NameForVolumeGroup(volumeGroup.Name)
+	for _, path := range slices.Concat(volumeGroup.Spec.DeviceSelector.Paths, volumeGroup.Spec.DeviceSelector.OptionalPaths) {
+		pathResolved, err := resolver.Resolve(path.Unresolved())
+		if err != nil {
+			continue
+		}
+		device, found := flattened[pathResolved]
+		if !found {
+			continue
+		}
+		if carvedPartition, found := findCarvedPartition(device, name); found {
+			carved[pathResolved] = carvedPartition.KName
+		}
+	}
+	return carved
+}
+
+// withCarvedPartitions replaces every path with the partition carved out of it, if any.
+func withCarvedPartitions(paths []lvmv1alpha1.DevicePath, carved map[string]string, resolver *symlinkResolver.Resolver) []lvmv1alpha1.DevicePath {
+	if len(carved) == 0 {
+		return paths
+	}
+	result := make([]lvmv1alpha1.DevicePath, 0, len(paths))
+	for _, path := range paths {
+		if pathResolved, err := resolver.Resolve(path.Unresolved()); err == nil {
+			if partitionPath, ok := carved[pathResolved]; ok {
+				result = append(result, lvmv1alpha1.DevicePath(partitionPath))
+				continue
+			}
+		}
+		result = append(result, path)
+	}
+	return result
+}
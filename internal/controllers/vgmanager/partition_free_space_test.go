@@ -0,0 +1,155 @@
+package vgmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	symlinkResolver "github.com/openshift/lvm-operator/v4/internal/controllers/symlink-resolver"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	partitionmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/partition/mocks"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestCarvePartitions(t *testing.T) {
+	gptDisk := lsblk.BlockDevice{KName: "/dev/sdb", Type: lsblk.DeviceTypeDisk, PartTableType: lsblk.PartTableTypeGPT,
+		Children: []lsblk.BlockDevice{{KName: "/dev/sdb1", PKName: "/dev/sdb", Type: lsblk.DeviceTypePart, PartLabel: "data"}}}
+	carvedDisk := lsblk.BlockDevice{KName: "/dev/sdb", Type: lsblk.DeviceTypeDisk, PartTableType: lsblk.PartTableTypeGPT,
+		Children: []lsblk.BlockDevice{
+			{KName: "/dev/sdb1", PKName: "/dev/sdb", Type: lsblk.DeviceTypePart, PartLabel: "data"},
+			{KName: "/dev/sdb2", PKName: "/dev/sdb", Type: lsblk.DeviceTypePart, PartLabel: "lvms-vg1"},
+		}}
+	rootDisk := lsblk.BlockDevice{KName: "/dev/sda", Type: lsblk.DeviceTypeDisk, PartTableType: lsblk.PartTableTypeGPT,
+		Children: []lsblk.BlockDevice{{KName: "/dev/sda4", PKName: "/dev/sda", Type: lsblk.DeviceTypePart, MountPoint: "/sysroot"}}}
+	dosDisk := lsblk.BlockDevice{KName: "/dev/sdc", Type: lsblk.DeviceTypeDisk, PartTableType: "dos"}
+	blankDisk := lsblk.BlockDevice{KName: "/dev/sdd", Type: lsblk.DeviceTypeDisk}
+	multipathDisk := lsblk.BlockDevice{KName: "/dev/sde", Type: lsblk.DeviceTypeDisk, PartTableType: lsblk.PartTableTypeGPT,
+		Children: []lsblk.BlockDevice{{Name: "/dev/mapper/mpatha", KName: "/dev/dm-0", PKName: "/dev/sde", Type: lsblk.DeviceTypeMultipath}}}
+
+	tests := []struct {
+		name                string
+		disabled            bool
+		devicePaths         []v1alpha1.DevicePath
+		optionalDevicePaths []v1alpha1.DevicePath
+		blockDevices        []lsblk.BlockDevice
+		carveCount          int
+		created             bool
+		expectErr           bool
+	}{
+		{
+			name:         "Partitioning is not enabled",
+			disabled:     true,
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdb"},
+			blockDevices: []lsblk.BlockDevice{gptDisk},
+		},
+		{
+			name:         "Free space of a GPT disk is partitioned",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdb"},
+			blockDevices: []lsblk.BlockDevice{gptDisk},
+			carveCount:   1,
+			created:      true,
+		},
+		{
+			name:         "Already partitioned disk is not touched again",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdb"},
+			blockDevices: []lsblk.BlockDevice{carvedDisk},
+		},
+		{
+			name:         "Disk without partition table is used as a whole",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdd"},
+			blockDevices: []lsblk.BlockDevice{blankDisk},
+		},
+		{
+			name:         "Disk holding the root filesystem is refused",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sda"},
+			blockDevices: []lsblk.BlockDevice{rootDisk},
+			expectErr:    true,
+		},
+		{
+			name:         "Path of a multipath device is refused",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sde"},
+			blockDevices: []lsblk.BlockDevice{multipathDisk},
+			expectErr:    true,
+		},
+		{
+			name:         "Disk with a non-GPT partition table is refused",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdc"},
+			blockDevices: []lsblk.BlockDevice{dosDisk},
+			expectErr:    true,
+		},
+		{
+			name:                "Unusable optional disk is skipped",
+			devicePaths:         []v1alpha1.DevicePath{"/dev/sdb"},
+			optionalDevicePaths: []v1alpha1.DevicePath{"/dev/sda"},
+			blockDevices:        []lsblk.BlockDevice{rootDisk, gptDisk},
+			carveCount:          1,
+			created:             true,
+		},
+		{
+			name:         "Missing disk is left to the mandatory path verification",
+			devicePaths:  []v1alpha1.DevicePath{"/dev/sdx"},
+			blockDevices: []lsblk.BlockDevice{gptDisk},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := log.IntoContext(context.Background(), testr.New(t))
+			mockPartitioner := partitionmocks.NewMockPartitioner(t)
+			r := &Reconciler{
+				NodeName:         "test",
+				Partitioner:      mockPartitioner,
+				SymlinkResolveFn: func(path string) (string, error) { return path, nil },
+			}
+			if tt.carveCount > 0 {
+				mockPartitioner.EXPECT().CarvePartition(ctx, "/dev/sdb", "lvms-vg1").Return("/dev/sdb2", nil).Times(tt.carveCount)
+			}
+			volumeGroup := &v1alpha1.LVMVolumeGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "vg1"},
+				Spec: v1alpha1.LVMVolumeGroupSpec{DeviceSelector: &v1alpha1.DeviceSelector{
+					Paths:              tt.devicePaths,
+					OptionalPaths:      tt.optionalDevicePaths,
+					PartitionFreeSpace: ptr.To(!tt.disabled),
+				}},
+			}
+
+			created, err := r.carvePartitions(ctx, volumeGroup, tt.blockDevices, symlinkResolver.NewWithResolver(r.SymlinkResolveFn))
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.created, created)
+		})
+	}
+}
+
+func TestCarvedPartitionPaths(t *testing.T) {
+	resolver := symlinkResolver.NewWithResolver(func(path string) (string, error) { return path, nil })
+	volumeGroup := &v1alpha1.LVMVolumeGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vg1"},
+		Spec: v1alpha1.LVMVolumeGroupSpec{DeviceSelector: &v1alpha1.DeviceSelector{
+			Paths:              []v1alpha1.DevicePath{"/dev/sdb", "/dev/sdc"},
+			PartitionFreeSpace: ptr.To(true),
+		}},
+	}
+	blockDevices := []lsblk.BlockDevice{
+		{KName: "/dev/sdb", Type: lsblk.DeviceTypeDisk, Children: []lsblk.BlockDevice{
+			{KName: "/dev/sdb1", Type: lsblk.DeviceTypePart, PartLabel: "data"},
+			{KName: "/dev/sdb2", Type: lsblk.DeviceTypePart, PartLabel: "lvms-vg1"},
+		}},
+		{KName: "/dev/sdc", Type: lsblk.DeviceTypeDisk},
+	}
+
+	carved := carvedPartitionPaths(volumeGroup, blockDevices, resolver)
+	assert.Equal(t, map[string]string{"/dev/sdb": "/dev/sdb2"}, carved)
+	assert.Equal(t, []v1alpha1.DevicePath{"/dev/sdb2", "/dev/sdc"},
+		withCarvedPartitions(volumeGroup.Spec.DeviceSelector.Paths, carved, resolver))
+
+	volumeGroup.Spec.DeviceSelector.PartitionFreeSpace = nil
+	assert.Empty(t, carvedPartitionPaths(volumeGroup, blockDevices, resolver))
+}
@@ -0,0 +1,457 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RecoveryAction is the action the recovery takes for a logical volume on the node.
+type RecoveryAction string
+
+const (
+	// RecoveryActionNone is reported for logical volumes whose LogicalVolume and PersistentVolume exist.
+	RecoveryActionNone RecoveryAction = "None"
+	// RecoveryActionCreateLogicalVolume recreates the LogicalVolume and the PersistentVolume of a logical volume.
+	RecoveryActionCreateLogicalVolume RecoveryAction = "CreateLogicalVolume"
+	// RecoveryActionCreatePersistentVolume recreates the PersistentVolume of a logical volume with a LogicalVolume.
+	RecoveryActionCreatePersistentVolume RecoveryAction = "CreatePersistentVolume"
+	// RecoveryActionSkip is reported for logical volumes that cannot be recovered automatically.
+	RecoveryActionSkip RecoveryAction = "Skip"
+)
+
+const (
+	// recoveredPersistentVolumePrefix is the prefix of the names of the LogicalVolumes and PersistentVolumes
+	// recreated for logical volumes whose tags do not record the UID of their claim.
+	recoveredPersistentVolumePrefix = "lvms-recovered-"
+
+	// recoveryCheckInterval is the interval in which the adoption of a recovered logical volume by TopoLVM is checked.
+	recoveryCheckInterval = 2 * time.Second
+	// recoveryAdoptionTimeout is the time TopoLVM is given to adopt a recovered logical volume.
+	recoveryAdoptionTimeout = 2 * time.Minute
+)
+
+// RecoveryItem is a logical volume provisioned by TopoLVM on the node together with its LogicalVolume and
+// PersistentVolume in the cluster and the action that recovers the missing ones.
+type RecoveryItem struct {
+	VolumeGroup string
+	LVName      string
+	Size        *resource.Quantity
+	// FSType is the filesystem found on the logical volume, empty for block volumes and inactive logical volumes
+	FSType string
+
+	// LogicalVolume is the name of the TopoLVM LogicalVolume of the logical volume, existing or to be created
+	LogicalVolume string
+	// PersistentVolume is the name of the PersistentVolume of the logical volume, existing or to be created
+	PersistentVolume string
+	// StorageClass is the StorageClass of the device class the PersistentVolume is created for
+	StorageClass string
+	// ClaimNamespace and ClaimName are the claim recorded in the tags of the logical volume,
+	// to which a recreated PersistentVolume is pre-bound
+	ClaimNamespace string
+	ClaimName      string
+
+	Action RecoveryAction
+	Reason string
+}
+
+// Recovery rebuilds the TopoLVM LogicalVolumes and the PersistentVolumes of the logical volumes on the node
+// after they were lost in the cluster, for example because etcd was restored from a backup taken before the
+// logical volumes were provisioned, or because a single node cluster was reinstalled on top of its volume groups.
+//
+// Plan matches every logical volume named by TopoLVM in the volume groups of LVMS with the LogicalVolumes and
+// PersistentVolumes of the cluster without changing anything, and Apply executes the planned actions.
+// LogicalVolumes are recreated like imported logical volumes, see ImportReconciler. The PersistentVolumes are
+// created with the Retain reclaim policy and pre-bound to the claim recorded in the tags of the logical volume
+// by the LogicalVolumeTagReconciler. Logical volumes without these tags are recovered without a claim.
+// Logical volumes in volume groups without an LVMVolumeGroup are planned to be skipped.
+type Recovery struct {
+	client.Client
+	LVM   lvm.LVM
+	LSBLK lsblk.LSBLK
+
+	NodeName  string
+	Namespace string
+}
+
+// Plan returns the logical volumes of the node and the action that recovers their LogicalVolume and PersistentVolume.
+func (r *Recovery) Plan(ctx context.Context) ([]RecoveryItem, error) {
+	volumeGroups := &lvmv1alpha1.LVMVolumeGroupList{}
+	if err := r.List(ctx, volumeGroups, client.InNamespace(r.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list LVMVolumeGroups: %w", err)
+	}
+	logicalVolumes := &topolvmv1.LogicalVolumeList{}
+	if err := r.List(ctx, logicalVolumes); err != nil {
+		return nil, fmt.Errorf("failed to list TopoLVM LogicalVolumes: %w", err)
+	}
+	pvs := &corev1.PersistentVolumeList{}
+	if err := r.List(ctx, pvs); err != nil {
+		return nil, fmt.Errorf("failed to list PersistentVolumes: %w", err)
+	}
+	storageClasses := &storagev1.StorageClassList{}
+	if err := r.List(ctx, storageClasses); err != nil {
+		return nil, fmt.Errorf("failed to list StorageClasses: %w", err)
+	}
+	vgs, err := r.LVM.ListVGs(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume groups: %w", err)
+	}
+	blockDevices, err := r.LSBLK.ListBlockDevices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list block devices: %w", err)
+	}
+	devices := make(map[string]lsblk.BlockDevice)
+	for _, device := range lsblk.FlattenedBlockDevices(blockDevices) {
+		devices[device.Name] = device
+	}
+
+	var items []RecoveryItem
+	for i := range volumeGroups.Items {
+		volumeGroup := &volumeGroups.Items[i]
+		if !slices.ContainsFunc(vgs, func(vg lvm.VolumeGroup) bool { return vg.Name == volumeGroup.Name }) {
+			continue
+		}
+		report, err := r.LVM.ListLVs(ctx, volumeGroup.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", volumeGroup.Name, err)
+		}
+		for _, reportItem := range report.Report {
+			for _, lv := range reportItem.Lv {
+				if !isTopoLVMVolume(lv) {
+					continue
+				}
+				item := r.planLogicalVolume(volumeGroup, lv, logicalVolumes.Items, pvs.Items, storageClasses.Items)
+				item.FSType = devices[deviceMapperPath(volumeGroup.Name, lv.Name)].FSType
+				items = append(items, item)
+			}
+		}
+	}
+
+	// the logical volumes of volume groups without an LVMVolumeGroup cannot be recovered, as TopoLVM does not know
+	// their device class, but they are listed so that they are not overlooked
+	for _, vg := range vgs {
+		if slices.ContainsFunc(volumeGroups.Items, func(volumeGroup lvmv1alpha1.LVMVolumeGroup) bool { return volumeGroup.Name == vg.Name }) {
+			continue
+		}
+		report, err := r.LVM.ListLVs(ctx, vg.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list logical volumes in volume group %s: %w", vg.Name, err)
+		}
+		for _, reportItem := range report.Report {
+			for _, lv := range reportItem.Lv {
+				if !isTopoLVMVolume(lv) {
+					continue
+				}
+				item := RecoveryItem{
+					VolumeGroup: vg.Name,
+					LVName:      lv.Name,
+					Size:        parseLVMQuantity(lv.LvSize),
+					FSType:      devices[deviceMapperPath(vg.Name, lv.Name)].FSType,
+					Action:      RecoveryActionSkip,
+					Reason:      "there is no LVMVolumeGroup for the volume group, add its device class to an LVMCluster first",
+				}
+				item.ClaimNamespace, item.ClaimName, _ = claimFromTags(lv.TagList())
+				items = append(items, item)
+			}
+		}
+	}
+	return items, nil
+}
+
+func (r *Recovery) planLogicalVolume(
+	volumeGroup *lvmv1alpha1.LVMVolumeGroup,
+	lv lvm.LogicalVolume,
+	logicalVolumes []topolvmv1.LogicalVolume,
+	pvs []corev1.PersistentVolume,
+	storageClasses []storagev1.StorageClass,
+) RecoveryItem {
+	item := RecoveryItem{VolumeGroup: volumeGroup.Name, LVName: lv.Name, Size: parseLVMQuantity(lv.LvSize)}
+	var claimUID types.UID
+	item.ClaimNamespace, item.ClaimName, claimUID = claimFromTags(lv.TagList())
+
+	var pv *corev1.PersistentVolume
+	if i := slices.IndexFunc(pvs, func(pv corev1.PersistentVolume) bool {
+		return pv.Spec.CSI != nil && pv.Spec.CSI.Driver == constants.TopolvmCSIDriverName && pv.Spec.CSI.VolumeHandle == lv.Name
+	}); i >= 0 {
+		pv = &pvs[i]
+		item.PersistentVolume = pv.Name
+	}
+
+	// TopoLVM names the logical volume after the UID of the LogicalVolume before it records the volume ID,
+	// and a recovery that was interrupted before the rename left a LogicalVolume without a node.
+	var logicalVolume *topolvmv1.LogicalVolume
+	if i := slices.IndexFunc(logicalVolumes, func(logicalVolume topolvmv1.LogicalVolume) bool {
+		return logicalVolume.Status.VolumeID == lv.Name || string(logicalVolume.UID) == lv.Name ||
+			(logicalVolume.Spec.NodeName == "" && logicalVolume.Annotations[constants.RecoveredLogicalVolumeAnnotation] == lv.Name)
+	}); i >= 0 {
+		logicalVolume = &logicalVolumes[i]
+		item.LogicalVolume = logicalVolume.Name
+	}
+
+	switch {
+	case logicalVolume != nil && logicalVolume.Spec.NodeName != "" && logicalVolume.Spec.NodeName != r.NodeName:
+		item.Action = RecoveryActionSkip
+		item.Reason = fmt.Sprintf("the LogicalVolume belongs to node %s", logicalVolume.Spec.NodeName)
+		return item
+	case logicalVolume != nil && logicalVolume.Annotations[constants.ImportLogicalVolumeAnnotation] != "" && pv == nil:
+		item.Action = RecoveryActionNone
+		item.Reason = "the logical volume is being imported"
+		return item
+	case logicalVolume != nil && logicalVolume.Status.VolumeID == "" &&
+		logicalVolume.Annotations[constants.RecoveredLogicalVolumeAnnotation] == "":
+		item.Action = RecoveryActionNone
+		item.Reason = "the logical volume is being provisioned by TopoLVM"
+		return item
+	case logicalVolume != nil && logicalVolume.Status.VolumeID == "":
+		// resume the interrupted recovery of the logical volume
+		item.Action = RecoveryActionCreateLogicalVolume
+		item.PersistentVolume = logicalVolume.Name
+	case logicalVolume != nil && pv != nil:
+		item.Action = RecoveryActionNone
+		return item
+	case logicalVolume != nil:
+		// TopoLVM names the PersistentVolume and the LogicalVolume alike
+		item.Action = RecoveryActionCreatePersistentVolume
+		item.PersistentVolume = logicalVolume.Name
+	case pv != nil:
+		item.Action = RecoveryActionSkip
+		item.Reason = "the PersistentVolume has no LogicalVolume, recreating it would change the volume handle"
+		return item
+	default:
+		if err := checkProvisionable(volumeGroup, lv); err != nil {
+			item.Action = RecoveryActionSkip
+			item.Reason = err.Error()
+			return item
+		}
+		item.Action = RecoveryActionCreateLogicalVolume
+		item.LogicalVolume = recoveredPersistentVolumePrefix + lv.Name
+		// the volume is named like the PersistentVolume provisioned for the claim, unless the name is taken
+		if name := "pvc-" + string(claimUID); claimUID != "" &&
+			!slices.ContainsFunc(pvs, func(pv corev1.PersistentVolume) bool { return pv.Name == name }) &&
+			!slices.ContainsFunc(logicalVolumes, func(lv topolvmv1.LogicalVolume) bool { return lv.Name == name }) {
+			item.LogicalVolume = name
+		}
+		item.PersistentVolume = item.LogicalVolume
+	}
+
+	if item.Size == nil {
+		item.Action = RecoveryActionSkip
+		item.Reason = fmt.Sprintf("invalid size %q", lv.LvSize)
+		return item
+	}
+	i := slices.IndexFunc(storageClasses, func(storageClass storagev1.StorageClass) bool {
+		return storageClass.Provisioner == constants.TopolvmCSIDriverName &&
+			storageClass.Parameters[constants.DeviceClassKey] == volumeGroup.Name
+	})
+	if i < 0 {
+		item.Action = RecoveryActionSkip
+		item.Reason = fmt.Sprintf("there is no StorageClass for device class %s", volumeGroup.Name)
+		return item
+	}
+	item.StorageClass = storageClasses[i].Name
+	return item
+}
+
+// Apply executes the actions of the planned items. Items that fail are logged and do not stop the recovery
+// of the other items.
+func (r *Recovery) Apply(ctx context.Context, items []RecoveryItem) error {
+	var errs []error
+	for _, item := range items {
+		logger := log.FromContext(ctx).WithValues("VGName", item.VolumeGroup, "LVName", item.LVName)
+		ctx := log.IntoContext(ctx, logger)
+
+		var err error
+		switch item.Action {
+		case RecoveryActionCreateLogicalVolume:
+			err = r.recoverLogicalVolume(ctx, item)
+		case RecoveryActionCreatePersistentVolume:
+			err = r.recoverPersistentVolume(ctx, item)
+		default:
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "failed to recover the logical volume")
+			errs = append(errs, fmt.Errorf("failed to recover logical volume %s in volume group %s: %w",
+				item.LVName, item.VolumeGroup, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recoverLogicalVolume recreates the LogicalVolume of the logical volume, waits until TopoLVM adopted the
+// logical volume and then creates its PersistentVolume.
+func (r *Recovery) recoverLogicalVolume(ctx context.Context, item RecoveryItem) error {
+	logger := log.FromContext(ctx)
+
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	err := r.Get(ctx, client.ObjectKey{Name: item.LogicalVolume}, logicalVolume)
+	if k8serrors.IsNotFound(err) {
+		logicalVolume = &topolvmv1.LogicalVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        item.LogicalVolume,
+				Annotations: map[string]string{constants.RecoveredLogicalVolumeAnnotation: item.LVName},
+			},
+			Spec: topolvmv1.LogicalVolumeSpec{
+				Name:        item.LogicalVolume,
+				DeviceClass: item.VolumeGroup,
+				Size:        *item.Size,
+			},
+		}
+		if err := r.Create(ctx, logicalVolume); err != nil {
+			return fmt.Errorf("failed to create LogicalVolume %s: %w", item.LogicalVolume, err)
+		}
+		logger.Info("created LogicalVolume for the recovered logical volume", "LogicalVolume", logicalVolume.Name)
+	} else if err != nil {
+		return fmt.Errorf("failed to get LogicalVolume %s: %w", item.LogicalVolume, err)
+	}
+
+	if logicalVolume.Spec.NodeName == "" {
+		if err := adoptLogicalVolume(ctx, r.Client, r.LVM, r.NodeName, item.VolumeGroup, item.LVName, logicalVolume); err != nil {
+			return err
+		}
+	}
+
+	if err := wait.PollUntilContextTimeout(ctx, recoveryCheckInterval, recoveryAdoptionTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(logicalVolume), logicalVolume); err != nil {
+				return false, err
+			}
+			return logicalVolume.Status.VolumeID != "", nil
+		}); err != nil {
+		return fmt.Errorf("TopoLVM did not adopt the logical volume of LogicalVolume %s: %w", logicalVolume.Name, err)
+	}
+
+	return r.createPersistentVolume(ctx, item, logicalVolume)
+}
+
+// recoverPersistentVolume recreates the PersistentVolume of the existing LogicalVolume of the logical volume.
+func (r *Recovery) recoverPersistentVolume(ctx context.Context, item RecoveryItem) error {
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	if err := r.Get(ctx, client.ObjectKey{Name: item.LogicalVolume}, logicalVolume); err != nil {
+		return fmt.Errorf("failed to get LogicalVolume %s: %w", item.LogicalVolume, err)
+	}
+	return r.createPersistentVolume(ctx, item, logicalVolume)
+}
+
+// createPersistentVolume creates the PersistentVolume of the recovered logical volume with the Retain reclaim policy,
+// pre-bound to the claim of the logical volume if it is known. Logical volumes without a filesystem are recovered
+// as block volumes, so that they are never formatted when they are mounted.
+func (r *Recovery) createPersistentVolume(ctx context.Context, item RecoveryItem, logicalVolume *topolvmv1.LogicalVolume) error {
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: item.StorageClass}, storageClass); err != nil {
+		return fmt.Errorf("failed to get StorageClass %s: %w", item.StorageClass, err)
+	}
+
+	volumeMode := corev1.PersistentVolumeFilesystem
+	if item.FSType == "" {
+		volumeMode = corev1.PersistentVolumeBlock
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: item.PersistentVolume,
+			Annotations: map[string]string{
+				provisionedByAnnotation:                    constants.TopolvmCSIDriverName,
+				constants.RecoveredLogicalVolumeAnnotation: item.LVName,
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: logicalVolume.Spec.Size},
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			VolumeMode:                    &volumeMode,
+			StorageClassName:              storageClass.Name,
+			MountOptions:                  storageClass.MountOptions,
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       constants.TopolvmCSIDriverName,
+					VolumeHandle: logicalVolume.Status.VolumeID,
+					FSType:       item.FSType,
+				},
+			},
+			NodeAffinity: nodeAffinity(r.NodeName),
+		},
+	}
+	// the claim is referenced without its UID, so that a recreated claim of the same name binds the volume
+	if item.ClaimName != "" {
+		pv.Spec.ClaimRef = &corev1.ObjectReference{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+			Namespace:  item.ClaimNamespace,
+			Name:       item.ClaimName,
+		}
+	}
+	if err := r.Create(ctx, pv); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("failed to create PersistentVolume %s: %w", pv.Name, err)
+	}
+	log.FromContext(ctx).Info("created PersistentVolume for the recovered logical volume", "PersistentVolume", pv.Name)
+	return nil
+}
+
+// WriteRecoveryReport writes the planned recovery as a table.
+func WriteRecoveryReport(w io.Writer, items []RecoveryItem) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VOLUME GROUP\tLOGICAL VOLUME\tSIZE\tFSTYPE\tCLAIM\tLOGICALVOLUME\tPERSISTENTVOLUME\tACTION\tREASON")
+	for _, item := range items {
+		size := "<unknown>"
+		if item.Size != nil {
+			size = resource.NewQuantity(item.Size.Value(), resource.BinarySI).String()
+		}
+		claim := ""
+		if item.ClaimName != "" {
+			claim = item.ClaimNamespace + "/" + item.ClaimName
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.VolumeGroup, item.LVName, size,
+			orNone(item.FSType), orNone(claim), orNone(item.LogicalVolume), orNone(item.PersistentVolume),
+			item.Action, item.Reason)
+	}
+	return tw.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// deviceMapperPath returns the path of the device mapper device of the logical volume,
+// in which the dashes of the volume group and logical volume names are escaped by doubling them.
+func deviceMapperPath(vgName, lvName string) string {
+	return "/dev/mapper/" + strings.ReplaceAll(vgName, "-", "--") + "-" + strings.ReplaceAll(lvName, "-", "--")
+}
@@ -0,0 +1,252 @@
+package vgmanager
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	lsblkmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk/mocks"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	lvmmocks "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	topolvmv1 "github.com/topolvm/topolvm/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	lostVolume      = "0b4e7d2c-3f1a-4e8b-9c6d-5a2f1e0d3c4b"
+	lostClaimVolume = "1c5f8e3d-4a2b-4f9c-8d7e-6b3a2f1e0d5c"
+	boundVolume     = "2d6a9f4e-5b3c-4a0d-9e8f-7c4b3a2f1e6d"
+	foreignVolume   = "3e7b0a5f-6c4d-4b1e-8f9a-8d5c4b3a2f7e"
+	staleVolume     = "4f8c1b6a-7d5e-4c2f-9a0b-9e6d5c4b3a8f"
+	taggedVolume    = "6b0e3d8c-9f7a-4e4b-9c2d-1a8f7e6d5c0b"
+	claimUID        = "7c1f4e9d-0a8b-4f5c-8d3e-2b9a8f7e6d1c"
+	recoveredUID    = "5a9d2c7b-8e6f-4d3a-8b1c-0f7e6d5c4b9a"
+)
+
+func newRecovery(t *testing.T, objs ...client.Object) (*Recovery, *lvmmocks.MockLVM) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, lvmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, topolvmv1.AddToScheme(scheme))
+
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: "lvms-vg1"},
+		Provisioner: constants.TopolvmCSIDriverName,
+		Parameters:  map[string]string{constants.DeviceClassKey: "vg1"},
+	}
+	vg := &lvmv1alpha1.LVMVolumeGroup{ObjectMeta: metav1.ObjectMeta{Name: "vg1", Namespace: "openshift-lvm-storage"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(append(objs, vg, storageClass)...).
+		WithStatusSubresource(&topolvmv1.LogicalVolume{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				// the fake client does not assign UIDs like the API server
+				obj.SetUID(recoveredUID)
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if err := c.Update(ctx, obj, opts...); err != nil {
+					return err
+				}
+				// TopoLVM adopts the logical volume named after the LogicalVolume once it is assigned to the node
+				if logicalVolume, ok := obj.(*topolvmv1.LogicalVolume); ok && logicalVolume.Spec.NodeName != "" &&
+					logicalVolume.Status.VolumeID == "" {
+					logicalVolume.Status.VolumeID = string(logicalVolume.UID)
+					return c.Status().Update(ctx, logicalVolume)
+				}
+				return nil
+			},
+		}).Build()
+
+	mockLVM := lvmmocks.NewMockLVM(t)
+	mockLSBLK := lsblkmocks.NewMockLSBLK(t)
+	mockLSBLK.EXPECT().ListBlockDevices(mock.Anything).Return([]lsblk.BlockDevice{{
+		Name: "/dev/sdb", KName: "/dev/sdb", Type: "disk",
+		Children: []lsblk.BlockDevice{{
+			Name: deviceMapperPath("vg1", lostVolume), KName: "/dev/dm-1", Type: "lvm", FSType: "xfs",
+		}},
+	}}, nil).Maybe()
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}}, nil).Maybe()
+
+	return &Recovery{
+		Client:    c,
+		LVM:       mockLVM,
+		LSBLK:     mockLSBLK,
+		NodeName:  "node1",
+		Namespace: "openshift-lvm-storage",
+	}, mockLVM
+}
+
+func recoveryLVs() *lvm.LVReport {
+	return &lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-1", VgName: "vg1", LvAttr: "twi-aotz--", LvSize: "10737418240"},
+		{Name: "data", VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824"},
+		{Name: lostVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "2147483648"},
+		{Name: lostClaimVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824",
+			Tags: "lvms.openshift.io/device-class=vg1,lvms.openshift.io/pvc-namespace=app,lvms.openshift.io/pvc-name=data"},
+		{Name: boundVolume, VgName: "vg1", LvAttr: "-wi-ao----", LvSize: "1073741824"},
+		{Name: foreignVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824"},
+		{Name: staleVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824"},
+	}}}}
+}
+
+func recoveryObjects() []client.Object {
+	logicalVolume := func(name, node, volumeID string) *topolvmv1.LogicalVolume {
+		return &topolvmv1.LogicalVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid")},
+			Spec:       topolvmv1.LogicalVolumeSpec{Name: name, NodeName: node, DeviceClass: "vg1", Size: resource.MustParse("1Gi")},
+			Status:     topolvmv1.LogicalVolumeStatus{VolumeID: volumeID},
+		}
+	}
+	pv := func(name, volumeID string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: constants.TopolvmCSIDriverName, VolumeHandle: volumeID},
+			}},
+		}
+	}
+	return []client.Object{
+		logicalVolume("pvc-claim", "node1", lostClaimVolume),
+		logicalVolume("pvc-bound", "node1", boundVolume),
+		pv("pvc-bound", boundVolume),
+		logicalVolume("pvc-foreign", "node2", foreignVolume),
+		pv("pvc-stale", staleVolume),
+	}
+}
+
+func TestRecovery_Plan(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, mockLVM := newRecovery(t, recoveryObjects()...)
+	lvs := recoveryLVs()
+	lvs.Report[0].Lv = append(lvs.Report[0].Lv, lvm.LogicalVolume{
+		Name: taggedVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824",
+		Tags: "lvms.openshift.io/pvc-namespace=app,lvms.openshift.io/pvc-name=logs,lvms.openshift.io/pvc-uid=" + claimUID,
+	})
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(lvs, nil).Once()
+
+	items, err := r.Plan(ctx)
+	require.NoError(t, err)
+
+	actions := make(map[string]RecoveryItem)
+	for _, item := range items {
+		actions[item.LVName] = item
+	}
+	assert.Len(t, actions, 6, "only the logical volumes provisioned by TopoLVM must be recovered")
+
+	assert.Equal(t, RecoveryActionCreateLogicalVolume, actions[lostVolume].Action)
+	assert.Equal(t, recoveredPersistentVolumePrefix+lostVolume, actions[lostVolume].PersistentVolume)
+	assert.Equal(t, "lvms-vg1", actions[lostVolume].StorageClass)
+	assert.Equal(t, "xfs", actions[lostVolume].FSType)
+
+	assert.Equal(t, RecoveryActionCreatePersistentVolume, actions[lostClaimVolume].Action)
+	assert.Equal(t, "pvc-claim", actions[lostClaimVolume].PersistentVolume)
+	assert.Empty(t, actions[lostClaimVolume].FSType, "a logical volume without a filesystem must be a block volume")
+
+	assert.Equal(t, "data", actions[lostClaimVolume].ClaimName)
+
+	assert.Equal(t, RecoveryActionCreateLogicalVolume, actions[taggedVolume].Action)
+	assert.Equal(t, "pvc-"+claimUID, actions[taggedVolume].PersistentVolume,
+		"the volume must be named like the PersistentVolume originally provisioned for the claim")
+	assert.Equal(t, "app", actions[taggedVolume].ClaimNamespace)
+	assert.Equal(t, "logs", actions[taggedVolume].ClaimName)
+
+	assert.Equal(t, RecoveryActionNone, actions[boundVolume].Action)
+	assert.Equal(t, RecoveryActionSkip, actions[foreignVolume].Action)
+	assert.Equal(t, RecoveryActionSkip, actions[staleVolume].Action)
+
+	report := &bytes.Buffer{}
+	require.NoError(t, WriteRecoveryReport(report, items))
+	assert.Contains(t, report.String(), "2Gi")
+	assert.Contains(t, report.String(), "the LogicalVolume belongs to node node2")
+}
+
+func TestRecovery_PlanVolumeGroupWithoutLVMVolumeGroup(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, mockLVM := newRecovery(t)
+	for _, call := range mockLVM.ExpectedCalls {
+		if call.Method == "ListVGs" {
+			call.Unset()
+		}
+	}
+	mockLVM.EXPECT().ListVGs(mock.Anything, true).Return([]lvm.VolumeGroup{{Name: "vg1"}, {Name: "vg2"}}, nil).Once()
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(&lvm.LVReport{}, nil).Once()
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg2").Return(&lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-2", VgName: "vg2", LvAttr: "twi-aotz--", LvSize: "10737418240"},
+		{Name: lostClaimVolume, VgName: "vg2", LvAttr: "-wi-a-----", LvSize: "1073741824",
+			Tags: "lvms.openshift.io/pvc-namespace=app,lvms.openshift.io/pvc-name=data"},
+	}}}}, nil).Once()
+
+	items, err := r.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1, "the logical volumes of a volume group without an LVMVolumeGroup must be listed")
+	assert.Equal(t, "vg2", items[0].VolumeGroup)
+	assert.Equal(t, lostClaimVolume, items[0].LVName)
+	assert.Equal(t, RecoveryActionSkip, items[0].Action)
+	assert.Contains(t, items[0].Reason, "no LVMVolumeGroup")
+	assert.Equal(t, "data", items[0].ClaimName)
+}
+
+func TestRecovery_Apply(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+	r, mockLVM := newRecovery(t, recoveryObjects()...)
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(recoveryLVs(), nil).Once()
+
+	items, err := r.Plan(ctx)
+	require.NoError(t, err)
+
+	mockLVM.EXPECT().ListLVsByName(mock.Anything, "vg1").Return([]string{lostVolume}, nil).Once()
+	mockLVM.EXPECT().RenameLV(mock.Anything, lostVolume, "vg1", recoveredUID).Return(nil).Once()
+	require.NoError(t, r.Apply(ctx, items))
+
+	logicalVolume := &topolvmv1.LogicalVolume{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: recoveredPersistentVolumePrefix + lostVolume}, logicalVolume))
+	assert.Equal(t, "node1", logicalVolume.Spec.NodeName)
+	assert.Equal(t, lostVolume, logicalVolume.Annotations[constants.RecoveredLogicalVolumeAnnotation])
+
+	pv := &corev1.PersistentVolume{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: recoveredPersistentVolumePrefix + lostVolume}, pv))
+	assert.Equal(t, recoveredUID, pv.Spec.CSI.VolumeHandle)
+	assert.Equal(t, "xfs", pv.Spec.CSI.FSType)
+	assert.Equal(t, corev1.PersistentVolumeFilesystem, *pv.Spec.VolumeMode)
+	assert.Equal(t, corev1.PersistentVolumeReclaimRetain, pv.Spec.PersistentVolumeReclaimPolicy)
+	assert.Nil(t, pv.Spec.ClaimRef)
+
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Name: "pvc-claim"}, pv))
+	assert.Equal(t, lostClaimVolume, pv.Spec.CSI.VolumeHandle)
+	assert.Equal(t, corev1.PersistentVolumeBlock, *pv.Spec.VolumeMode)
+	require.NotNil(t, pv.Spec.ClaimRef, "the volume must be pre-bound to the claim in the tags")
+	assert.Equal(t, "app", pv.Spec.ClaimRef.Namespace)
+	assert.Equal(t, "data", pv.Spec.ClaimRef.Name)
+	assert.Empty(t, pv.Spec.ClaimRef.UID)
+
+	// nothing is left to recover
+	renamed := &lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: recoveredUID, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "2147483648"},
+		{Name: lostClaimVolume, VgName: "vg1", LvAttr: "-wi-a-----", LvSize: "1073741824"},
+	}}}}
+	mockLVM.EXPECT().ListLVs(mock.Anything, "vg1").Return(renamed, nil).Once()
+	items, err = r.Plan(ctx)
+	require.NoError(t, err)
+	for _, item := range items {
+		assert.Equal(t, RecoveryActionNone, item.Action, item.LVName)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smart
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// smartctlFatalExitStatus are the bits of the smartctl exit status that mean that the device could not be read,
+	// see smartctl(8). The other bits report the health of the device.
+	smartctlFatalExitStatus = 1<<0 | 1<<1
+
+	// ataReallocatedSectorCount is the id of the ATA SMART attribute counting the reallocated sectors.
+	ataReallocatedSectorCount = 5
+
+	// kelvinOffset converts the temperatures in Kelvin reported in the NVMe SMART log to degrees Celsius.
+	kelvinOffset = 273
+)
+
+var ErrHealthNotReported = errors.New("the SMART overall-health self-assessment was not reported")
+
+// smartctlOutput is the subset of the output of smartctl --json that is used to determine the health of a device.
+type smartctlOutput struct {
+	Smartctl struct {
+		ExitStatus int `json:"exit_status"`
+		Messages   []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current *int64 `json:"current"`
+	} `json:"temperature"`
+	ATASmartAttributes *struct {
+		Table []struct {
+			ID  int `json:"id"`
+			Raw struct {
+				Value int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeSmartHealthInformationLog *struct {
+		PercentageUsed *int64 `json:"percentage_used"`
+		MediaErrors    *int64 `json:"media_errors"`
+	} `json:"nvme_smart_health_information_log"`
+	SCSIGrownDefectList *int64 `json:"scsi_grown_defect_list"`
+}
+
+// ParseSmartctl parses the health of the device from the output of smartctl --json.
+func ParseSmartctl(device string, data []byte) (*DeviceHealth, error) {
+	var output smartctlOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse smartctl output: %w", err)
+	}
+
+	if output.Smartctl.ExitStatus&smartctlFatalExitStatus != 0 {
+		var messages []string
+		for _, message := range output.Smartctl.Messages {
+			messages = append(messages, message.String)
+		}
+		return nil, fmt.Errorf("smartctl failed to read the device with exit status %d: %s",
+			output.Smartctl.ExitStatus, strings.Join(messages, "; "))
+	}
+	if output.SmartStatus == nil {
+		return nil, ErrHealthNotReported
+	}
+
+	health := &DeviceHealth{
+		Device: device,
+		Passed: output.SmartStatus.Passed,
+	}
+	if output.Temperature != nil {
+		health.Temperature = output.Temperature.Current
+	}
+	if output.ATASmartAttributes != nil {
+		for _, attribute := range output.ATASmartAttributes.Table {
+			if attribute.ID == ataReallocatedSectorCount {
+				health.ReallocatedSectors = ptr.To(attribute.Raw.Value)
+			}
+		}
+	}
+	if output.SCSIGrownDefectList != nil {
+		health.ReallocatedSectors = output.SCSIGrownDefectList
+	}
+	if log := output.NVMeSmartHealthInformationLog; log != nil {
+		health.PercentageUsed = log.PercentageUsed
+		health.MediaErrors = log.MediaErrors
+	}
+	return health, nil
+}
+
+// nvmeSmartLog is the subset of the output of nvme smart-log --output-format=json that is used to determine
+// the health of a device. Depending on the version of nvme-cli, counters can be reported as strings.
+type nvmeSmartLog struct {
+	CriticalWarning *jsonInt `json:"critical_warning"`
+	Temperature     *jsonInt `json:"temperature"`
+	PercentUsed     *jsonInt `json:"percent_used"`
+	PercentageUsed  *jsonInt `json:"percentage_used"`
+	MediaErrors     *jsonInt `json:"media_errors"`
+}
+
+// ParseNVMeSmartLog parses the health of the device from the output of nvme smart-log --output-format=json.
+// The device passes the self-assessment if the controller does not report any critical warning.
+func ParseNVMeSmartLog(device string, data []byte) (*DeviceHealth, error) {
+	var log nvmeSmartLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to parse nvme smart-log output: %w", err)
+	}
+	if log.CriticalWarning == nil {
+		return nil, ErrHealthNotReported
+	}
+
+	health := &DeviceHealth{
+		Device:      device,
+		Passed:      *log.CriticalWarning == 0,
+		MediaErrors: log.MediaErrors.int64(),
+	}
+	if log.Temperature != nil {
+		health.Temperature = ptr.To(int64(*log.Temperature) - kelvinOffset)
+	}
+	health.PercentageUsed = log.PercentUsed.int64()
+	if health.PercentageUsed == nil {
+		health.PercentageUsed = log.PercentageUsed.int64()
+	}
+	return health, nil
+}
+
+// jsonInt is an integer that can be encoded as JSON number or string.
+type jsonInt int64
+
+func (i *jsonInt) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = jsonInt(value)
+	return nil
+}
+
+func (i *jsonInt) int64() *int64 {
+	if i == nil {
+		return nil
+	}
+	return ptr.To(int64(*i))
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/exec"
+)
+
+var (
+	DefaultSmartctl = "/usr/sbin/smartctl"
+	DefaultNVMe     = "/usr/sbin/nvme"
+)
+
+// DefaultCollectionInterval is the interval at which the health of a device is collected at most.
+const DefaultCollectionInterval = 10 * time.Minute
+
+// DeviceHealth is the health of a device as reported by SMART.
+// Attributes that are not reported for the type of the device are nil.
+type DeviceHealth struct {
+	// Device is the kernel name of the device
+	Device string
+	// Passed tells if the device passed its SMART overall-health self-assessment
+	Passed bool
+	// ReallocatedSectors is the number of reallocated sectors of an ATA device or the grown defects of a SCSI device
+	ReallocatedSectors *int64
+	// MediaErrors is the number of unrecovered media and data integrity errors of an NVMe device
+	MediaErrors *int64
+	// PercentageUsed is the vendor specific estimate of the used endurance of an NVMe device in percent
+	PercentageUsed *int64
+	// Temperature is the current temperature of the device in degrees Celsius
+	Temperature *int64
+}
+
+// SMART collects the health of devices.
+type SMART interface {
+	DeviceHealth(ctx context.Context, device string) (*DeviceHealth, error)
+}
+
+type HostSMART struct {
+	exec.Executor
+	smartctl string
+	nvme     string
+}
+
+func NewDefaultHostSMART() *HostSMART {
+	return NewHostSMART(&exec.CommandExecutor{}, DefaultSmartctl, DefaultNVMe)
+}
+
+func NewHostSMART(executor exec.Executor, smartctl, nvme string) *HostSMART {
+	return &HostSMART{
+		Executor: executor,
+		smartctl: smartctl,
+		nvme:     nvme,
+	}
+}
+
+// DeviceHealth collects the health of the device with smartctl. The health of NVMe devices is read from their
+// SMART log with nvme-cli instead if smartctl is not available on the host or cannot read the device.
+func (s *HostSMART) DeviceHealth(ctx context.Context, device string) (*DeviceHealth, error) {
+	health, err := s.smartctlHealth(ctx, device)
+	if err == nil {
+		return health, nil
+	}
+	if !strings.HasPrefix(filepath.Base(device), "nvme") {
+		return nil, err
+	}
+
+	health, nvmeErr := s.nvmeHealth(ctx, device)
+	if nvmeErr != nil {
+		return nil, errors.Join(err, nvmeErr)
+	}
+	return health, nil
+}
+
+func (s *HostSMART) smartctlHealth(ctx context.Context, device string) (*DeviceHealth, error) {
+	// smartctl reports the health of the device in the bits of its exit status, so a failed command
+	// is only an error if its output cannot be used.
+	output, runErr := s.output(ctx, s.smartctl, "--json", "--info", "--health", "--attributes", device)
+	health, err := ParseSmartctl(device, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect the health of %s with smartctl: %w", device, errors.Join(err, runErr))
+	}
+	return health, nil
+}
+
+func (s *HostSMART) nvmeHealth(ctx context.Context, device string) (*DeviceHealth, error) {
+	output, err := s.output(ctx, s.nvme, "smart-log", "--output-format=json", device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the SMART log of %s with nvme: %w", device, err)
+	}
+	return ParseNVMeSmartLog(device, output)
+}
+
+func (s *HostSMART) output(ctx context.Context, command string, arg ...string) ([]byte, error) {
+	reader, err := s.StartCommandWithOutputAsHost(ctx, command, arg...)
+	if err != nil {
+		return nil, err
+	}
+	output, err := io.ReadAll(reader)
+	return output, errors.Join(err, reader.Close())
+}
+
+// CachingSMART collects the health of a device at most once per interval, as the health of a device
+// changes slowly and collecting it can be slow. Failures are cached as well, so that devices without
+// SMART support are not queried again in every reconciliation.
+type CachingSMART struct {
+	SMART
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	health    *DeviceHealth
+	err       error
+	collected time.Time
+}
+
+func NewCachingSMART(smart SMART, interval time.Duration) *CachingSMART {
+	return &CachingSMART{
+		SMART:    smart,
+		interval: interval,
+		now:      time.Now,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+// DeviceHealth returns the cached health of the device, or collects it if it is older than the interval.
+func (c *CachingSMART) DeviceHealth(ctx context.Context, device string) (*DeviceHealth, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if entry, ok := c.entries[device]; ok && now.Sub(entry.collected) < c.interval {
+		return entry.health, entry.err
+	}
+
+	health, err := c.SMART.DeviceHealth(ctx, device)
+	c.entries[device] = cacheEntry{health: health, err: err, collected: now}
+	return health, err
+}
@@ -0,0 +1,193 @@
+package smart
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	mockExec "github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseSmartctl(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected *DeviceHealth
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			fixture: "smartctl_ata_healthy.json",
+			expected: &DeviceHealth{
+				Device:             "/dev/sda",
+				Passed:             true,
+				ReallocatedSectors: ptr.To[int64](0),
+				Temperature:        ptr.To[int64](32),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			fixture: "smartctl_ata_failing.json",
+			expected: &DeviceHealth{
+				Device:             "/dev/sda",
+				Passed:             false,
+				ReallocatedSectors: ptr.To[int64](2040),
+				Temperature:        ptr.To[int64](41),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			fixture: "smartctl_nvme.json",
+			expected: &DeviceHealth{
+				Device:         "/dev/sda",
+				Passed:         true,
+				MediaErrors:    ptr.To[int64](3),
+				PercentageUsed: ptr.To[int64](7),
+				Temperature:    ptr.To[int64](38),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			fixture: "smartctl_scsi.json",
+			expected: &DeviceHealth{
+				Device:             "/dev/sda",
+				Passed:             true,
+				ReallocatedSectors: ptr.To[int64](12),
+				Temperature:        ptr.To[int64](29),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			fixture: "smartctl_virtual.json",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorContains(t, err, "Unable to detect device type")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			health, err := ParseSmartctl("/dev/sda", fixture(t, tt.fixture))
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.expected, health)
+		})
+	}
+
+	_, err := ParseSmartctl("/dev/sda", []byte(`{"smartctl": {"exit_status": 4}}`))
+	assert.ErrorIs(t, err, ErrHealthNotReported)
+}
+
+func TestParseNVMeSmartLog(t *testing.T) {
+	health, err := ParseNVMeSmartLog("/dev/nvme0n1", fixture(t, "nvme_smart_log.json"))
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceHealth{
+		Device:         "/dev/nvme0n1",
+		Passed:         false,
+		MediaErrors:    ptr.To[int64](0),
+		PercentageUsed: ptr.To[int64](101),
+		Temperature:    ptr.To[int64](45),
+	}, health)
+
+	health, err = ParseNVMeSmartLog("/dev/nvme0n1", []byte(`{"critical_warning": 0, "percentage_used": "3", "media_errors": "1"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &DeviceHealth{
+		Device:         "/dev/nvme0n1",
+		Passed:         true,
+		MediaErrors:    ptr.To[int64](1),
+		PercentageUsed: ptr.To[int64](3),
+	}, health, "counters encoded as strings must be parsed")
+
+	_, err = ParseNVMeSmartLog("/dev/nvme0n1", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrHealthNotReported)
+}
+
+func TestHostSMART_DeviceHealth(t *testing.T) {
+	ctx := log.IntoContext(context.Background(), testr.New(t))
+
+	var commands []string
+	executor := &mockExec.MockExecutor{
+		MockExecuteCommandWithOutputAsHost: func(ctx context.Context, command string, args ...string) (io.ReadCloser, error) {
+			commands = append(commands, command)
+			switch command {
+			case DefaultSmartctl:
+				if args[len(args)-1] == "/dev/sda" {
+					return io.NopCloser(strings.NewReader(string(fixture(t, "smartctl_ata_failing.json")))), nil
+				}
+				return nil, errors.New("smartctl: command not found")
+			case DefaultNVMe:
+				assert.Equal(t, []string{"smart-log", "--output-format=json", "/dev/nvme0n1"}, args)
+				return io.NopCloser(strings.NewReader(string(fixture(t, "nvme_smart_log.json")))), nil
+			}
+			return nil, errors.New("unexpected command")
+		},
+	}
+	s := NewHostSMART(executor, DefaultSmartctl, DefaultNVMe)
+
+	health, err := s.DeviceHealth(ctx, "/dev/sda")
+	require.NoError(t, err)
+	assert.False(t, health.Passed)
+	assert.Equal(t, []string{DefaultSmartctl}, commands)
+
+	commands = nil
+	health, err = s.DeviceHealth(ctx, "/dev/nvme0n1")
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int64](101), health.PercentageUsed)
+	assert.Equal(t, []string{DefaultSmartctl, DefaultNVMe}, commands, "nvme devices must fall back to nvme-cli")
+
+	commands = nil
+	_, err = s.DeviceHealth(ctx, "/dev/sdb")
+	assert.ErrorContains(t, err, "command not found")
+	assert.Equal(t, []string{DefaultSmartctl}, commands, "only nvme devices can fall back to nvme-cli")
+}
+
+type countingSMART struct {
+	calls int
+	err   error
+}
+
+func (c *countingSMART) DeviceHealth(_ context.Context, device string) (*DeviceHealth, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &DeviceHealth{Device: device, Passed: true}, nil
+}
+
+func TestCachingSMART(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	inner := &countingSMART{}
+	s := NewCachingSMART(inner, time.Minute)
+	s.now = func() time.Time { return now }
+
+	_, err := s.DeviceHealth(ctx, "/dev/sda")
+	require.NoError(t, err)
+	_, err = s.DeviceHealth(ctx, "/dev/sda")
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.calls, "the health must be cached within the interval")
+
+	_, err = s.DeviceHealth(ctx, "/dev/sdb")
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.calls, "the health is cached per device")
+
+	inner.err = errors.New("no SMART support")
+	now = now.Add(time.Minute)
+	_, err = s.DeviceHealth(ctx, "/dev/sda")
+	assert.Error(t, err)
+	_, err = s.DeviceHealth(ctx, "/dev/sda")
+	assert.Error(t, err)
+	assert.Equal(t, 3, inner.calls, "failures must be cached as well")
+}
@@ -19,15 +19,23 @@ package vgmanager
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	lvmv1alpha1 "github.com/openshift/lvm-operator/v4/api/v1alpha1"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/filter"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
+	"github.com/openshift/lvm-operator/v4/internal/version"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -39,11 +47,12 @@ func (r *Reconciler) setVolumeGroupProgressingStatus(ctx context.Context, vg *lv
 	}
 
 	// Set devices for the VGStatus.
-	if _, err := r.setDevices(status, vgs, devices); err != nil {
+	vgExists, err := r.setDevices(status, vgs, devices)
+	if err != nil {
 		return false, err
 	}
 
-	return r.setVolumeGroupStatus(ctx, vg, status)
+	return r.setVolumeGroupStatus(ctx, vg, status, progressingCondition(vgExists))
 }
 
 func (r *Reconciler) setVolumeGroupReadyStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup, devices FilteredBlockDevices) (bool, error) {
@@ -57,10 +66,15 @@ func (r *Reconciler) setVolumeGroupReadyStatus(ctx context.Context, vg *lvmv1alp
 		return false, err
 	}
 
-	return r.setVolumeGroupStatus(ctx, vg, status)
+	// Set thin pool usage for the VGStatus.
+	r.setThinPool(ctx, status, vg)
+
+	return r.setVolumeGroupStatus(ctx, vg, status, nil)
 }
 
-func (r *Reconciler) setVolumeGroupFailedStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup, devices FilteredBlockDevices, err error) (bool, error) {
+// setVolumeGroupFailedStatus sets the volume group to failed, or degraded if it is backed by devices.
+// The condition of the given type is set to False with the error as message.
+func (r *Reconciler) setVolumeGroupFailedStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup, devices FilteredBlockDevices, conditionType string, err error) (bool, error) {
 	status := &lvmv1alpha1.VGStatus{
 		Name:   vg.GetName(),
 		Status: lvmv1alpha1.VGStatusFailed,
@@ -75,10 +89,29 @@ func (r *Reconciler) setVolumeGroupFailedStatus(ctx context.Context, vg *lvmv1al
 		status.Status = lvmv1alpha1.VGStatusDegraded
 	}
 
-	return r.setVolumeGroupStatus(ctx, vg, status)
+	return r.setVolumeGroupStatus(ctx, vg, status, failedCondition(conditionType, err))
 }
 
-func (r *Reconciler) setVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, status *lvmv1alpha1.VGStatus) (bool, error) {
+// setVolumeGroupOrphanedStatus reports the volume group as orphaned on a node that no longer matches its node selector.
+func (r *Reconciler) setVolumeGroupOrphanedStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, vgs []lvm.VolumeGroup, reason string) (bool, error) {
+	status := &lvmv1alpha1.VGStatus{
+		Name:   vg.GetName(),
+		Status: lvmv1alpha1.VGStatusOrphaned,
+		Reason: reason,
+	}
+
+	// Set devices for the VGStatus.
+	if _, err := r.setDevices(status, vgs, FilteredBlockDevices{}); err != nil {
+		return false, err
+	}
+
+	return r.setVolumeGroupStatus(ctx, vg, status, nil)
+}
+
+// setVolumeGroupStatus sets the VGStatus in the spec of the LVMVolumeGroupNodeStatus and the conditions of the
+// volume group in its status subresource, see setVolumeGroupConditions for the condition.
+// It returns true if the VGStatus was modified.
+func (r *Reconciler) setVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup, status *lvmv1alpha1.VGStatus, condition *metav1.Condition) (bool, error) {
 	logger := log.FromContext(ctx).WithValues("VolumeGroup", client.ObjectKeyFromObject(vg))
 
 	if hasExplicitDevicePaths(vg) {
@@ -90,6 +123,8 @@ func (r *Reconciler) setVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha1.L
 		status.DeviceDiscoveryPolicy = lvmv1alpha1.DeviceDiscoveryPolicyRuntimeStatic
 	}
 
+	metrics.SetVolumeGroupStatus(status)
+
 	// Get LVMVolumeGroupNodeStatus and set the relevant VGStatus
 	nodeStatus := r.getLVMVolumeGroupNodeStatus()
 
@@ -120,9 +155,49 @@ func (r *Reconciler) setVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha1.L
 	if updated {
 		logger.Info("LVMVolumeGroupNodeStatus modified", "operation", result, "name", nodeStatus.Name)
 	}
+
+	if err := r.patchVolumeGroupConditions(ctx, nodeStatus, func(volumeGroups []lvmv1alpha1.VolumeGroupConditions) []lvmv1alpha1.VolumeGroupConditions {
+		i := slices.IndexFunc(volumeGroups, func(c lvmv1alpha1.VolumeGroupConditions) bool {
+			return c.Name == vg.GetName()
+		})
+		if i < 0 {
+			volumeGroups = append(volumeGroups, lvmv1alpha1.VolumeGroupConditions{Name: vg.GetName()})
+			i = len(volumeGroups) - 1
+		}
+		setVolumeGroupConditions(&volumeGroups[i].Conditions, vg, condition)
+		stampHeartbeat(&volumeGroups[i], time.Now())
+		return volumeGroups
+	}); err != nil {
+		return updated, fmt.Errorf("LVMVolumeGroupNodeStatus conditions could not be updated: %w", err)
+	}
+
 	return updated, nil
 }
 
+// patchVolumeGroupConditions updates the conditions of the volume groups in the status subresource
+// of the LVMVolumeGroupNodeStatus. The status is only patched if the conditions changed.
+func (r *Reconciler) patchVolumeGroupConditions(
+	ctx context.Context,
+	nodeStatus *lvmv1alpha1.LVMVolumeGroupNodeStatus,
+	update func([]lvmv1alpha1.VolumeGroupConditions) []lvmv1alpha1.VolumeGroupConditions,
+) error {
+	original := nodeStatus.DeepCopy()
+	nodeStatus.Status.VolumeGroups = update(nodeStatus.Status.VolumeGroups)
+	if equality.Semantic.DeepEqual(original.Status, nodeStatus.Status) {
+		return nil
+	}
+	return r.Status().Patch(ctx, nodeStatus, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// stampHeartbeat refreshes the heartbeat of vgmanager for the volume group. To keep the status writes cheap,
+// the time of the last reconciliation is only refreshed once it is older than the DefaultHeartbeatInterval.
+func stampHeartbeat(vg *lvmv1alpha1.VolumeGroupConditions, now time.Time) {
+	if vg.LastReconcileTime == nil || now.Sub(vg.LastReconcileTime.Time) >= DefaultHeartbeatInterval {
+		vg.LastReconcileTime = &metav1.Time{Time: now}
+	}
+	vg.VGManagerVersion = version.Get()
+}
+
 func (r *Reconciler) removeVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha1.LVMVolumeGroup) error {
 	logger := log.FromContext(ctx)
 
@@ -164,6 +239,14 @@ func (r *Reconciler) removeVolumeGroupStatus(ctx context.Context, vg *lvmv1alpha
 		return fmt.Errorf("failed to create or update LVMVolumeGroupNodeStatus %s, %w", nodeStatus.GetName(), err)
 	}
 
+	if err := r.patchVolumeGroupConditions(ctx, nodeStatus, func(volumeGroups []lvmv1alpha1.VolumeGroupConditions) []lvmv1alpha1.VolumeGroupConditions {
+		return slices.DeleteFunc(volumeGroups, func(c lvmv1alpha1.VolumeGroupConditions) bool {
+			return c.Name == vg.GetName()
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to remove conditions from LVMVolumeGroupNodeStatus %s, %w", nodeStatus.GetName(), err)
+	}
+
 	if result != controllerutil.OperationResultNone {
 		logger.Info("LVMVolumeGroupNodeStatus modified", "operation", result, "name", nodeStatus.Name)
 	} else {
@@ -177,11 +260,22 @@ func (r *Reconciler) setDevices(status *lvmv1alpha1.VGStatus, vgs []lvm.VolumeGr
 	devicesExist := false
 	for _, vg := range vgs {
 		if status.Name == vg.Name {
+			status.Size = parseLVMQuantity(vg.VgSize)
+			status.Free = parseLVMQuantity(vg.VgFree)
 			if len(vg.PVs) > 0 {
 				devicesExist = true
 				status.Devices = make([]string, len(vg.PVs))
-				for i := range vg.PVs {
-					status.Devices[i] = vg.PVs[i].PvName
+				status.PhysicalVolumes = make([]lvmv1alpha1.PhysicalVolumeStatus, len(vg.PVs))
+				for i, pv := range vg.PVs {
+					status.Devices[i] = pv.PvName
+					status.PhysicalVolumes[i] = lvmv1alpha1.PhysicalVolumeStatus{
+						Name:       pv.PvName,
+						Size:       parseLVMQuantity(pv.PvSize),
+						Free:       parseLVMQuantity(pv.PvFree),
+						DeviceSize: parseLVMQuantity(pv.DevSize),
+						Missing:    pv.PvMissing != "",
+						Health:     deviceHealthStatus(devices.Health[pv.PvName]),
+					}
 				}
 			}
 		}
@@ -214,9 +308,115 @@ func (r *Reconciler) setDevices(status *lvmv1alpha1.VGStatus, vgs []lvm.VolumeGr
 		return status.Excluded[i].Name < status.Excluded[j].Name
 	})
 
+	status.Multipath = multipathDevices(status.Devices, devices)
+
 	return devicesExist, nil
 }
 
+// setThinPool sets the usage of the thin pool of the volume group in the VGStatus.
+// The usage is informational only, so a failure to determine it is logged and the thin pool is left out of the status.
+func (r *Reconciler) setThinPool(ctx context.Context, status *lvmv1alpha1.VGStatus, vg *lvmv1alpha1.LVMVolumeGroup) {
+	if vg.Spec.ThinPoolConfig == nil {
+		return
+	}
+
+	resp, err := r.ListLVs(ctx, vg.GetName())
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list logical volumes for the thin pool status")
+		return
+	}
+	status.ThinPool = thinPoolStatus(vg.Spec.ThinPoolConfig.Name, resp)
+}
+
+// thinPoolStatus builds the status of the thin pool with the given name from the logical volumes of its volume group.
+// It returns nil if the thin pool is not part of the report.
+func thinPoolStatus(name string, report *lvm.LVReport) *lvmv1alpha1.ThinPoolStatus {
+	var thinPool *lvmv1alpha1.ThinPoolStatus
+	thinVolumes := 0
+	for _, item := range report.Report {
+		for _, lv := range item.Lv {
+			if lv.Name == name {
+				thinPool = &lvmv1alpha1.ThinPoolStatus{
+					Name:            lv.Name,
+					Size:            parseLVMQuantity(lv.LvSize),
+					DataPercent:     strings.TrimSpace(lv.DataPercent),
+					MetadataPercent: strings.TrimSpace(lv.MetadataPercent),
+					ChunkSize:       parseLVMQuantity(lv.ChunkSize),
+				}
+			} else if lv.PoolName == name {
+				thinVolumes++
+			}
+		}
+	}
+	if thinPool != nil {
+		thinPool.LogicalVolumeCount = thinVolumes
+	}
+	return thinPool
+}
+
+// parseLVMQuantity parses a size reported by lvm2 in bytes without a unit suffix.
+// It returns nil if the size is not reported or cannot be parsed.
+func parseLVMQuantity(value string) *resource.Quantity {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil
+	}
+	return &quantity
+}
+
+// multipathDevices collects the multipath devices that are used by or available to the volume group
+// together with the state of their paths. The paths themselves are always excluded from the volume group.
+func multipathDevices(used []string, devices FilteredBlockDevices) []lvmv1alpha1.MultipathDevice {
+	candidates := sets.New(used...)
+	for _, available := range devices.Available {
+		candidates.Insert(available.Name, available.KName)
+	}
+
+	byName := make(map[string]*lvmv1alpha1.MultipathDevice)
+	for _, excluded := range devices.Excluded {
+		mpath, ok := excluded.MultipathDevice()
+		if !ok || !candidates.HasAny(mpath.Name, mpath.KName) {
+			continue
+		}
+		device, ok := byName[mpath.Name]
+		if !ok {
+			device = &lvmv1alpha1.MultipathDevice{Name: mpath.Name}
+			byName[mpath.Name] = device
+		}
+		device.Paths = append(device.Paths, lvmv1alpha1.MultipathPath{
+			Name:    excluded.Name,
+			State:   excluded.State,
+			Healthy: isPathHealthy(excluded.State),
+		})
+	}
+
+	var result []lvmv1alpha1.MultipathDevice
+	for _, device := range byName {
+		sort.Slice(device.Paths, func(i, j int) bool {
+			return device.Paths[i].Name < device.Paths[j].Name
+		})
+		result = append(result, *device)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// isPathHealthy checks the kernel state of a multipath path. Devices that do not report a state are considered healthy.
+func isPathHealthy(state string) bool {
+	switch state {
+	case "", "running", "live":
+		return true
+	default:
+		return false
+	}
+}
+
 func (r *Reconciler) getLVMVolumeGroupNodeStatus() *lvmv1alpha1.LVMVolumeGroupNodeStatus {
 	return &lvmv1alpha1.LVMVolumeGroupNodeStatus{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,87 @@
+package vgmanager
+
+import (
+	"testing"
+
+	"github.com/openshift/lvm-operator/v4/api/v1alpha1"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/filter"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lvm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipathDevices(t *testing.T) {
+	mpatha := lsblk.BlockDevice{Name: "/dev/mapper/mpatha", KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath}
+	mpathb := lsblk.BlockDevice{Name: "/dev/mapper/mpathb", KName: "/dev/dm-1", Type: lsblk.DeviceTypeMultipath}
+	memberErr := []error{filter.ErrMultipathMember}
+
+	devices := FilteredBlockDevices{
+		Available: []lsblk.BlockDevice{mpathb},
+		Excluded: []FilteredBlockDevice{
+			{BlockDevice: lsblk.BlockDevice{Name: "/dev/sdc", State: "offline", Children: []lsblk.BlockDevice{mpatha}}, FilterErrors: memberErr},
+			{BlockDevice: lsblk.BlockDevice{Name: "/dev/sdb", State: "running", Children: []lsblk.BlockDevice{mpatha}}, FilterErrors: memberErr},
+			{BlockDevice: lsblk.BlockDevice{Name: "/dev/sdd", State: "running", Children: []lsblk.BlockDevice{mpathb}}, FilterErrors: memberErr},
+			{BlockDevice: lsblk.BlockDevice{Name: "/dev/sde", State: "running", Children: []lsblk.BlockDevice{
+				{Name: "/dev/mapper/mpathc", KName: "/dev/dm-2", Type: lsblk.DeviceTypeMultipath},
+			}}, FilterErrors: memberErr},
+		},
+	}
+
+	assert.Equal(t, []v1alpha1.MultipathDevice{
+		{Name: "/dev/mapper/mpatha", Paths: []v1alpha1.MultipathPath{
+			{Name: "/dev/sdb", State: "running", Healthy: true},
+			{Name: "/dev/sdc", State: "offline", Healthy: false},
+		}},
+		{Name: "/dev/mapper/mpathb", Paths: []v1alpha1.MultipathPath{
+			{Name: "/dev/sdd", State: "running", Healthy: true},
+		}},
+	}, multipathDevices([]string{"/dev/mapper/mpatha"}, devices), "multipath devices neither used nor available must not be reported")
+}
+
+func TestSetDevicesCapacity(t *testing.T) {
+	r := &Reconciler{}
+	status := &v1alpha1.VGStatus{Name: "vg1"}
+	vgs := []lvm.VolumeGroup{
+		{Name: "vg0", VgSize: "1", VgFree: "1"},
+		{Name: "vg1", VgSize: "21470642176", VgFree: "1073741824 ", PVs: []lvm.PhysicalVolume{
+			{PvName: "/dev/sdb", PvSize: "10735321088", PvFree: "0", DevSize: "10737418240"},
+			{PvName: "/dev/sdc", PvSize: "10735321088", PvFree: "1073741824", DevSize: "21474836480", PvMissing: "missing"},
+		}},
+	}
+
+	devicesExist, err := r.setDevices(status, vgs, FilteredBlockDevices{})
+	require.NoError(t, err)
+	assert.True(t, devicesExist)
+	assert.Equal(t, int64(21470642176), status.Size.Value())
+	assert.Equal(t, int64(1073741824), status.Free.Value())
+	assert.Equal(t, []string{"/dev/sdb", "/dev/sdc"}, status.Devices)
+	require.Len(t, status.PhysicalVolumes, 2)
+	assert.Equal(t, "/dev/sdb", status.PhysicalVolumes[0].Name)
+	assert.Equal(t, int64(10735321088), status.PhysicalVolumes[0].Size.Value())
+	assert.True(t, status.PhysicalVolumes[0].Free.IsZero())
+	assert.Equal(t, int64(10737418240), status.PhysicalVolumes[0].DeviceSize.Value())
+	assert.False(t, status.PhysicalVolumes[0].Missing)
+	assert.Equal(t, int64(21474836480), status.PhysicalVolumes[1].DeviceSize.Value())
+	assert.True(t, status.PhysicalVolumes[1].Missing)
+}
+
+func TestThinPoolStatus(t *testing.T) {
+	report := &lvm.LVReport{Report: []lvm.LVReportItem{{Lv: []lvm.LogicalVolume{
+		{Name: "thin-pool-1", LvSize: "10737418240", DataPercent: "12.50", MetadataPercent: "1.05", ChunkSize: "65536"},
+		{Name: "pvc-1", PoolName: "thin-pool-1", LvSize: "1073741824"},
+		{Name: "pvc-2", PoolName: "thin-pool-1", LvSize: "1073741824"},
+		{Name: "thick", LvSize: "1073741824"},
+	}}}}
+
+	thinPool := thinPoolStatus("thin-pool-1", report)
+	require.NotNil(t, thinPool)
+	assert.Equal(t, "thin-pool-1", thinPool.Name)
+	assert.Equal(t, int64(10737418240), thinPool.Size.Value())
+	assert.Equal(t, "12.50", thinPool.DataPercent)
+	assert.Equal(t, "1.05", thinPool.MetadataPercent)
+	assert.Equal(t, int64(65536), thinPool.ChunkSize.Value())
+	assert.Equal(t, 2, thinPool.LogicalVolumeCount)
+
+	assert.Nil(t, thinPoolStatus("thin-pool-2", report))
+}
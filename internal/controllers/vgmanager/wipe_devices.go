@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/dmsetup"
 	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/lsblk"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/vgmanager/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -36,7 +37,11 @@ func (r *Reconciler) wipeDevices(
 			return false, fmt.Errorf("failed to wipe device %s: %w", path, err)
 		}
 
-		if deviceWiped, err := r.wipeDevice(ctx, pathResolved, blockDevices); err != nil {
+		deviceWiped, err := r.wipeDevice(ctx, pathResolved, blockDevices)
+		if err != nil || deviceWiped {
+			metrics.ObserveDeviceWipe(volumeGroup.Name, err)
+		}
+		if err != nil {
 			return false, fmt.Errorf("failed to wipe device %s: %w", path, err)
 		} else if deviceWiped {
 			updated = true
@@ -47,7 +52,11 @@ func (r *Reconciler) wipeDevices(
 		if err != nil {
 			logger.Info(fmt.Sprintf("skipping wiping optional device %s: %v", path, err))
 		}
-		if deviceWiped, err := r.wipeDevice(ctx, pathResolved, blockDevices); err != nil {
+		deviceWiped, err := r.wipeDevice(ctx, pathResolved, blockDevices)
+		if err != nil || deviceWiped {
+			metrics.ObserveDeviceWipe(volumeGroup.Name, err)
+		}
+		if err != nil {
 			logger.Info(fmt.Sprintf("skipping wiping optional device %s: %v", path, err))
 		} else if deviceWiped {
 			updated = true
@@ -105,6 +114,11 @@ func (r *Reconciler) wipeDevice(ctx context.Context, deviceName string, blockDev
 	wiped := false
 	for _, device := range blockDevices {
 		if device.KName == deviceName {
+			// wiping a single path would destroy the data of the active multipath device behind it
+			if mpath, ok := device.MultipathDevice(); ok {
+				return false, fmt.Errorf("%s is a path of the active multipath device %s and cannot be wiped, "+
+					"select the multipath device instead", deviceName, mpath.Name)
+			}
 			// remove all references that were just orphaned
 			for _, child := range device.Children {
 				// all mapper references must be removed before wiping the device
@@ -124,7 +138,9 @@ func (r *Reconciler) wipeDevice(ctx context.Context, deviceName string, blockDev
 				return false, err
 			}
 			if childWiped {
+				// multipath devices show up below each of their paths, so stop after the first match
 				wiped = true
+				break
 			}
 		}
 	}
@@ -134,12 +150,17 @@ func (r *Reconciler) wipeDevice(ctx context.Context, deviceName string, blockDev
 // removeMapperReference remove the device-mapper reference of the device starting from the most inner child
 func (r *Reconciler) removeMapperReference(ctx context.Context, device lsblk.BlockDevice) {
 	logger := log.FromContext(ctx).WithValues("deviceName", device.KName)
+	if device.Type == lsblk.DeviceTypeMultipath {
+		// the multipath map and everything on top of it is still in use through the other paths
+		logger.Info("skipping the removal of device-mapper reference as the device is an active multipath device", "childName", device.KName)
+		return
+	}
 	if device.HasChildren() {
 		for _, child := range device.Children {
 			r.removeMapperReference(ctx, child)
 		}
 	}
-	if device.Type == "part" {
+	if device.Type == lsblk.DeviceTypePart {
 		logger.Info("skipping the removal of device-mapper reference as the device is a partition", "childName", device.KName)
 		return
 	} else {
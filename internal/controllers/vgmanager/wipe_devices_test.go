@@ -33,6 +33,7 @@ func TestWipeDevices(t *testing.T) {
 		wipeCount            int
 		removeReferenceCount int
 		wipedBefore          bool
+		expectErr            bool
 	}{
 		{
 			name:                 "Force wipe feature is not enabled",
@@ -159,6 +160,25 @@ func TestWipeDevices(t *testing.T) {
 			wipeCount:            2,
 			removeReferenceCount: 1,
 		},
+		{
+			name:        "Multipath device listed below each of its paths is wiped once",
+			devicePaths: []v1alpha1.DevicePath{"/dev/dm-0"},
+			blockDevices: []lsblk.BlockDevice{
+				{KName: "/dev/sdb", Type: "disk", Children: []lsblk.BlockDevice{{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath, Children: []lsblk.BlockDevice{{KName: "/dev/dm-1", Type: "lvm"}}}}},
+				{KName: "/dev/sdc", Type: "disk", Children: []lsblk.BlockDevice{{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath, Children: []lsblk.BlockDevice{{KName: "/dev/dm-1", Type: "lvm"}}}}},
+			},
+			wipeCount:            1,
+			removeReferenceCount: 1,
+		},
+		{
+			name:        "Path of an active multipath device is not wiped",
+			devicePaths: []v1alpha1.DevicePath{"/dev/sdb"},
+			blockDevices: []lsblk.BlockDevice{
+				{KName: "/dev/sdb", Type: "disk", Children: []lsblk.BlockDevice{{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath}}},
+				{KName: "/dev/sdc", Type: "disk", Children: []lsblk.BlockDevice{{KName: "/dev/dm-0", Type: lsblk.DeviceTypeMultipath}}},
+			},
+			expectErr: true,
+		},
 	}
 	mockWipefs := wipefsmocks.NewMockWipefs(t)
 	mockDmsetup := dmsetupmocks.NewMockDmsetup(t)
@@ -198,7 +218,11 @@ func TestWipeDevices(t *testing.T) {
 			} else {
 				assert.False(t, wiped)
 			}
-			assert.NoError(t, err)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,297 @@
+/*
+Copyright © 2025 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/openshift/lvm-operator/v4/internal/controllers/constants"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ConfigMapName is the name of the ConfigMap in the operator namespace that records the completed migrations.
+// Every key is the ID of a completed migration and its value the Record of its completion.
+const ConfigMapName = "lvms-migrations"
+
+// LastRunAnnotation is the annotation of the ConfigMapName ConfigMap that holds the RunSummary of the last run.
+const LastRunAnnotation = "lvms.openshift.io/last-migration-run"
+
+// Migration is a step of an operator upgrade that runs once before the controllers are started.
+type Migration struct {
+	// ID identifies the migration in the record of completed migrations. It must never change.
+	ID string
+	// MinVersion is the first operator version that runs the migration. Empty means no lower bound.
+	MinVersion string
+	// MaxVersion is the first operator version that no longer runs the migration. Empty means no upper bound.
+	MaxVersion string
+	// Run runs the migration. It must be idempotent, as it is run again if the operator stops before the
+	// completion was recorded.
+	Run func(ctx context.Context) error
+}
+
+// Record is the record of a completed migration.
+type Record struct {
+	// Version is the operator version that completed the migration.
+	Version string `json:"version"`
+	// CompletedAt is the time at which the migration completed.
+	CompletedAt metav1.Time `json:"completedAt"`
+}
+
+type State string
+
+const (
+	// StateCompleted is the state of a migration that was run successfully now or before.
+	StateCompleted State = "Completed"
+	// StateSkipped is the state of a migration that does not apply to the operator version.
+	StateSkipped State = "Skipped"
+	// StateFailed is the state of a migration that failed.
+	StateFailed State = "Failed"
+	// StatePending is the state of a migration that was not run because a previous migration failed.
+	StatePending State = "Pending"
+)
+
+// Result is the outcome of a migration in a run of the Registry.
+type Result struct {
+	ID    string
+	State State
+	// Record is set for completed migrations.
+	Record *Record
+	// Ran is true if the migration was run by this operator process.
+	Ran   bool
+	Error error
+}
+
+// RunSummary is the outcome of the last run of the Registry, recorded in the LastRunAnnotation.
+type RunSummary struct {
+	// Version is the operator version of the run.
+	Version string `json:"version"`
+	// Time is the time at which the run finished.
+	Time metav1.Time `json:"time"`
+	// Migrations is the outcome of every registered migration in the run.
+	Migrations []MigrationSummary `json:"migrations"`
+}
+
+// MigrationSummary is the outcome of a migration in a RunSummary.
+type MigrationSummary struct {
+	ID    string `json:"id"`
+	State State  `json:"state"`
+	// Ran is true if the migration was run in this run.
+	Ran   bool   `json:"ran,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Registry runs the registered migrations in order and records their completion in the ConfigMapName ConfigMap,
+// so that every migration runs once per cluster. A failed migration stops the run, as later migrations may
+// depend on it, and is retried on the next start of the operator.
+type Registry struct {
+	client     client.Client
+	namespace  string
+	version    string
+	migrations []registeredMigration
+
+	results []Result
+}
+
+type registeredMigration struct {
+	Migration
+	minVersion, maxVersion *semver.Version
+}
+
+// NewRegistry returns a Registry for the migrations, or an error if the version bounds of a migration
+// are not semantic versions.
+func NewRegistry(client client.Client, namespace, version string, migrations ...Migration) (*Registry, error) {
+	registered := make([]registeredMigration, 0, len(migrations))
+	for _, migration := range migrations {
+		m := registeredMigration{Migration: migration}
+		var err error
+		if migration.MinVersion != "" {
+			if m.minVersion, err = semver.NewVersion(migration.MinVersion); err != nil {
+				return nil, fmt.Errorf("invalid minimum version %q of migration %s: %w", migration.MinVersion, migration.ID, err)
+			}
+		}
+		if migration.MaxVersion != "" {
+			if m.maxVersion, err = semver.NewVersion(migration.MaxVersion); err != nil {
+				return nil, fmt.Errorf("invalid maximum version %q of migration %s: %w", migration.MaxVersion, migration.ID, err)
+			}
+		}
+		registered = append(registered, m)
+	}
+	return &Registry{
+		client:     client,
+		namespace:  namespace,
+		version:    version,
+		migrations: registered,
+	}, nil
+}
+
+// Run runs the migrations that were not completed yet and apply to the operator version.
+func (r *Registry) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("version", r.version)
+
+	records, err := r.getRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	results := make([]Result, 0, len(r.migrations))
+	var runErr error
+	for _, migration := range r.migrations {
+		result := Result{ID: migration.ID}
+		switch record, completed := records[migration.ID]; {
+		case runErr != nil:
+			result.State = StatePending
+		case completed:
+			result.State, result.Record = StateCompleted, &record
+		case !r.applies(migration):
+			result.State = StateSkipped
+		default:
+			logger.Info("running migration", "migration", migration.ID)
+			result.Ran = true
+			if err := migration.Run(ctx); err != nil {
+				result.State, result.Error = StateFailed, err
+				runErr = fmt.Errorf("migration %s failed: %w", migration.ID, err)
+				break
+			}
+			record = Record{Version: r.version, CompletedAt: metav1.Now()}
+			if err := r.addRecord(ctx, migration.ID, record); err != nil {
+				result.State, result.Error = StateFailed, err
+				runErr = fmt.Errorf("failed to record the completion of migration %s: %w", migration.ID, err)
+				break
+			}
+			result.State, result.Record = StateCompleted, &record
+			logger.Info("migration completed", "migration", migration.ID)
+		}
+		results = append(results, result)
+	}
+
+	r.results = results
+
+	if err := r.recordSummary(ctx, results); err != nil {
+		return errors.Join(runErr, fmt.Errorf("failed to record the summary of the migration run: %w", err))
+	}
+	return runErr
+}
+
+// Results returns the result of every registered migration in the last run.
+func (r *Registry) Results() []Result {
+	return append([]Result(nil), r.results...)
+}
+
+// applies returns whether the operator version is within the version bounds of the migration.
+// Operator versions that are not semantic versions, such as development builds, run all migrations.
+func (r *Registry) applies(migration registeredMigration) bool {
+	version, err := semver.NewVersion(r.version)
+	if err != nil {
+		return true
+	}
+	// pre-releases are compared like the release they precede
+	current := semver.New(version.Major(), version.Minor(), version.Patch(), "", "")
+	if migration.minVersion != nil && current.LessThan(migration.minVersion) {
+		return false
+	}
+	if migration.maxVersion != nil && !current.LessThan(migration.maxVersion) {
+		return false
+	}
+	return true
+}
+
+func (r *Registry) getRecords(ctx context.Context) (map[string]Record, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: ConfigMapName, Namespace: r.namespace}, cm); k8serrors.IsNotFound(err) {
+		return map[string]Record{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the migration record ConfigMap: %w", err)
+	}
+
+	records := make(map[string]Record, len(cm.Data))
+	for id, data := range cm.Data {
+		var record Record
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse the record of migration %s: %w", id, err)
+		}
+		records[id] = record
+	}
+	return records, nil
+}
+
+func (r *Registry) addRecord(ctx context.Context, id string, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return r.updateConfigMap(ctx, func(cm *corev1.ConfigMap) {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[id] = string(data)
+	})
+}
+
+// recordSummary records the outcome of the run in the LastRunAnnotation, so that the migrations run by
+// an upgrade can be inspected after the operator started.
+func (r *Registry) recordSummary(ctx context.Context, results []Result) error {
+	summary := RunSummary{Version: r.version, Time: metav1.Now(), Migrations: make([]MigrationSummary, 0, len(results))}
+	for _, result := range results {
+		migration := MigrationSummary{ID: result.ID, State: result.State, Ran: result.Ran}
+		if result.Error != nil {
+			migration.Error = result.Error.Error()
+		}
+		summary.Migrations = append(summary.Migrations, migration)
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return r.updateConfigMap(ctx, func(cm *corev1.ConfigMap) {
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[LastRunAnnotation] = string(data)
+	})
+}
+
+// updateConfigMap applies update to the ConfigMapName ConfigMap and creates it if it does not exist.
+func (r *Registry) updateConfigMap(ctx context.Context, update func(cm *corev1.ConfigMap)) error {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: ConfigMapName, Namespace: r.namespace}, cm); k8serrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: r.namespace,
+				Labels: map[string]string{
+					constants.AppKubernetesManagedByLabel: constants.ManagedByLabelVal,
+					constants.AppKubernetesPartOfLabel:    constants.PartOfLabelVal,
+				},
+			},
+		}
+		update(cm)
+		return r.client.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+
+	update(cm)
+	return r.client.Update(ctx, cm)
+}
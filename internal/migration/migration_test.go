@@ -0,0 +1,140 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "openshift-lvm-storage"
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestRegistry_Run(t *testing.T) {
+	ctx := context.Background()
+	completed := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: namespace},
+		Data:       map[string]string{"done": `{"version":"4.18.0","completedAt":"2025-01-01T00:00:00Z"}`},
+	}
+	c := newFakeClient(t, completed)
+
+	var ran []string
+	run := func(id string) func(context.Context) error {
+		return func(context.Context) error {
+			ran = append(ran, id)
+			return nil
+		}
+	}
+	registry, err := NewRegistry(c, namespace, "4.19.1-rc.1",
+		Migration{ID: "done", Run: run("done")},
+		Migration{ID: "first", Run: run("first")},
+		Migration{ID: "too-old", MaxVersion: "4.19.0", Run: run("too-old")},
+		Migration{ID: "too-new", MinVersion: "4.20.0", Run: run("too-new")},
+		Migration{ID: "second", MinVersion: "4.19.1", MaxVersion: "4.20.0", Run: run("second")},
+	)
+	require.NoError(t, err)
+	require.NoError(t, registry.Run(ctx))
+	assert.Equal(t, []string{"first", "second"}, ran, "migrations must run in order and only once per cluster")
+
+	states := map[string]State{}
+	for _, result := range registry.Results() {
+		states[result.ID] = result.State
+	}
+	assert.Equal(t, map[string]State{
+		"done":    StateCompleted,
+		"first":   StateCompleted,
+		"too-old": StateSkipped,
+		"too-new": StateSkipped,
+		"second":  StateCompleted,
+	}, states)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(completed), cm))
+	assert.Contains(t, cm.Data, "first")
+	assert.Contains(t, cm.Data, "second")
+	assert.Contains(t, cm.Data, "done")
+
+	var summary RunSummary
+	require.NoError(t, json.Unmarshal([]byte(cm.Annotations[LastRunAnnotation]), &summary))
+	assert.Equal(t, "4.19.1-rc.1", summary.Version)
+	assert.Equal(t, []MigrationSummary{
+		{ID: "done", State: StateCompleted},
+		{ID: "first", State: StateCompleted, Ran: true},
+		{ID: "too-old", State: StateSkipped},
+		{ID: "too-new", State: StateSkipped},
+		{ID: "second", State: StateCompleted, Ran: true},
+	}, summary.Migrations, "the migrations that ran must be recorded in the ConfigMap")
+
+	ran = nil
+	require.NoError(t, registry.Run(ctx))
+	assert.Empty(t, ran, "completed migrations must not run again")
+}
+
+func TestRegistry_RunFailure(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(t)
+
+	failing := true
+	var secondRuns int
+	registry, err := NewRegistry(c, namespace, "unknown",
+		Migration{ID: "first", Run: func(context.Context) error {
+			if failing {
+				return errors.New("failure")
+			}
+			return nil
+		}},
+		Migration{ID: "second", Run: func(context.Context) error {
+			secondRuns++
+			return nil
+		}},
+	)
+	require.NoError(t, err)
+
+	require.Error(t, registry.Run(ctx))
+	results := registry.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, StateFailed, results[0].State)
+	assert.Error(t, results[0].Error)
+	assert.Equal(t, StatePending, results[1].State)
+	assert.Zero(t, secondRuns, "migrations after a failed migration must not run")
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: ConfigMapName, Namespace: namespace}, cm))
+	var summary RunSummary
+	require.NoError(t, json.Unmarshal([]byte(cm.Annotations[LastRunAnnotation]), &summary))
+	assert.Equal(t, []MigrationSummary{
+		{ID: "first", State: StateFailed, Ran: true, Error: "failure"},
+		{ID: "second", State: StatePending},
+	}, summary.Migrations, "failed migrations must be recorded in the ConfigMap")
+
+	failing = false
+	require.NoError(t, registry.Run(ctx))
+	assert.Equal(t, 1, secondRuns)
+	for _, result := range registry.Results() {
+		assert.Equal(t, StateCompleted, result.State)
+		assert.True(t, result.Ran)
+		assert.Equal(t, "unknown", result.Record.Version)
+	}
+}
+
+func TestNewRegistry_InvalidVersion(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	_, err := NewRegistry(newFakeClient(t), namespace, "4.19.0", Migration{ID: "min", MinVersion: "next", Run: run})
+	assert.ErrorContains(t, err, "migration min")
+	_, err = NewRegistry(newFakeClient(t), namespace, "4.19.0", Migration{ID: "max", MaxVersion: "4.x", Run: run})
+	assert.ErrorContains(t, err, "migration max")
+}